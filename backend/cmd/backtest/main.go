@@ -0,0 +1,122 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/app"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/repository"
+	"github.com/valeriapadilla/stock-insights/internal/service"
+	"github.com/valeriapadilla/stock-insights/internal/service/backtest"
+	"github.com/valeriapadilla/stock-insights/internal/validator"
+)
+
+const dateLayout = "2006-01-02"
+
+func main() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	from := flag.String("from", today.AddDate(0, 0, -90).Format(dateLayout), "first as-of date (YYYY-MM-DD)")
+	to := flag.String("to", today.AddDate(0, 0, -30).Format(dateLayout), "last as-of date (YYYY-MM-DD)")
+	stepDays := flag.Int("step-days", 7, "days between as-of dates")
+	horizonDays := flag.Int("horizon-days", 30, "days after each as-of date used to measure outcomes")
+	strategies := flag.String("strategies", "additive,consensus", "comma-separated scoring strategies")
+	daysBack := flag.Int("days-back", 7, "days of analyst events scored on each as-of date")
+	maxResults := flag.Int("max-results", 30, "maximum picks per as-of date")
+	minScore := flag.Int("min-score", 80, "minimum score for a pick")
+	format := flag.String("format", "json", "report format: json or csv")
+	output := flag.String("output", "", "report file (defaults to stdout)")
+	flag.Parse()
+
+	cfg := config.Load()
+
+	app.SetupLogging(cfg)
+	logger := logrus.StandardLogger()
+
+	fromDate, err := time.Parse(dateLayout, *from)
+	if err != nil {
+		logger.WithError(err).Fatal("Invalid -from date")
+	}
+	toDate, err := time.Parse(dateLayout, *to)
+	if err != nil {
+		logger.WithError(err).Fatal("Invalid -to date")
+	}
+	if *format != "json" && *format != "csv" {
+		logger.WithField("format", *format).Fatal("Unsupported report format")
+	}
+
+	backtestCfg := backtest.Config{
+		From:        fromDate,
+		To:          toDate,
+		StepDays:    *stepDays,
+		HorizonDays: *horizonDays,
+		Strategies:  backtest.ParseStrategies(*strategies),
+		Params: validator.RecommendationParams{
+			DaysBack:   *daysBack,
+			MaxResults: *maxResults,
+			MinScore:   *minScore,
+		},
+	}
+	if err := backtestCfg.Validate(); err != nil {
+		logger.WithError(err).Fatal("Invalid backtest configuration")
+	}
+
+	if err := database.Connect(); err != nil {
+		logger.WithError(err).Fatal("Failed to connect to database")
+	}
+	defer database.Close()
+
+	stockRepo := repository.NewStockRepository(database.DB)
+	referenceService := service.NewReferenceService(repository.NewReferenceRepository(database.DB), logger)
+	scoringConfigService := service.NewScoringConfigService(repository.NewScoringConfigRepository(database.DB), cfg.ScoringConfigFile, logger)
+
+	recommendationService := service.NewRecommendationService(stockRepo, nil, nil, nil, referenceService, scoringConfigService, logger)
+	backtester := backtest.NewBacktester(recommendationService, stockRepo, logger)
+
+	logger.WithFields(logrus.Fields{
+		"from":         *from,
+		"to":           *to,
+		"step_days":    *stepDays,
+		"horizon_days": *horizonDays,
+		"strategies":   backtestCfg.Strategies,
+	}).Info("Starting recommendation backtest...")
+
+	report, err := backtester.Run(backtestCfg)
+	if err != nil {
+		logger.WithError(err).Fatal("Backtest failed")
+	}
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to create report file")
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	if *format == "csv" {
+		err = backtest.WriteCSV(writer, report)
+	} else {
+		err = backtest.WriteJSON(writer, report)
+	}
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to write backtest report")
+	}
+
+	for _, summary := range report.Strategies {
+		logger.WithFields(logrus.Fields{
+			"strategy":          summary.Strategy,
+			"picks":             summary.Picks,
+			"evaluated":         summary.Evaluated,
+			"hit_rate":          summary.HitRate,
+			"avg_target_change": summary.AvgTargetChangePercent,
+		}).Info("Backtest result")
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/fakeupstream"
+)
+
+// faultFlags collects repeated -fault values.
+type faultFlags []fakeupstream.Fault
+
+func (f *faultFlags) String() string {
+	return ""
+}
+
+func (f *faultFlags) Set(value string) error {
+	fault, err := fakeupstream.ParseFault(value)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, fault)
+	return nil
+}
+
+func main() {
+	var faults faultFlags
+
+	addr := flag.String("addr", ":8090", "address to listen on")
+	fixture := flag.String("fixture", "", "JSON file of events to serve (an array or an {\"items\": [...]} page)")
+	generate := flag.Int("generate", 200, "number of events to generate when no fixture is given")
+	seed := flag.Int64("seed", 1, "seed for generated events, jitter and fault rates")
+	pageSize := flag.Int("page-size", 10, "events per page")
+	latency := flag.Duration("latency", 0, "delay added to every response")
+	jitter := flag.Duration("jitter", 0, "random extra delay up to this value")
+	apiKey := flag.String("api-key", "", "require this key in the Authorization header")
+	recordTarget := flag.String("record", "", "proxy to this upstream URL and record its pages into -dir")
+	dir := flag.String("dir", "testdata/recordings", "directory for recorded pages (-record) or pages to replay (-replay)")
+	replay := flag.Bool("replay", false, "serve the pages recorded in -dir")
+	verbose := flag.Bool("v", false, "log every request")
+	flag.Var(&faults, "fault", "inject a fault, e.g. \"kind=status,status=503,page=2,times=1\" (repeatable)")
+	flag.Parse()
+
+	logger := logrus.StandardLogger()
+	logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	if *verbose {
+		logger.SetLevel(logrus.DebugLevel)
+	}
+
+	options := fakeupstream.Options{
+		PageSize: *pageSize,
+		Latency:  *latency,
+		Jitter:   *jitter,
+		APIKey:   *apiKey,
+		Faults:   faults,
+		Seed:     *seed,
+		Logger:   logger,
+	}
+
+	var handler http.Handler
+	switch {
+	case *recordTarget != "":
+		key := *apiKey
+		if key == "" {
+			key = os.Getenv("EXTERNAL_API_KEY")
+		}
+		recorder, err := fakeupstream.NewRecorder(*recordTarget, key, *dir, logger)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to start recorder")
+		}
+		handler = recorder
+		logger.WithFields(logrus.Fields{"target": *recordTarget, "dir": *dir}).Info("Recording upstream pages")
+
+	case *replay:
+		server, err := fakeupstream.NewReplay(*dir, options)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to load recordings")
+		}
+		handler = server
+		logger.WithFields(logrus.Fields{"dir": *dir, "pages": len(server.Cursors())}).Info("Replaying recorded pages")
+
+	default:
+		events := fakeupstream.Generate(*generate, *seed, time.Now())
+		if *fixture != "" {
+			loaded, err := fakeupstream.LoadFixture(*fixture)
+			if err != nil {
+				logger.WithError(err).Fatal("Failed to load fixture")
+			}
+			events = loaded
+		}
+		server := fakeupstream.New(events, options)
+		handler = server
+		logger.WithFields(logrus.Fields{"events": len(events), "pages": len(server.Cursors())}).Info("Serving events")
+	}
+
+	for _, fault := range faults {
+		logger.WithFields(logrus.Fields{
+			"kind":  fault.Kind,
+			"page":  fault.Page,
+			"times": fault.Times,
+			"rate":  fault.Rate,
+		}).Info("Fault configured")
+	}
+
+	httpServer := &http.Server{
+		Addr:    *addr,
+		Handler: handler,
+	}
+
+	go func() {
+		logger.WithField("addr", *addr).Info("Fake upstream listening; point EXTERNAL_API_URL at it")
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.WithError(err).Fatal("Fake upstream failed")
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logger.WithError(err).Error("Fake upstream shutdown failed")
+	}
+	logger.Info("Fake upstream stopped")
+}
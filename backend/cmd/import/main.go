@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/app"
+	"github.com/valeriapadilla/stock-insights/internal/client"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/importer"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository"
+	"github.com/valeriapadilla/stock-insights/internal/service"
+	"github.com/valeriapadilla/stock-insights/internal/worker/implementations"
+)
+
+func main() {
+	file := flag.String("file", "", "file to import, or - for stdin")
+	format := flag.String("format", "", "csv, ndjson or json (defaults to the file extension)")
+	source := flag.String("source", model.DefaultImportSource, "source recorded on the imported events")
+	columns := flag.String("columns", "", "CSV column mapping, e.g. \"ticker=Symbol,time=Date\"")
+	dryRun := flag.Bool("dry-run", false, "validate and report without storing anything")
+	output := flag.String("output", "", "report file (defaults to stdout)")
+	flag.Parse()
+
+	cfg := config.Load()
+
+	app.SetupLogging(cfg)
+	logger := logrus.StandardLogger()
+
+	if *file == "" {
+		logger.Fatal("An input -file is required")
+	}
+
+	importFormat := model.ImportFormat(*format)
+	if importFormat == "" {
+		importFormat = importer.DetectFormat(*file)
+	}
+	if !importFormat.IsValid() {
+		logger.WithField("format", *format).Fatal("Unknown import format; pass -format csv, ndjson or json")
+	}
+
+	columnMap, err := importer.ParseColumns(*columns)
+	if err != nil {
+		logger.WithError(err).Fatal("Invalid -columns mapping")
+	}
+
+	var input io.Reader = os.Stdin
+	if *file != "-" {
+		opened, err := os.Open(*file)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to open import file")
+		}
+		defer opened.Close()
+		input = opened
+	}
+
+	if err := database.Connect(); err != nil {
+		logger.WithError(err).Fatal("Failed to connect to database")
+	}
+	defer database.Close()
+
+	// Configured sources are only needed to rank them above imported events
+	sources, err := app.StockSources(cfg, logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to load stock sources")
+	}
+
+	stockCmd := repository.NewStockCommand(database.DB)
+	stockCmd.SetSourcePrecedence(client.SourceNames(sources))
+	referenceService := service.NewReferenceService(repository.NewReferenceRepository(database.DB), logger)
+
+	dataWorker := implementations.NewDataWorker(
+		sources,
+		repository.NewStockRepository(database.DB),
+		stockCmd,
+		repository.NewIngestionCheckpointRepository(database.DB),
+		repository.NewIngestionRunRepository(database.DB),
+		repository.NewStockRejectRepository(database.DB),
+		repository.NewDataQualityRepository(database.DB),
+		referenceService,
+		logger,
+		implementations.DataWorkerConfig{},
+	)
+	importService := service.NewImportService(dataWorker, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		logger.WithField("signal", sig).Info("Received shutdown signal")
+		cancel()
+	}()
+
+	logger.WithFields(logrus.Fields{
+		"file":    *file,
+		"format":  importFormat,
+		"source":  *source,
+		"dry_run": *dryRun,
+	}).Info("Starting stock import...")
+
+	report, err := importService.ImportStocks(ctx, input, model.ImportOptions{
+		Source:  *source,
+		Format:  importFormat,
+		Columns: columnMap,
+		DryRun:  *dryRun,
+	})
+	if err != nil {
+		logger.WithError(err).Fatal("Import failed")
+	}
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		reportFile, err := os.Create(*output)
+		if err != nil {
+			logger.WithError(err).Fatal("Failed to create report file")
+		}
+		defer reportFile.Close()
+		writer = reportFile
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.WithError(err).Fatal("Failed to write import report")
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/app"
+	"github.com/valeriapadilla/stock-insights/internal/client"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/repository"
+	"github.com/valeriapadilla/stock-insights/internal/service"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+const dateLayout = "2006-01-02"
+
+func main() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	from := flag.String("from", today.AddDate(0, 0, -30).Format(dateLayout), "first date to fetch (YYYY-MM-DD)")
+	to := flag.String("to", today.Format(dateLayout), "last date to fetch (YYYY-MM-DD)")
+	tickers := flag.String("tickers", "", "comma-separated tickers (defaults to every covered ticker)")
+	flag.Parse()
+
+	cfg := config.Load()
+
+	app.SetupLogging(cfg)
+	logger := logrus.StandardLogger()
+
+	logger.Info("Starting Price Worker...")
+
+	fromDate, err := time.Parse(dateLayout, *from)
+	if err != nil {
+		logger.WithError(err).Fatal("Invalid -from date")
+	}
+	toDate, err := time.Parse(dateLayout, *to)
+	if err != nil {
+		logger.WithError(err).Fatal("Invalid -to date")
+	}
+
+	provider, err := client.NewPriceProvider(client.PriceProviderConfig{
+		Provider: cfg.PriceProvider,
+		BaseURL:  cfg.PriceAPIURL,
+		APIKey:   cfg.PriceAPIKey,
+		CSVFile:  cfg.PriceCSVFile,
+	}, logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to configure price provider")
+	}
+
+	if err := database.Connect(); err != nil {
+		logger.WithError(err).Fatal("Failed to connect to database")
+	}
+	defer database.Close()
+
+	priceService := service.NewPriceService(repository.NewPriceRepository(database.DB), provider, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		logger.WithField("signal", sig).Info("Received shutdown signal")
+		cancel()
+	}()
+
+	params := interfaces.PriceSyncParams{
+		From: fromDate,
+		To:   toDate,
+	}
+	if *tickers != "" {
+		params.Tickers = strings.Split(*tickers, ",")
+	}
+
+	result, err := priceService.SyncPrices(ctx, params)
+	if err != nil {
+		logger.WithError(err).Fatal("Price sync failed")
+	}
+
+	logger.WithFields(logrus.Fields{
+		"provider": result.Provider,
+		"tickers":  result.Tickers,
+		"bars":     result.Bars,
+		"failed":   result.Failed,
+	}).Info("Price worker completed")
+
+	if len(result.Failed) > 0 {
+		os.Exit(1)
+	}
+}
@@ -33,9 +33,15 @@ func main() {
 	recommendationRepo := repository.NewRecommendationRepository(database.DB)
 	recommendationCmd := repository.NewRecommendationCommand(database.DB, stockRepo)
 
-	recommendationService := service.NewRecommendationService(stockRepo, recommendationRepo, recommendationCmd, logger)
+	referenceService := service.NewReferenceService(repository.NewReferenceRepository(database.DB), logger)
+	scoringConfigService := service.NewScoringConfigService(repository.NewScoringConfigRepository(database.DB), cfg.ScoringConfigFile, logger)
 
-	recommendationWorker := implementations.NewRecommendationWorker(recommendationService, stockRepo, logger)
+	recommendationRunRepo := repository.NewRecommendationRunRepository(database.DB)
+
+	recommendationService := service.NewRecommendationService(stockRepo, recommendationRepo, recommendationCmd, recommendationRunRepo, referenceService, scoringConfigService, logger)
+	recommendationService.SetRetention(cfg.RecommendationRetention)
+
+	recommendationWorker := implementations.NewRecommendationWorker(recommendationService, stockRepo, 0, logger)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -2,58 +2,214 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/valeriapadilla/stock-insights/internal/app"
 	"github.com/valeriapadilla/stock-insights/internal/client"
 	"github.com/valeriapadilla/stock-insights/internal/config"
 	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/quality"
 	"github.com/valeriapadilla/stock-insights/internal/repository"
+	"github.com/valeriapadilla/stock-insights/internal/scheduler"
+	"github.com/valeriapadilla/stock-insights/internal/service"
 	"github.com/valeriapadilla/stock-insights/internal/worker/implementations"
 	workerInterfaces "github.com/valeriapadilla/stock-insights/internal/worker/interfaces"
 )
 
 func main() {
+	source := flag.String("source", "", "ingest only this source (defaults to every configured source)")
+	daemon := flag.Bool("daemon", false, "keep running: ingest on INGESTION_SCHEDULE and calculate recommendations on RECOMMENDATION_SCHEDULE")
+	flag.Parse()
+
 	cfg := config.Load()
 	app.SetupLogging(cfg)
 	logger := logrus.StandardLogger()
 
 	logger.Info("Starting Scheduled Data Worker...")
 
+	schedulerConfig, ingestionSchedule, err := loadSchedulerConfig(cfg)
+	if *daemon && err != nil {
+		log.Fatal("Invalid scheduler configuration:", err)
+	}
+
 	if err := database.Connect(); err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
+	defer database.Close()
 
-	externalClient := client.NewExternalAPIClient(client.ExternalAPIConfig{
-		BaseURL: cfg.ExternalAPIURL,
-		APIKey:  cfg.ExternalAPIKey,
-	}, logger)
+	sources, err := app.StockSources(cfg, logger)
+	if err != nil {
+		log.Fatal("Failed to load stock sources:", err)
+	}
 
 	stockRepo := repository.NewStockRepository(database.DB)
 	stockCmd := repository.NewStockCommand(database.DB)
+	stockCmd.SetSourcePrecedence(client.SourceNames(sources))
+	referenceService := service.NewReferenceService(repository.NewReferenceRepository(database.DB), logger)
+	ingestionRunRepo := repository.NewIngestionRunRepository(database.DB)
+	stockRejectRepo := repository.NewStockRejectRepository(database.DB)
+	dataQualityRepo := repository.NewDataQualityRepository(database.DB)
+
+	workerConfig := implementations.DataWorkerConfig{
+		MaxRetries:       cfg.ExternalAPIMaxRetries,
+		RetryDelay:       cfg.ExternalAPIRetryDelay,
+		RevisionLookback: cfg.IngestionRevisionLookback,
+		Quality: quality.Config{
+			Policy:                 model.DataQualityPolicy(cfg.DataQualityPolicy),
+			MaxTargetChangePercent: cfg.DataQualityMaxTargetChange,
+		},
+	}
+	if *daemon {
+		// A run inside this interval, manual ones included, skips the next slot
+		workerConfig.ScheduleInterval = scheduler.RerunInterval(ingestionSchedule, time.Now().In(schedulerConfig.Location), schedulerConfig.Jitter)
+	}
 
 	dataWorker := implementations.NewDataWorker(
-		externalClient,
+		sources,
 		stockRepo,
 		stockCmd,
+		repository.NewIngestionCheckpointRepository(database.DB),
+		ingestionRunRepo,
+		stockRejectRepo,
+		dataQualityRepo,
+		referenceService,
 		logger,
-		implementations.DataWorkerConfig{},
+		workerConfig,
 	)
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if *daemon {
+		if err := runScheduler(ctx, cfg, schedulerConfig, ingestionSchedule, dataWorker, *source, logger); err != nil {
+			log.Fatal("Scheduler failed:", err)
+		}
+		return
+	}
 
-	if err := runIngestion(ctx, dataWorker, logger); err != nil {
+	if err := runIngestion(ctx, dataWorker, *source, logger); err != nil {
 		log.Fatal("Ingestion failed:", err)
 	}
 
 	logger.Info("Ingestion completed successfully")
 }
 
-func runIngestion(ctx context.Context, dataWorker workerInterfaces.DataWorker, logger *logrus.Logger) error {
+func loadSchedulerConfig(cfg *config.Config) (scheduler.Config, scheduler.Schedule, error) {
+	location, err := time.LoadLocation(cfg.SchedulerTimezone)
+	if err != nil {
+		return scheduler.Config{}, nil, fmt.Errorf("SCHEDULER_TIMEZONE: %w", err)
+	}
+
+	catchUp := scheduler.CatchUpPolicy(cfg.SchedulerCatchUp)
+	if !catchUp.IsValid() {
+		return scheduler.Config{}, nil, fmt.Errorf("SCHEDULER_CATCH_UP must be one of: skip, once")
+	}
+
+	ingestionSchedule, err := scheduler.ParseCron(cfg.IngestionSchedule)
+	if err != nil {
+		return scheduler.Config{}, nil, fmt.Errorf("INGESTION_SCHEDULE: %w", err)
+	}
+
+	return scheduler.Config{
+		Jitter:          cfg.SchedulerJitter,
+		CatchUp:         catchUp,
+		ShutdownTimeout: cfg.SchedulerShutdownTimeout,
+		Location:        location,
+	}, ingestionSchedule, nil
+}
+
+// runScheduler runs ingestion, and recommendations unless
+// RECOMMENDATION_SCHEDULE is "off", until ctx is cancelled by SIGINT or
+// SIGTERM. Both consult ShouldRun so a recent manual run skips a slot.
+func runScheduler(ctx context.Context, cfg *config.Config, schedulerConfig scheduler.Config, ingestionSchedule scheduler.Schedule, dataWorker workerInterfaces.DataWorker, source string, logger *logrus.Logger) error {
+	s := scheduler.New(schedulerConfig, logger)
+	s.Add(scheduler.Task{
+		Name:     "ingestion",
+		Schedule: ingestionSchedule,
+		ShouldRun: func(ctx context.Context) (bool, error) {
+			return dataWorker.ShouldRun(ctx, source)
+		},
+		Run: func(ctx context.Context) error {
+			return runIngestion(ctx, dataWorker, source, logger)
+		},
+	})
+
+	if cfg.RecommendationSchedule != "off" {
+		recommendationSchedule, err := scheduler.ParseCron(cfg.RecommendationSchedule)
+		if err != nil {
+			return fmt.Errorf("RECOMMENDATION_SCHEDULE: %w", err)
+		}
+		// A published run inside this interval, manual ones included, skips the
+		// next slot
+		interval := scheduler.RerunInterval(recommendationSchedule, time.Now().In(schedulerConfig.Location), schedulerConfig.Jitter)
+		recommendationWorker := newRecommendationWorker(cfg, interval, logger)
+		s.Add(scheduler.Task{
+			Name:      "recommendations",
+			Schedule:  recommendationSchedule,
+			ShouldRun: recommendationWorker.ShouldRun,
+			Run:       recommendationWorker.RunDailyRecommendations,
+		})
+	}
+
+	logger.WithFields(logrus.Fields{
+		"ingestion_schedule":      cfg.IngestionSchedule,
+		"recommendation_schedule": cfg.RecommendationSchedule,
+		"timezone":                schedulerConfig.Location.String(),
+		"jitter":                  schedulerConfig.Jitter.String(),
+		"catch_up":                schedulerConfig.CatchUp,
+	}).Info("Running in scheduler mode")
+
+	return s.Run(ctx)
+}
+
+func newRecommendationWorker(cfg *config.Config, scheduleInterval time.Duration, logger *logrus.Logger) workerInterfaces.RecommendationWorker {
+	stockRepo := repository.NewStockRepository(database.DB)
+	recommendationRepo := repository.NewRecommendationRepository(database.DB)
+	recommendationCmd := repository.NewRecommendationCommand(database.DB, stockRepo)
+	referenceService := service.NewReferenceService(repository.NewReferenceRepository(database.DB), logger)
+	scoringConfigService := service.NewScoringConfigService(repository.NewScoringConfigRepository(database.DB), cfg.ScoringConfigFile, logger)
+	recommendationRunRepo := repository.NewRecommendationRunRepository(database.DB)
+
+	recommendationService := service.NewRecommendationService(stockRepo, recommendationRepo, recommendationCmd, recommendationRunRepo, referenceService, scoringConfigService, logger)
+	recommendationService.SetRetention(cfg.RecommendationRetention)
+
+	return implementations.NewRecommendationWorker(recommendationService, stockRepo, scheduleInterval, logger)
+}
+
+func runIngestion(ctx context.Context, dataWorker workerInterfaces.DataWorker, source string, logger *logrus.Logger) error {
 	logger.Info("Starting scheduled ingestion...")
 
-	if err := dataWorker.FetchAndProcessStocks(ctx); err != nil {
+	var results []*model.IngestionResult
+	var err error
+	if source != "" {
+		var result *model.IngestionResult
+		result, err = dataWorker.FetchAndProcessSource(ctx, source, nil)
+		if result != nil {
+			results = append(results, result)
+		}
+	} else {
+		results, err = dataWorker.FetchAndProcessStocks(ctx, nil)
+	}
+
+	for _, result := range results {
+		logger.WithFields(logrus.Fields{
+			"source":        result.Source,
+			"pages_fetched": result.PagesFetched,
+			"pages_skipped": result.PagesSkipped,
+			"pages_resumed": result.PagesResumed,
+			"resumed":       result.Resumed,
+			"stocks_saved":  result.StocksSaved,
+		}).Info("Source ingestion finished")
+	}
+
+	if err != nil {
 		logger.WithError(err).Error("Failed to complete ingestion")
 		return err
 	}
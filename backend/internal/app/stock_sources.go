@@ -0,0 +1,40 @@
+package app
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/client"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+)
+
+// StockSources builds the ingestion sources in precedence order, highest
+// first. Without STOCK_SOURCES_FILE the single EXTERNAL_API_URL source is used.
+func StockSources(cfg *config.Config, logger *logrus.Logger) ([]client.StockSource, error) {
+	defaults := client.ExternalAPIConfig{
+		BaseURL:    cfg.ExternalAPIURL,
+		APIKey:     cfg.ExternalAPIKey,
+		MaxRetries: cfg.ExternalAPIMaxRetries,
+		RetryDelay: cfg.ExternalAPIRetryDelay,
+		CircuitBreaker: client.CircuitBreakerConfig{
+			FailureThreshold: cfg.ExternalAPICircuitFailureThreshold,
+			OpenTimeout:      cfg.ExternalAPICircuitOpenTimeout,
+			HalfOpenRequests: cfg.ExternalAPICircuitHalfOpenRequests,
+		},
+	}
+
+	if cfg.StockSourcesFile == "" {
+		return []client.StockSource{client.NewExternalAPIClient(defaults, logger)}, nil
+	}
+
+	sourcesConfig, err := client.LoadStockSourcesFile(cfg.StockSourcesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := client.NewStockSources(sourcesConfig, defaults, logger)
+	logger.WithFields(logrus.Fields{
+		"file":    cfg.StockSourcesFile,
+		"sources": client.SourceNames(sources),
+	}).Info("Loaded stock sources")
+
+	return sources, nil
+}
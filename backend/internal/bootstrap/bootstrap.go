@@ -9,6 +9,8 @@ import (
 	"github.com/valeriapadilla/stock-insights/internal/client"
 	"github.com/valeriapadilla/stock-insights/internal/config"
 	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/quality"
 	"github.com/valeriapadilla/stock-insights/internal/repository"
 	"github.com/valeriapadilla/stock-insights/internal/server"
 	"github.com/valeriapadilla/stock-insights/internal/service"
@@ -29,28 +31,44 @@ func Bootstrap(cfg *config.Config) *App {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	externalClient := client.NewExternalAPIClient(client.ExternalAPIConfig{
-		BaseURL: cfg.ExternalAPIURL,
-		APIKey:  cfg.ExternalAPIKey,
-	}, logger)
+	sources, err := app.StockSources(cfg, logger)
+	if err != nil {
+		log.Fatal("Failed to load stock sources:", err)
+	}
 
 	stockRepo := repository.NewStockRepository(database.DB)
 	stockCmd := repository.NewStockCommand(database.DB)
+	stockCmd.SetSourcePrecedence(client.SourceNames(sources))
+	referenceService := service.NewReferenceService(repository.NewReferenceRepository(database.DB), logger)
+	ingestionRunRepo := repository.NewIngestionRunRepository(database.DB)
+	stockRejectRepo := repository.NewStockRejectRepository(database.DB)
+	dataQualityRepo := repository.NewDataQualityRepository(database.DB)
 
 	dataWorker := implementations.NewDataWorker(
-		externalClient,
+		sources,
 		stockRepo,
 		stockCmd,
+		repository.NewIngestionCheckpointRepository(database.DB),
+		ingestionRunRepo,
+		stockRejectRepo,
+		dataQualityRepo,
+		referenceService,
 		logger,
 		implementations.DataWorkerConfig{
 			ScheduleInterval: 24 * time.Hour,
-			MaxRetries:       3,
-			RetryDelay:       5 * time.Second,
+			MaxRetries:       cfg.ExternalAPIMaxRetries,
+			RetryDelay:       cfg.ExternalAPIRetryDelay,
+			RevisionLookback: cfg.IngestionRevisionLookback,
+			Quality: quality.Config{
+				Policy:                 model.DataQualityPolicy(cfg.DataQualityPolicy),
+				MaxTargetChangePercent: cfg.DataQualityMaxTargetChange,
+			},
 		},
 	)
 
-	ingestionService := service.NewIngestionService(dataWorker, logger)
-	srv := server.NewServer(cfg, ingestionService, logger)
+	ingestionService := service.NewIngestionService(dataWorker, ingestionRunRepo, stockRejectRepo, dataQualityRepo, logger)
+	importService := service.NewImportService(dataWorker, logger)
+	srv := server.NewServer(cfg, ingestionService, importService, logger)
 
 	return &App{
 		config: cfg,
@@ -0,0 +1,218 @@
+package client
+
+import (
+	stderrors "errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+const (
+	defaultCircuitFailureThreshold = 5
+	defaultCircuitOpenTimeout      = 30 * time.Second
+	defaultCircuitHalfOpenRequests = 1
+)
+
+// ErrCircuitOpen is wrapped by the errors of requests a circuit breaker
+// rejected without calling the upstream.
+var ErrCircuitOpen = stderrors.New("circuit breaker open")
+
+// CircuitBreakerConfig tunes a CircuitBreaker. Zero values select the
+// defaults.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures that opens the
+	// breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting trial
+	// requests through.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of trial requests allowed at once while
+	// half-open.
+	HalfOpenRequests int
+}
+
+func (c CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = defaultCircuitFailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = defaultCircuitOpenTimeout
+	}
+	if c.HalfOpenRequests <= 0 {
+		c.HalfOpenRequests = defaultCircuitHalfOpenRequests
+	}
+	return c
+}
+
+// CircuitBreaker stops calls to an upstream after consecutive failures and
+// probes it again once OpenTimeout has passed. Every Allow that returns nil
+// must be followed by exactly one of Success, Failure or Release. It is safe
+// for concurrent use.
+type CircuitBreaker struct {
+	mu       sync.Mutex
+	name     string
+	config   CircuitBreakerConfig
+	state    model.CircuitState
+	failures int
+	inFlight int
+	openedAt time.Time
+
+	lastError     string
+	lastFailureAt time.Time
+	successes     int64
+	failuresTotal int64
+	rejected      int64
+	opens         int64
+
+	now func() time.Time
+}
+
+// NewCircuitBreaker returns a closed breaker for the upstream called name.
+func NewCircuitBreaker(name string, config CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:   name,
+		config: config.withDefaults(),
+		state:  model.CircuitClosed,
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether a request may be made. An open breaker whose timeout
+// has passed turns half-open and admits up to HalfOpenRequests trials.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == model.CircuitOpen && !b.now().Before(b.retryAt()) {
+		b.state = model.CircuitHalfOpen
+		b.inFlight = 0
+	}
+
+	switch b.state {
+	case model.CircuitOpen:
+		b.rejected++
+		return b.openError()
+	case model.CircuitHalfOpen:
+		if b.inFlight >= b.config.HalfOpenRequests {
+			b.rejected++
+			return b.openError()
+		}
+		b.inFlight++
+	}
+	return nil
+}
+
+// Check returns the error Allow would return while the breaker is open, but
+// neither changes its state nor counts a rejection.
+func (b *CircuitBreaker) Check() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == model.CircuitOpen && b.now().Before(b.retryAt()) {
+		return b.openError()
+	}
+	return nil
+}
+
+// Success records that an allowed request reached a working upstream. A
+// half-open breaker closes.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.successes++
+	b.failures = 0
+	if b.state == model.CircuitHalfOpen {
+		b.state = model.CircuitClosed
+		b.inFlight = 0
+	}
+}
+
+// Failure records that an allowed request found the upstream unavailable. It
+// opens a closed breaker at FailureThreshold consecutive failures and a
+// half-open one at once.
+func (b *CircuitBreaker) Failure(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failuresTotal++
+	b.failures++
+	b.lastFailureAt = b.now()
+	if err != nil {
+		b.lastError = err.Error()
+	}
+
+	switch b.state {
+	case model.CircuitHalfOpen:
+		b.open()
+	case model.CircuitClosed:
+		if b.failures >= b.config.FailureThreshold {
+			b.open()
+		}
+	}
+}
+
+// Release ends an allowed request that says nothing about the upstream's
+// health, such as one cancelled by the caller.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == model.CircuitHalfOpen && b.inFlight > 0 {
+		b.inFlight--
+	}
+}
+
+// State returns the current state without advancing an expired open state.
+func (b *CircuitBreaker) State() model.CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Status returns a snapshot of the breaker.
+func (b *CircuitBreaker) Status() model.CircuitBreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := model.CircuitBreakerStatus{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		FailureThreshold:    b.config.FailureThreshold,
+		LastError:           b.lastError,
+		Successes:           b.successes,
+		Failures:            b.failuresTotal,
+		Rejected:            b.rejected,
+		Opens:               b.opens,
+	}
+	if b.state != model.CircuitClosed {
+		openedAt, retryAt := b.openedAt, b.retryAt()
+		status.OpenedAt = &openedAt
+		status.RetryAt = &retryAt
+	}
+	if !b.lastFailureAt.IsZero() {
+		lastFailureAt := b.lastFailureAt
+		status.LastFailureAt = &lastFailureAt
+	}
+	return status
+}
+
+// open moves to the open state. Callers hold b.mu.
+func (b *CircuitBreaker) open() {
+	b.state = model.CircuitOpen
+	b.openedAt = b.now()
+	b.inFlight = 0
+	b.opens++
+}
+
+func (b *CircuitBreaker) retryAt() time.Time {
+	return b.openedAt.Add(b.config.OpenTimeout)
+}
+
+func (b *CircuitBreaker) openError() *errors.AppError {
+	return errors.NewExternalError(fmt.Sprintf("circuit breaker open for source %s after %d consecutive failures, retry after %s",
+		b.name, b.failures, b.retryAt().UTC().Format(time.RFC3339)), ErrCircuitOpen)
+}
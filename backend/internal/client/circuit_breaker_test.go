@@ -0,0 +1,98 @@
+package client
+
+import (
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func newTestCircuitBreaker(config CircuitBreakerConfig) (*CircuitBreaker, *time.Time) {
+	now := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker("test_source", config)
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestCircuitBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	breaker, now := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 3, OpenTimeout: time.Minute})
+	failure := stderrors.New("API returned status 503")
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, breaker.Allow())
+		breaker.Failure(failure)
+	}
+	// A success resets the streak
+	require.NoError(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, model.CircuitClosed, breaker.State())
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, breaker.Allow())
+		breaker.Failure(failure)
+	}
+	assert.Equal(t, model.CircuitOpen, breaker.State())
+
+	err := breaker.Allow()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Contains(t, err.Error(), "EXTERNAL_ERROR")
+	assert.Contains(t, err.Error(), "test_source")
+	assert.Contains(t, err.Error(), "retry after 2025-03-12T10:01:00Z")
+
+	status := breaker.Status()
+	assert.Equal(t, model.CircuitOpen, status.State)
+	assert.Equal(t, 3, status.ConsecutiveFailures)
+	assert.Equal(t, 3, status.FailureThreshold)
+	assert.Equal(t, "API returned status 503", status.LastError)
+	assert.Equal(t, int64(1), status.Successes)
+	assert.Equal(t, int64(5), status.Failures)
+	assert.Equal(t, int64(1), status.Rejected)
+	assert.Equal(t, int64(1), status.Opens)
+	require.NotNil(t, status.RetryAt)
+	assert.Equal(t, now.Add(time.Minute), *status.RetryAt)
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	breaker, now := newTestCircuitBreaker(CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	require.NoError(t, breaker.Allow())
+	breaker.Failure(stderrors.New("timeout"))
+	assert.Error(t, breaker.Check())
+
+	// After the timeout a single trial request is let through
+	*now = now.Add(time.Minute)
+	assert.NoError(t, breaker.Check())
+	require.NoError(t, breaker.Allow())
+	assert.Equal(t, model.CircuitHalfOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen)
+
+	// A failed trial opens the breaker again for another timeout
+	breaker.Failure(stderrors.New("timeout"))
+	assert.Equal(t, model.CircuitOpen, breaker.State())
+	assert.Equal(t, int64(2), breaker.Status().Opens)
+
+	*now = now.Add(time.Minute)
+	require.NoError(t, breaker.Allow())
+
+	// A released trial frees its slot without closing the breaker
+	breaker.Release()
+	assert.Equal(t, model.CircuitHalfOpen, breaker.State())
+	require.NoError(t, breaker.Allow())
+
+	// A successful trial closes it
+	breaker.Success()
+	assert.Equal(t, model.CircuitClosed, breaker.State())
+	assert.NoError(t, breaker.Allow())
+	assert.Nil(t, breaker.Status().RetryAt)
+}
+
+func TestCircuitBreakerConfig_Defaults(t *testing.T) {
+	config := CircuitBreakerConfig{}.withDefaults()
+	assert.Equal(t, 5, config.FailureThreshold)
+	assert.Equal(t, 30*time.Second, config.OpenTimeout)
+	assert.Equal(t, 1, config.HalfOpenRequests)
+}
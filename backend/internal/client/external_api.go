@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -13,18 +15,46 @@ import (
 	"github.com/valeriapadilla/stock-insights/internal/model"
 )
 
+// pageInterval spaces consecutive page requests to stay polite to the API.
+const pageInterval = 100 * time.Millisecond
+
+var (
+	_ StockSource     = (*ExternalAPIClient)(nil)
+	_ CircuitBreaking = (*ExternalAPIClient)(nil)
+)
+
 type ExternalAPIClient struct {
-	baseURL    string
-	apiKey     string
-	httpClient *http.Client
-	logger     *logrus.Logger
+	name        string
+	baseURL     string
+	apiKey      string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	breaker     *CircuitBreaker
+	sleep       func(ctx context.Context, delay time.Duration) error
+	logger      *logrus.Logger
 }
 
 type ExternalAPIConfig struct {
-	BaseURL    string
-	APIKey     string
-	Timeout    time.Duration
-	MaxRetries int
+	// Name identifies the client as a StockSource; defaults to
+	// model.DefaultStockSource.
+	Name          string
+	BaseURL       string
+	APIKey        string
+	Timeout       time.Duration
+	MaxRetries    int
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	// CircuitBreaker makes requests fail fast while the API keeps failing.
+	CircuitBreaker CircuitBreakerConfig
+}
+
+// CircuitBreaking is implemented by sources guarded by a CircuitBreaker.
+type CircuitBreaking interface {
+	// CircuitStatus returns a snapshot of the source's breaker.
+	CircuitStatus() model.CircuitBreakerStatus
+	// CheckCircuit returns the breaker's EXTERNAL_ERROR while it is open and
+	// still rejecting requests, without counting a rejection.
+	CheckCircuit() error
 }
 
 func NewExternalAPIClient(config ExternalAPIConfig, logger *logrus.Logger) *ExternalAPIClient {
@@ -32,128 +62,324 @@ func NewExternalAPIClient(config ExternalAPIConfig, logger *logrus.Logger) *Exte
 		Timeout: config.Timeout,
 	}
 
+	name := config.Name
+	if name == "" {
+		name = model.DefaultStockSource
+	}
+
 	return &ExternalAPIClient{
+		name:       name,
 		baseURL:    config.BaseURL,
 		apiKey:     config.APIKey,
 		httpClient: httpClient,
-		logger:     logger,
+		retryPolicy: RetryPolicy{
+			MaxRetries: config.MaxRetries,
+			BaseDelay:  config.RetryDelay,
+			MaxDelay:   config.MaxRetryDelay,
+		}.withDefaults(),
+		breaker: NewCircuitBreaker(name, config.CircuitBreaker),
+		sleep:   sleepContext,
+		logger:  logger,
 	}
 }
 
+func (c *ExternalAPIClient) Name() string {
+	return c.name
+}
+
+// SetRetryPolicy replaces the retry policy given by ExternalAPIConfig.
+func (c *ExternalAPIClient) SetRetryPolicy(policy RetryPolicy) {
+	c.retryPolicy = policy.withDefaults()
+}
+
+func (c *ExternalAPIClient) CircuitStatus() model.CircuitBreakerStatus {
+	return c.breaker.Status()
+}
+
+func (c *ExternalAPIClient) CheckCircuit() error {
+	return c.breaker.Check()
+}
+
+// StockPage is one page of analyst events delivered by StreamStocks.
+type StockPage struct {
+	// Number counts pages from the first page of the stream's starting run.
+	Number int
+	// Cursor is the next_page value that fetched this page, empty for the first.
+	Cursor string
+	// NextPage is the cursor of the following page, empty on the last page.
+	NextPage string
+	Items    []model.Stock
+}
+
+// ErrStopStream may be returned by a StreamStocks handler to end the stream
+// early without an error.
+var ErrStopStream = stderrors.New("stop stream")
+
 func (c *ExternalAPIClient) GetAllStocks(ctx context.Context) ([]model.Stock, error) {
 	var allStocks []model.Stock
-	nextPage := ""
 	pageCount := 0
 
-	for {
-		url := c.baseURL
-		if nextPage != "" {
-			url = fmt.Sprintf("%s?next_page=%s", c.baseURL, nextPage)
+	err := c.StreamStocks(ctx, func(page *StockPage) error {
+		allStocks = append(allStocks, page.Items...)
+		pageCount++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.WithFields(logrus.Fields{
+		"total_stocks": len(allStocks),
+		"total_pages":  pageCount,
+	}).Info("Successfully retrieved all stocks from external API")
+
+	return allStocks, nil
+}
+
+// StreamStocks walks every page from the first one, handing each page to
+// handle before requesting the next, so only one page is held at a time.
+func (c *ExternalAPIClient) StreamStocks(ctx context.Context, handle func(page *StockPage) error) error {
+	return c.StreamStocksFrom(ctx, "", 1, handle)
+}
+
+// StreamStocksFrom is StreamStocks starting at the cursor of page number
+// firstPage. An error from handle stops the stream and is returned, except
+// ErrStopStream which stops it cleanly.
+func (c *ExternalAPIClient) StreamStocksFrom(ctx context.Context, cursor string, firstPage int, handle func(page *StockPage) error) error {
+	for number := firstPage; ; number++ {
+		if number > firstPage {
+			if err := c.sleep(ctx, pageInterval); err != nil {
+				return err
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
 		}
 
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		response, err := c.GetStocksPage(ctx, cursor, number)
 		if err != nil {
-			return nil, errors.NewInternalError("failed to create request", err)
+			return err
 		}
 
-		if c.apiKey != "" {
-			req.Header.Set("Authorization", c.apiKey)
+		c.logger.WithFields(logrus.Fields{
+			"page":          number,
+			"items_in_page": len(response.Items),
+			"has_next_page": response.NextPage != "",
+		}).Debug("Retrieved page from external API")
+
+		page := &StockPage{
+			Number:   number,
+			Cursor:   cursor,
+			NextPage: response.NextPage,
+			Items:    response.Items,
+		}
+		if err := handle(page); err != nil {
+			if err == ErrStopStream {
+				return nil
+			}
+			return err
+		}
+
+		if response.NextPage == "" {
+			return nil
 		}
-		req.Header.Set("Content-Type", "application/json")
+		cursor = response.NextPage
+	}
+}
 
+// GetStocksPage fetches the single page addressed by the nextPage cursor; an
+// empty cursor fetches the first page. Retryable failures are retried under
+// the client's retry policy; page only labels the attempts in the logs.
+func (c *ExternalAPIClient) GetStocksPage(ctx context.Context, nextPage string, page int) (*model.ExternalAPIResponse, error) {
+	requestURL := c.baseURL
+	if nextPage != "" {
+		requestURL = fmt.Sprintf("%s?next_page=%s", c.baseURL, url.QueryEscape(nextPage))
+	}
+
+	maxAttempts := c.retryPolicy.MaxRetries + 1
+	for attempt := 1; ; attempt++ {
 		c.logger.WithFields(logrus.Fields{
-			"url":       url,
-			"method":    "GET",
-			"page":      pageCount + 1,
-			"next_page": nextPage,
+			"url":          requestURL,
+			"method":       "GET",
+			"page":         page,
+			"next_page":    nextPage,
+			"attempt":      attempt,
+			"max_attempts": maxAttempts,
 		}).Debug("Making external API request")
 
-		resp, err := c.httpClient.Do(req)
-		if err != nil {
-			c.logger.WithError(err).Error("HTTP request failed")
-			return nil, errors.NewExternalError("failed to make HTTP request", err)
+		if err := c.breaker.Allow(); err != nil {
+			c.logger.WithError(err).WithField("page", page).Warn("External API request rejected by circuit breaker")
+			return nil, err
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			errorMsg := fmt.Sprintf("API returned status %d: %s", resp.StatusCode, string(body))
+		response, err := c.fetchStocksPage(ctx, requestURL)
+		c.recordOutcome(ctx, err)
+		if err == nil {
+			return response, nil
+		}
 
-			c.logger.WithFields(logrus.Fields{
-				"status_code":   resp.StatusCode,
-				"response_body": string(body),
-				"url":           url,
-			}).Error("External API error")
+		fields := logrus.Fields{
+			"page":         page,
+			"attempt":      attempt,
+			"max_attempts": maxAttempts,
+		}
+		if !err.Retryable || attempt >= maxAttempts || ctx.Err() != nil {
+			c.logger.WithError(err).WithFields(fields).Error("External API request failed")
+			return nil, err
+		}
 
-			return nil, errors.NewExternalError(errorMsg, nil)
+		// No point waiting out a backoff the breaker will reject anyway
+		if breakerErr := c.breaker.Check(); breakerErr != nil {
+			c.logger.WithError(err).WithFields(fields).Error("External API request failed, circuit breaker opened")
+			return nil, breakerErr
 		}
 
-		var apiResponse model.ExternalAPIResponse
-		if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
-			c.logger.WithError(err).Error("Failed to decode API response")
-			return nil, errors.NewInternalError("failed to decode response", err)
+		delay, ok := c.retryPolicy.retryDelay(attempt, err.RetryAfter)
+		fields["retry_in"] = delay.String()
+		if !ok {
+			c.logger.WithError(err).WithFields(fields).Error("External API request failed, Retry-After exceeds the maximum retry delay")
+			return nil, err
 		}
+		c.logger.WithError(err).WithFields(fields).Warn("External API request failed, retrying")
 
-		allStocks = append(allStocks, apiResponse.Items...)
-		pageCount++
+		if sleepErr := c.sleep(ctx, delay); sleepErr != nil {
+			return nil, errors.NewExternalError("external API retry cancelled", sleepErr)
+		}
+	}
+}
 
-		c.logger.WithFields(logrus.Fields{
-			"page":          pageCount,
-			"items_in_page": len(apiResponse.Items),
-			"total_items":   len(allStocks),
-			"has_next_page": apiResponse.NextPage != "",
-		}).Info("Retrieved page from external API")
+// recordOutcome reports a request to the circuit breaker. Only retryable
+// failures count against the upstream; cancelled requests say nothing about
+// it and fatal ones, such as a bad API key, mean it answered.
+func (c *ExternalAPIClient) recordOutcome(ctx context.Context, err *errors.AppError) {
+	switch {
+	case ctx.Err() != nil:
+		c.breaker.Release()
+	case err == nil || !err.Retryable:
+		c.breaker.Success()
+	default:
+		c.breaker.Failure(err)
+	}
+}
 
-		if apiResponse.NextPage == "" {
-			break
+// fetchStocksPage performs one request and classifies its failure: network
+// errors, 408, 429, 5xx and bodies cut short are retryable, anything else is
+// fatal.
+func (c *ExternalAPIClient) fetchStocksPage(ctx context.Context, requestURL string) (*model.ExternalAPIResponse, *errors.AppError) {
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to create request", err)
+	}
+
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", c.apiKey)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, errors.NewExternalError("failed to make HTTP request", err)
 		}
-		if pageCount > 1 {
-			time.Sleep(100 * time.Millisecond)
+		return nil, errors.NewRetryableExternalError("failed to make HTTP request", err, 0)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		errorMsg := fmt.Sprintf("API returned status %d: %s", resp.StatusCode, string(body))
+
+		c.logger.WithFields(logrus.Fields{
+			"status_code":   resp.StatusCode,
+			"response_body": string(body),
+			"url":           requestURL,
+		}).Debug("External API error")
+
+		if !retryableStatus(resp.StatusCode) {
+			return nil, errors.NewExternalError(errorMsg, nil)
 		}
 
-		nextPage = apiResponse.NextPage
+		var retryAfter time.Duration
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		return nil, errors.NewRetryableExternalError(errorMsg, nil, retryAfter)
 	}
 
-	c.logger.WithFields(logrus.Fields{
-		"total_stocks": len(allStocks),
-		"total_pages":  pageCount,
-	}).Info("Successfully retrieved all stocks from external API")
+	var apiResponse model.ExternalAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		if stderrors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errors.NewRetryableExternalError("truncated response", err, 0)
+		}
+		return nil, errors.NewInternalError("failed to decode response", err)
+	}
 
-	return allStocks, nil
+	return &apiResponse, nil
 }
 
+// HealthCheck probes the API with a HEAD request, falling back to GET when
+// HEAD is not supported. It fails fast while the circuit breaker is open, but
+// only reads the breaker: its outcome is not recorded, so it neither opens
+// the breaker nor uses up a half-open trial meant for ingestion.
 func (c *ExternalAPIClient) HealthCheck(ctx context.Context) error {
-	url := c.baseURL
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return errors.NewInternalError("failed to create health check request", err)
+	if err := c.breaker.Check(); err != nil {
+		return err
 	}
 
-	if c.apiKey != "" {
-		req.Header.Set("Authorization", c.apiKey)
+	if err := c.healthCheck(ctx); err != nil {
+		return err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	c.logger.WithField("source", c.name).Info("External API health check passed")
+	return nil
+}
+
+func (c *ExternalAPIClient) healthCheck(ctx context.Context) *errors.AppError {
+	resp, err := c.probe(ctx, http.MethodHead)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented) {
+		resp.Body.Close()
+		resp, err = c.probe(ctx, http.MethodGet)
+	}
 	if err != nil {
-		c.logger.WithError(err).Error("Health check HTTP request failed")
-		return errors.NewExternalError("health check failed", err)
+		c.logger.WithError(err).WithField("source", c.name).Error("Health check HTTP request failed")
+		if ctx.Err() != nil {
+			return errors.NewExternalError("health check failed", err)
+		}
+		return errors.NewRetryableExternalError("health check failed", err, 0)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
 		errorMsg := fmt.Sprintf("health check returned status %d: %s", resp.StatusCode, string(body))
 
 		c.logger.WithFields(logrus.Fields{
+			"source":        c.name,
 			"status_code":   resp.StatusCode,
 			"response_body": string(body),
-			"url":           url,
+			"url":           c.baseURL,
 		}).Error("External API health check failed")
 
+		if retryableStatus(resp.StatusCode) {
+			return errors.NewRetryableExternalError(errorMsg, nil, 0)
+		}
 		return errors.NewExternalError(errorMsg, nil)
 	}
 
-	c.logger.Info("External API health check passed")
+	// A GET fallback returns a whole page; drain it so the connection is reused
+	io.Copy(io.Discard, resp.Body)
 	return nil
 }
+
+func (c *ExternalAPIClient) probe(ctx context.Context, method string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", c.apiKey)
+	}
+
+	return c.httpClient.Do(req)
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -11,6 +12,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
 )
 
 func TestNewExternalAPIClient(t *testing.T) {
@@ -228,7 +231,7 @@ func TestExternalAPIClient_GetAllStocks_InvalidJSON(t *testing.T) {
 
 func TestExternalAPIClient_HealthCheck_Success(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "HEAD", r.Method)
 		assert.Equal(t, "/", r.URL.String())
 		assert.Equal(t, "test-key", r.Header.Get("Authorization"))
 
@@ -275,6 +278,77 @@ func TestExternalAPIClient_HealthCheck_Failure(t *testing.T) {
 	assert.Contains(t, err.Error(), "health check returned status 503")
 }
 
+func TestExternalAPIClient_HealthCheck_FallsBackToGet(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+
+	client := NewExternalAPIClient(ExternalAPIConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, logrus.New())
+
+	require.NoError(t, client.HealthCheck(context.Background()))
+	assert.Equal(t, []string{"HEAD", "GET"}, methods)
+}
+
+func TestExternalAPIClient_HealthCheck_CircuitOpen(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewExternalAPIClient(ExternalAPIConfig{
+		BaseURL:        server.URL,
+		Timeout:        5 * time.Second,
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute},
+	}, logrus.New())
+
+	// Failing health checks do not count towards the breaker
+	ctx := context.Background()
+	assert.Error(t, client.HealthCheck(ctx))
+	assert.Error(t, client.HealthCheck(ctx))
+	assert.Equal(t, model.CircuitClosed, client.breaker.State())
+	assert.Equal(t, 2, callCount)
+
+	client.breaker.Failure(assert.AnError)
+	client.breaker.Failure(assert.AnError)
+
+	err := client.HealthCheck(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, callCount)
+}
+
+func TestExternalAPIClient_HealthCheck_KeepsHalfOpenTrial(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewExternalAPIClient(ExternalAPIConfig{
+		BaseURL:        server.URL,
+		Timeout:        5 * time.Second,
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenRequests: 1},
+	}, logrus.New())
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+	client.breaker.Failure(assert.AnError)
+	now = now.Add(2 * time.Minute)
+
+	require.NoError(t, client.HealthCheck(context.Background()))
+
+	// The trial request is still available to ingestion
+	require.NoError(t, client.breaker.Allow())
+	assert.Equal(t, model.CircuitHalfOpen, client.breaker.State())
+}
+
 func TestExternalAPIClient_WithoutAPIKey(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Empty(t, r.Header.Get("Authorization"))
@@ -304,3 +378,245 @@ func TestExternalAPIClient_WithoutAPIKey(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, stocks, 0)
 }
+
+func newRetryingTestClient(serverURL string, maxRetries int) (*ExternalAPIClient, *[]time.Duration) {
+	client := NewExternalAPIClient(ExternalAPIConfig{
+		BaseURL:    serverURL,
+		Timeout:    5 * time.Second,
+		MaxRetries: maxRetries,
+		RetryDelay: 10 * time.Millisecond,
+	}, logrus.New())
+
+	var delays []time.Duration
+	client.sleep = func(ctx context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return nil
+	}
+	return client, &delays
+}
+
+func TestExternalAPIClient_GetStocksPage_RetriesTransientErrors(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		switch callCount {
+		case 1:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"items": [{"ticker": "AAPL", "time": "2024-01-15T10:30:00Z"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client, delays := newRetryingTestClient(server.URL, 3)
+
+	page, err := client.GetStocksPage(context.Background(), "", 1)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, 3, callCount)
+
+	require.Len(t, *delays, 2)
+	assert.Equal(t, 7*time.Second, (*delays)[0])
+	assert.LessOrEqual(t, (*delays)[1], 20*time.Millisecond)
+}
+
+func TestExternalAPIClient_GetStocksPage_LongRetryAfterFailsFast(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client, delays := newRetryingTestClient(server.URL, 3)
+
+	_, err := client.GetStocksPage(context.Background(), "", 1)
+	require.Error(t, err)
+	assert.True(t, errors.IsRetryable(err))
+	assert.Equal(t, 1, callCount)
+	assert.Empty(t, *delays)
+}
+
+func TestExternalAPIClient_GetStocksPage_FatalErrorNotRetried(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, delays := newRetryingTestClient(server.URL, 3)
+
+	_, err := client.GetStocksPage(context.Background(), "", 1)
+	require.Error(t, err)
+	assert.False(t, errors.IsRetryable(err))
+	assert.Equal(t, 1, callCount)
+	assert.Empty(t, *delays)
+}
+
+func TestExternalAPIClient_GetStocksPage_RetriesTruncatedBody(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		if callCount == 1 {
+			w.Write([]byte(`{"items": [{"ticker": "AA`))
+			return
+		}
+		w.Write([]byte(`{"items": [{"ticker": "AAPL", "time": "2024-01-15T10:30:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	client, delays := newRetryingTestClient(server.URL, 3)
+
+	page, err := client.GetStocksPage(context.Background(), "", 1)
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, 2, callCount)
+	assert.Len(t, *delays, 1)
+}
+
+func TestExternalAPIClient_GetStocksPage_RetriesExhausted(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, delays := newRetryingTestClient(server.URL, 2)
+
+	_, err := client.GetStocksPage(context.Background(), "NEXT", 4)
+	require.Error(t, err)
+	assert.True(t, errors.IsRetryable(err))
+	assert.Contains(t, err.Error(), "API returned status 503")
+	assert.Equal(t, 3, callCount)
+	assert.Len(t, *delays, 2)
+}
+
+func TestExternalAPIClient_StreamStocks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("next_page") {
+		case "":
+			w.Write([]byte(`{"items": [{"ticker": "AAPL"}, {"ticker": "GOOGL"}], "next_page": "GOOGL"}`))
+		case "GOOGL":
+			w.Write([]byte(`{"items": [{"ticker": "MSFT"}], "next_page": "MSFT"}`))
+		default:
+			w.Write([]byte(`{"items": [{"ticker": "NVDA"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := newRetryingTestClient(server.URL, 0)
+
+	var pages []*StockPage
+	err := client.StreamStocks(context.Background(), func(page *StockPage) error {
+		pages = append(pages, page)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, pages, 3)
+	assert.Equal(t, 1, pages[0].Number)
+	assert.Equal(t, "", pages[0].Cursor)
+	assert.Equal(t, "GOOGL", pages[0].NextPage)
+	assert.Len(t, pages[0].Items, 2)
+	assert.Equal(t, "GOOGL", pages[1].Cursor)
+	assert.Equal(t, "", pages[2].NextPage)
+
+	// Stopping early fetches no further pages
+	pages = nil
+	err = client.StreamStocksFrom(context.Background(), "GOOGL", 2, func(page *StockPage) error {
+		pages = append(pages, page)
+		return ErrStopStream
+	})
+	require.NoError(t, err)
+	require.Len(t, pages, 1)
+	assert.Equal(t, 2, pages[0].Number)
+	assert.Equal(t, "MSFT", pages[0].Items[0].Ticker)
+
+	// Handler errors end the stream
+	handlerErr := fmt.Errorf("disk full")
+	err = client.StreamStocks(context.Background(), func(page *StockPage) error {
+		return handlerErr
+	})
+	assert.Equal(t, handlerErr, err)
+
+	// Cancelling the context stops the stream before the next page
+	pages = nil
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = client.StreamStocks(ctx, func(page *StockPage) error {
+		pages = append(pages, page)
+		cancel()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, pages, 1)
+}
+
+func TestExternalAPIClient_GetStocksPage_CircuitBreakerFailsFast(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewExternalAPIClient(ExternalAPIConfig{
+		BaseURL:        server.URL,
+		Timeout:        5 * time.Second,
+		MaxRetries:     5,
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 3, OpenTimeout: time.Minute},
+	}, logrus.New())
+	var delays []time.Duration
+	client.sleep = func(ctx context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return nil
+	}
+
+	// The breaker opens on the third failure, before the retries run out
+	_, err := client.GetStocksPage(context.Background(), "", 1)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, callCount)
+	assert.Len(t, delays, 2)
+
+	appErr, ok := err.(*errors.AppError)
+	require.True(t, ok)
+	assert.Equal(t, errors.ErrorTypeExternal, appErr.Type)
+	assert.False(t, appErr.Retryable)
+
+	// Later requests are rejected without reaching the API
+	_, err = client.GetStocksPage(context.Background(), "", 1)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, callCount)
+
+	status := client.CircuitStatus()
+	assert.Equal(t, model.CircuitOpen, status.State)
+	assert.Equal(t, int64(3), status.Failures)
+	assert.Equal(t, int64(1), status.Rejected)
+	assert.Error(t, client.CheckCircuit())
+}
+
+func TestExternalAPIClient_GetStocksPage_FatalErrorKeepsCircuitClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewExternalAPIClient(ExternalAPIConfig{
+		BaseURL:        server.URL,
+		Timeout:        5 * time.Second,
+		CircuitBreaker: CircuitBreakerConfig{FailureThreshold: 1},
+	}, logrus.New())
+
+	for i := 0; i < 3; i++ {
+		_, err := client.GetStocksPage(context.Background(), "", 1)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrCircuitOpen)
+	}
+	assert.Equal(t, model.CircuitClosed, client.CircuitStatus().State)
+}
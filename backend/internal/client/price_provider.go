@@ -0,0 +1,324 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+const priceDateLayout = "2006-01-02"
+
+// PriceProvider supplies daily OHLCV bars for a ticker. Bars are returned in
+// date order and cover from..to inclusive.
+type PriceProvider interface {
+	Name() string
+	GetDailyBars(ctx context.Context, ticker string, from, to time.Time) ([]*model.PriceBar, error)
+}
+
+type PriceProviderConfig struct {
+	// Provider is "http" or "csv".
+	Provider string
+	BaseURL  string
+	APIKey   string
+	Timeout  time.Duration
+	CSVFile  string
+}
+
+// NewPriceProvider builds the provider selected by config.Provider.
+func NewPriceProvider(config PriceProviderConfig, logger *logrus.Logger) (PriceProvider, error) {
+	switch strings.ToLower(strings.TrimSpace(config.Provider)) {
+	case "http":
+		if config.BaseURL == "" {
+			return nil, fmt.Errorf("price provider base URL is required")
+		}
+		return NewHTTPPriceProvider(config, logger), nil
+	case "csv":
+		if config.CSVFile == "" {
+			return nil, fmt.Errorf("price CSV file is required")
+		}
+		return NewCSVPriceProvider(config.CSVFile, logger), nil
+	case "":
+		return nil, fmt.Errorf("no price provider configured")
+	default:
+		return nil, fmt.Errorf("unknown price provider %q (available: http, csv)", config.Provider)
+	}
+}
+
+// HTTPPriceProvider fetches bars from a market-data API serving
+// GET {base}/{ticker}?from=YYYY-MM-DD&to=YYYY-MM-DD.
+type HTTPPriceProvider struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	logger     *logrus.Logger
+}
+
+var _ PriceProvider = (*HTTPPriceProvider)(nil)
+
+type priceBarsResponse struct {
+	Ticker string `json:"ticker"`
+	Bars   []struct {
+		Date   string  `json:"date"`
+		Open   float64 `json:"open"`
+		High   float64 `json:"high"`
+		Low    float64 `json:"low"`
+		Close  float64 `json:"close"`
+		Volume int64   `json:"volume"`
+	} `json:"bars"`
+}
+
+func NewHTTPPriceProvider(config PriceProviderConfig, logger *logrus.Logger) *HTTPPriceProvider {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	return &HTTPPriceProvider{
+		baseURL:    strings.TrimRight(config.BaseURL, "/"),
+		apiKey:     config.APIKey,
+		httpClient: &http.Client{Timeout: timeout},
+		logger:     logger,
+	}
+}
+
+func (p *HTTPPriceProvider) Name() string {
+	return "http"
+}
+
+func (p *HTTPPriceProvider) GetDailyBars(ctx context.Context, ticker string, from, to time.Time) ([]*model.PriceBar, error) {
+	query := url.Values{}
+	query.Set("from", from.Format(priceDateLayout))
+	query.Set("to", to.Format(priceDateLayout))
+	requestURL := fmt.Sprintf("%s/%s?%s", p.baseURL, url.PathEscape(ticker), query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to create price request", err)
+	}
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", p.apiKey)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	p.logger.WithFields(logrus.Fields{
+		"url":    requestURL,
+		"ticker": ticker,
+	}).Debug("Requesting daily price bars")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.NewExternalError("failed to request price bars", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, errors.NewExternalError(fmt.Sprintf("price API returned status %d: %s", resp.StatusCode, string(body)), nil)
+	}
+
+	var apiResponse priceBarsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, errors.NewInternalError("failed to decode price response", err)
+	}
+
+	bars := make([]*model.PriceBar, 0, len(apiResponse.Bars))
+	for _, raw := range apiResponse.Bars {
+		date, err := time.Parse(priceDateLayout, raw.Date)
+		if err != nil {
+			return nil, errors.NewExternalError(fmt.Sprintf("invalid price date %q for %s", raw.Date, ticker), err)
+		}
+
+		bars = append(bars, &model.PriceBar{
+			Ticker: ticker,
+			Date:   date,
+			Open:   raw.Open,
+			High:   raw.High,
+			Low:    raw.Low,
+			Close:  raw.Close,
+			Volume: raw.Volume,
+			Source: p.Name(),
+		})
+	}
+
+	// Callers rely on oldest-first order, which the API does not promise
+	sort.Slice(bars, func(i, j int) bool {
+		return bars[i].Date.Before(bars[j].Date)
+	})
+	return filterBars(bars, from, to), nil
+}
+
+// CSVPriceProvider serves bars from a local CSV file with the header
+// ticker,date,open,high,low,close,volume. The file is read once, on first use.
+type CSVPriceProvider struct {
+	path   string
+	logger *logrus.Logger
+
+	once     sync.Once
+	loadErr  error
+	byTicker map[string][]*model.PriceBar
+}
+
+var _ PriceProvider = (*CSVPriceProvider)(nil)
+
+func NewCSVPriceProvider(path string, logger *logrus.Logger) *CSVPriceProvider {
+	return &CSVPriceProvider{
+		path:   path,
+		logger: logger,
+	}
+}
+
+func (p *CSVPriceProvider) Name() string {
+	return "csv"
+}
+
+func (p *CSVPriceProvider) GetDailyBars(ctx context.Context, ticker string, from, to time.Time) ([]*model.PriceBar, error) {
+	p.once.Do(p.load)
+	if p.loadErr != nil {
+		return nil, p.loadErr
+	}
+
+	return filterBars(p.byTicker[strings.ToUpper(ticker)], from, to), nil
+}
+
+func (p *CSVPriceProvider) load() {
+	file, err := os.Open(p.path)
+	if err != nil {
+		p.loadErr = errors.NewInternalError("failed to open price CSV file", err)
+		return
+	}
+	defer file.Close()
+
+	bars, err := parsePriceCSV(file, p.Name())
+	if err != nil {
+		p.loadErr = errors.NewValidationError(fmt.Sprintf("invalid price CSV file %s: %v", p.path, err), err)
+		return
+	}
+
+	p.byTicker = make(map[string][]*model.PriceBar)
+	for _, bar := range bars {
+		p.byTicker[bar.Ticker] = append(p.byTicker[bar.Ticker], bar)
+	}
+	for _, tickerBars := range p.byTicker {
+		sort.Slice(tickerBars, func(i, j int) bool {
+			return tickerBars[i].Date.Before(tickerBars[j].Date)
+		})
+	}
+
+	p.logger.WithFields(logrus.Fields{
+		"file":    p.path,
+		"bars":    len(bars),
+		"tickers": len(p.byTicker),
+	}).Info("Loaded price bars from CSV")
+}
+
+func parsePriceCSV(r io.Reader, source string) ([]*model.PriceBar, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"ticker", "date", "open", "high", "low", "close", "volume"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing column %q", required)
+		}
+	}
+
+	var bars []*model.PriceBar
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		bar, err := parsePriceRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		bar.Source = source
+		bars = append(bars, bar)
+	}
+
+	return bars, nil
+}
+
+func parsePriceRecord(record []string, columns map[string]int) (*model.PriceBar, error) {
+	field := func(name string) string {
+		return strings.TrimSpace(record[columns[name]])
+	}
+
+	date, err := time.Parse(priceDateLayout, field("date"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", field("date"))
+	}
+
+	prices := make(map[string]float64, 4)
+	for _, name := range []string{"open", "high", "low", "close"} {
+		value, err := strconv.ParseFloat(field(name), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q", name, field(name))
+		}
+		prices[name] = value
+	}
+
+	volume, err := strconv.ParseInt(field("volume"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid volume %q", field("volume"))
+	}
+
+	ticker := strings.ToUpper(field("ticker"))
+	if ticker == "" {
+		return nil, fmt.Errorf("ticker is required")
+	}
+
+	return &model.PriceBar{
+		Ticker: ticker,
+		Date:   date,
+		Open:   prices["open"],
+		High:   prices["high"],
+		Low:    prices["low"],
+		Close:  prices["close"],
+		Volume: volume,
+	}, nil
+}
+
+// filterBars keeps the bars dated from..to inclusive, compared by day.
+func filterBars(bars []*model.PriceBar, from, to time.Time) []*model.PriceBar {
+	fromDay := from.Format(priceDateLayout)
+	toDay := to.Format(priceDateLayout)
+
+	var filtered []*model.PriceBar
+	for _, bar := range bars {
+		day := bar.Date.Format(priceDateLayout)
+		if day >= fromDay && day <= toDay {
+			filtered = append(filtered, bar)
+		}
+	}
+	return filtered
+}
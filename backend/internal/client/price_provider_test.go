@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func priceDate(value string) time.Time {
+	date, _ := time.Parse(priceDateLayout, value)
+	return date
+}
+
+func TestNewPriceProvider(t *testing.T) {
+	logger := logrus.New()
+
+	provider, err := NewPriceProvider(PriceProviderConfig{Provider: "http", BaseURL: "https://prices.example.com"}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "http", provider.Name())
+
+	provider, err = NewPriceProvider(PriceProviderConfig{Provider: "CSV", CSVFile: "prices.csv"}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, "csv", provider.Name())
+
+	_, err = NewPriceProvider(PriceProviderConfig{Provider: "http"}, logger)
+	assert.Error(t, err)
+
+	_, err = NewPriceProvider(PriceProviderConfig{Provider: "ftp"}, logger)
+	assert.Error(t, err)
+
+	_, err = NewPriceProvider(PriceProviderConfig{}, logger)
+	assert.Error(t, err)
+}
+
+func TestHTTPPriceProvider_GetDailyBars(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/AAPL", r.URL.Path)
+		assert.Equal(t, "2025-03-03", r.URL.Query().Get("from"))
+		assert.Equal(t, "2025-03-04", r.URL.Query().Get("to"))
+		assert.Equal(t, "test-key", r.Header.Get("Authorization"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"ticker": "AAPL",
+			"bars": [
+				{"date": "2025-03-04", "open": 104, "high": 106, "low": 101, "close": 102.5, "volume": 1500},
+				{"date": "2025-03-03", "open": 100, "high": 105, "low": 99, "close": 104, "volume": 1000}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	provider := NewHTTPPriceProvider(PriceProviderConfig{BaseURL: server.URL + "/", APIKey: "test-key"}, logrus.New())
+
+	bars, err := provider.GetDailyBars(context.Background(), "AAPL", priceDate("2025-03-03"), priceDate("2025-03-04"))
+	require.NoError(t, err)
+	require.Len(t, bars, 2)
+	// Bars come back oldest first whatever the API order
+	assert.Equal(t, priceDate("2025-03-03"), bars[0].Date)
+	assert.Equal(t, "AAPL", bars[1].Ticker)
+	assert.Equal(t, 102.5, bars[1].Close)
+	assert.Equal(t, int64(1500), bars[1].Volume)
+	assert.Equal(t, "http", bars[1].Source)
+}
+
+func TestHTTPPriceProvider_GetDailyBarsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/MISSING" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("boom"))
+	}))
+	defer server.Close()
+
+	provider := NewHTTPPriceProvider(PriceProviderConfig{BaseURL: server.URL}, logrus.New())
+
+	bars, err := provider.GetDailyBars(context.Background(), "MISSING", priceDate("2025-03-03"), priceDate("2025-03-04"))
+	assert.NoError(t, err)
+	assert.Empty(t, bars)
+
+	_, err = provider.GetDailyBars(context.Background(), "AAPL", priceDate("2025-03-03"), priceDate("2025-03-04"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "status 500")
+}
+
+func TestCSVPriceProvider_GetDailyBars(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.csv")
+	content := "ticker,date,open,high,low,close,volume\n" +
+		"aapl,2025-03-04,104,106,101,102.5,1500\n" +
+		"AAPL,2025-03-03,100,105,99,104,1000\n" +
+		"MSFT,2025-03-03,400,410,395,405,2000\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	provider := NewCSVPriceProvider(path, logrus.New())
+
+	bars, err := provider.GetDailyBars(context.Background(), "AAPL", priceDate("2025-03-01"), priceDate("2025-03-31"))
+	require.NoError(t, err)
+	require.Len(t, bars, 2)
+	assert.Equal(t, priceDate("2025-03-03"), bars[0].Date)
+	assert.Equal(t, 102.5, bars[1].Close)
+	assert.Equal(t, "csv", bars[0].Source)
+
+	bars, err = provider.GetDailyBars(context.Background(), "AAPL", priceDate("2025-03-04"), priceDate("2025-03-04"))
+	require.NoError(t, err)
+	assert.Len(t, bars, 1)
+}
+
+func TestCSVPriceProvider_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.csv")
+	require.NoError(t, os.WriteFile(path, []byte("ticker,date,close\nAAPL,2025-03-03,104\n"), 0o600))
+
+	provider := NewCSVPriceProvider(path, logrus.New())
+
+	_, err := provider.GetDailyBars(context.Background(), "AAPL", priceDate("2025-03-01"), priceDate("2025-03-31"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "missing column")
+
+	missing := NewCSVPriceProvider(filepath.Join(t.TempDir(), "missing.csv"), logrus.New())
+	_, err = missing.GetDailyBars(context.Background(), "AAPL", priceDate("2025-03-01"), priceDate("2025-03-31"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}.withDefaults()
+
+	for i := 0; i < 20; i++ {
+		first := policy.backoff(1)
+		assert.GreaterOrEqual(t, first, 50*time.Millisecond)
+		assert.LessOrEqual(t, first, 100*time.Millisecond)
+
+		third := policy.backoff(3)
+		assert.GreaterOrEqual(t, third, 200*time.Millisecond)
+		assert.LessOrEqual(t, third, 400*time.Millisecond)
+
+		capped := policy.backoff(10)
+		assert.GreaterOrEqual(t, capped, 500*time.Millisecond)
+		assert.LessOrEqual(t, capped, time.Second)
+	}
+}
+
+func TestRetryPolicy_WithDefaults(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: -1}.withDefaults()
+	assert.Equal(t, 0, policy.MaxRetries)
+	assert.Equal(t, defaultRetryDelay, policy.BaseDelay)
+	assert.Equal(t, defaultMaxRetryDelay, policy.MaxDelay)
+}
+
+func TestRetryPolicy_RetryDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 10 * time.Second}.withDefaults()
+
+	delay, ok := policy.retryDelay(1, 0)
+	assert.True(t, ok)
+	assert.LessOrEqual(t, delay, 100*time.Millisecond)
+
+	delay, ok = policy.retryDelay(1, 7*time.Second)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, delay)
+
+	_, ok = policy.retryDelay(1, time.Hour)
+	assert.False(t, ok)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 7*time.Second, parseRetryAfter("7", now))
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	assert.Zero(t, parseRetryAfter("", now))
+	assert.Zero(t, parseRetryAfter("-3", now))
+	assert.Zero(t, parseRetryAfter("soon", now))
+	assert.Zero(t, parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+}
+
+func TestRetryableStatus(t *testing.T) {
+	for _, code := range []int{408, 429, 500, 502, 503, 504} {
+		assert.True(t, retryableStatus(code), code)
+	}
+	for _, code := range []int{400, 401, 403, 404, 422} {
+		assert.False(t, retryableStatus(code), code)
+	}
+}
@@ -0,0 +1,149 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// StockSource is an upstream of analyst events that can be read page by page
+// from a cursor.
+type StockSource interface {
+	Name() string
+	StreamStocksFrom(ctx context.Context, cursor string, firstPage int, handle func(page *StockPage) error) error
+	HealthCheck(ctx context.Context) error
+}
+
+// StockSourcesConfig lists the configured upstreams. Precedence ranks them,
+// highest first, for events reported by more than one source; sources it
+// omits follow in file order.
+type StockSourcesConfig struct {
+	Precedence []string            `yaml:"precedence"`
+	Sources    []StockSourceConfig `yaml:"sources"`
+}
+
+type StockSourceConfig struct {
+	Name    string        `yaml:"name"`
+	URL     string        `yaml:"url"`
+	APIKey  string        `yaml:"api_key"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// LoadStockSourcesFile reads a YAML or JSON sources file. An api_key written
+// as ${VAR} is read from the environment.
+func LoadStockSourcesFile(path string) (*StockSourcesConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stock sources file: %w", err)
+	}
+
+	var config StockSourcesConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse stock sources file %s: %w", path, err)
+	}
+
+	for i := range config.Sources {
+		config.Sources[i].Name = strings.TrimSpace(config.Sources[i].Name)
+		config.Sources[i].APIKey = os.ExpandEnv(config.Sources[i].APIKey)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid stock sources file %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+func (c *StockSourcesConfig) Validate() error {
+	if len(c.Sources) == 0 {
+		return fmt.Errorf("at least one source is required")
+	}
+
+	names := make(map[string]bool, len(c.Sources))
+	for _, source := range c.Sources {
+		if source.Name == "" {
+			return fmt.Errorf("source name is required")
+		}
+		if names[source.Name] {
+			return fmt.Errorf("duplicate source %q", source.Name)
+		}
+		if source.URL == "" {
+			return fmt.Errorf("source %q has no url", source.Name)
+		}
+		names[source.Name] = true
+	}
+
+	for _, name := range c.Precedence {
+		if !names[name] {
+			return fmt.Errorf("precedence lists unknown source %q", name)
+		}
+	}
+
+	return nil
+}
+
+// Ordered returns the sources sorted by precedence, highest first.
+func (c *StockSourcesConfig) Ordered() []StockSourceConfig {
+	byName := make(map[string]StockSourceConfig, len(c.Sources))
+	for _, source := range c.Sources {
+		byName[source.Name] = source
+	}
+
+	ordered := make([]StockSourceConfig, 0, len(c.Sources))
+	listed := make(map[string]bool, len(c.Precedence))
+	for _, name := range c.Precedence {
+		if source, ok := byName[name]; ok && !listed[name] {
+			ordered = append(ordered, source)
+			listed[name] = true
+		}
+	}
+	for _, source := range c.Sources {
+		if !listed[source.Name] {
+			ordered = append(ordered, source)
+		}
+	}
+
+	return ordered
+}
+
+// PrecedenceOrder returns the source names, highest precedence first.
+func (c *StockSourcesConfig) PrecedenceOrder() []string {
+	ordered := c.Ordered()
+	names := make([]string, len(ordered))
+	for i, source := range ordered {
+		names[i] = source.Name
+	}
+	return names
+}
+
+// NewStockSources builds one client per configured source, in precedence
+// order. Retry settings and unset timeouts come from defaults.
+func NewStockSources(config *StockSourcesConfig, defaults ExternalAPIConfig, logger *logrus.Logger) []StockSource {
+	ordered := config.Ordered()
+	sources := make([]StockSource, 0, len(ordered))
+	for _, source := range ordered {
+		apiConfig := defaults
+		apiConfig.Name = source.Name
+		apiConfig.BaseURL = source.URL
+		apiConfig.APIKey = source.APIKey
+		if source.Timeout > 0 {
+			apiConfig.Timeout = source.Timeout
+		}
+		sources = append(sources, NewExternalAPIClient(apiConfig, logger))
+	}
+	return sources
+}
+
+// SourceNames lists the names of sources, keeping their order.
+func SourceNames(sources []StockSource) []string {
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name()
+	}
+	return names
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadStockSourcesFile(t *testing.T) {
+	t.Setenv("BACKUP_API_KEY", "Bearer backup-key")
+
+	path := filepath.Join(t.TempDir(), "sources.yaml")
+	content := `
+precedence: [backup]
+sources:
+  - name: primary
+    url: https://primary.example.com/list
+    api_key: Bearer primary-key
+  - name: backup
+    url: https://backup.example.com/list
+    api_key: ${BACKUP_API_KEY}
+    timeout: 10s
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	config, err := LoadStockSourcesFile(path)
+	require.NoError(t, err)
+	require.Len(t, config.Sources, 2)
+	assert.Equal(t, "Bearer backup-key", config.Sources[1].APIKey)
+	assert.Equal(t, 10*time.Second, config.Sources[1].Timeout)
+
+	// Listed sources come first, the rest keep file order
+	assert.Equal(t, []string{"backup", "primary"}, config.PrecedenceOrder())
+
+	sources := NewStockSources(config, ExternalAPIConfig{Timeout: 30 * time.Second, MaxRetries: 2}, logrus.New())
+	require.Len(t, sources, 2)
+	assert.Equal(t, []string{"backup", "primary"}, SourceNames(sources))
+
+	backup := sources[0].(*ExternalAPIClient)
+	assert.Equal(t, "https://backup.example.com/list", backup.baseURL)
+	assert.Equal(t, 10*time.Second, backup.httpClient.Timeout)
+	assert.Equal(t, 2, backup.retryPolicy.MaxRetries)
+	assert.Equal(t, 30*time.Second, sources[1].(*ExternalAPIClient).httpClient.Timeout)
+}
+
+func TestStockSourcesConfig_Validate(t *testing.T) {
+	valid := StockSourcesConfig{Sources: []StockSourceConfig{{Name: "primary", URL: "https://primary.example.com"}}}
+	assert.NoError(t, valid.Validate())
+
+	assert.Error(t, (&StockSourcesConfig{}).Validate())
+
+	duplicate := StockSourcesConfig{Sources: []StockSourceConfig{
+		{Name: "primary", URL: "https://a.example.com"},
+		{Name: "primary", URL: "https://b.example.com"},
+	}}
+	assert.Error(t, duplicate.Validate())
+
+	missingURL := StockSourcesConfig{Sources: []StockSourceConfig{{Name: "primary"}}}
+	assert.Error(t, missingURL.Validate())
+
+	unknownPrecedence := valid
+	unknownPrecedence.Precedence = []string{"backup"}
+	assert.Error(t, unknownPrecedence.Validate())
+}
+
+func TestExternalAPIClient_Name(t *testing.T) {
+	assert.Equal(t, "external_api", NewExternalAPIClient(ExternalAPIConfig{}, logrus.New()).Name())
+	assert.Equal(t, "backup", NewExternalAPIClient(ExternalAPIConfig{Name: "backup"}, logrus.New()).Name())
+}
@@ -17,9 +17,46 @@ type Config struct {
 	DatabaseURL    string
 	ExternalAPIURL string
 	ExternalAPIKey string
-	CacheTTL       time.Duration
-	RateLimit      int
-	AdminAPIKey    string
+
+	ExternalAPIMaxRetries int
+	ExternalAPIRetryDelay time.Duration
+	StockSourcesFile      string
+
+	ExternalAPICircuitFailureThreshold int
+	ExternalAPICircuitOpenTimeout      time.Duration
+	ExternalAPICircuitHalfOpenRequests int
+
+	DataQualityPolicy          string
+	DataQualityMaxTargetChange float64
+
+	IngestionRevisionLookback time.Duration
+
+	CacheTTL    time.Duration
+	RateLimit   int
+	AdminAPIKey string
+
+	ScoringConfigFile       string
+	RecommendationRetention time.Duration
+
+	IngestionSchedule        string
+	RecommendationSchedule   string
+	SchedulerJitter          time.Duration
+	SchedulerCatchUp         string
+	SchedulerShutdownTimeout time.Duration
+	SchedulerTimezone        string
+
+	JobWorkers                    int
+	JobIngestTimeout              time.Duration
+	JobRecommendationsTimeout     time.Duration
+	JobBackfillTimeout            time.Duration
+	JobIngestConcurrency          int
+	JobRecommendationsConcurrency int
+	JobBackfillConcurrency        int
+
+	PriceProvider string
+	PriceAPIURL   string
+	PriceAPIKey   string
+	PriceCSVFile  string
 }
 
 func Load() *Config {
@@ -37,10 +74,46 @@ func Load() *Config {
 		ExternalAPIURL: getEnv("EXTERNAL_API_URL", "https://api.karenai.click"),
 		ExternalAPIKey: getEnv("EXTERNAL_API_KEY", ""),
 
+		ExternalAPIMaxRetries: getEnvAsInt("EXTERNAL_API_MAX_RETRIES", 3),
+		ExternalAPIRetryDelay: getEnvAsDuration("EXTERNAL_API_RETRY_DELAY", time.Second),
+		StockSourcesFile:      getEnv("STOCK_SOURCES_FILE", ""),
+
+		ExternalAPICircuitFailureThreshold: getEnvAsInt("EXTERNAL_API_CIRCUIT_FAILURE_THRESHOLD", 5),
+		ExternalAPICircuitOpenTimeout:      getEnvAsDuration("EXTERNAL_API_CIRCUIT_OPEN_TIMEOUT", 30*time.Second),
+		ExternalAPICircuitHalfOpenRequests: getEnvAsInt("EXTERNAL_API_CIRCUIT_HALF_OPEN_REQUESTS", 1),
+
+		DataQualityPolicy:          getEnv("DATA_QUALITY_POLICY", "flag"),
+		DataQualityMaxTargetChange: getEnvAsFloat("DATA_QUALITY_MAX_TARGET_CHANGE", 1000),
+
+		IngestionRevisionLookback: getEnvAsDuration("INGESTION_REVISION_LOOKBACK", 24*time.Hour),
+
 		CacheTTL:  getEnvAsDuration("CACHE_TTL", 5*time.Minute),
 		RateLimit: getEnvAsInt("RATE_LIMIT", 100),
 
 		AdminAPIKey: getEnv("ADMIN_API_KEY", ""),
+
+		ScoringConfigFile:       getEnv("SCORING_CONFIG_FILE", ""),
+		RecommendationRetention: getEnvAsDuration("RECOMMENDATION_RETENTION", 30*24*time.Hour),
+
+		IngestionSchedule:        getEnv("INGESTION_SCHEDULE", "0 1 * * *"),
+		RecommendationSchedule:   getEnv("RECOMMENDATION_SCHEDULE", "8 1 * * *"),
+		SchedulerJitter:          getEnvAsDuration("SCHEDULER_JITTER", time.Minute),
+		SchedulerCatchUp:         getEnv("SCHEDULER_CATCH_UP", "once"),
+		SchedulerShutdownTimeout: getEnvAsDuration("SCHEDULER_SHUTDOWN_TIMEOUT", 30*time.Second),
+		SchedulerTimezone:        getEnv("SCHEDULER_TIMEZONE", "UTC"),
+
+		JobWorkers:                    getEnvAsInt("JOB_WORKERS", 5),
+		JobIngestTimeout:              getEnvAsDuration("JOB_INGEST_TIMEOUT", time.Hour),
+		JobRecommendationsTimeout:     getEnvAsDuration("JOB_RECOMMENDATIONS_TIMEOUT", 10*time.Minute),
+		JobBackfillTimeout:            getEnvAsDuration("JOB_BACKFILL_TIMEOUT", time.Hour),
+		JobIngestConcurrency:          getEnvAsInt("JOB_INGEST_CONCURRENCY", 1),
+		JobRecommendationsConcurrency: getEnvAsInt("JOB_RECOMMENDATIONS_CONCURRENCY", 1),
+		JobBackfillConcurrency:        getEnvAsInt("JOB_BACKFILL_CONCURRENCY", 2),
+
+		PriceProvider: getEnv("PRICE_PROVIDER", ""),
+		PriceAPIURL:   getEnv("PRICE_API_URL", ""),
+		PriceAPIKey:   getEnv("PRICE_API_KEY", ""),
+		PriceCSVFile:  getEnv("PRICE_CSV_FILE", ""),
 	}
 
 	return config
@@ -63,6 +136,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("RATE_LIMIT must be greater than 0")
 	}
 
+	switch c.DataQualityPolicy {
+	case "", "off", "flag", "fail":
+	default:
+		return fmt.Errorf("DATA_QUALITY_POLICY must be one of: off, flag, fail")
+	}
+
+	switch c.SchedulerCatchUp {
+	case "", "skip", "once":
+	default:
+		return fmt.Errorf("SCHEDULER_CATCH_UP must be one of: skip, once")
+	}
+
 	return nil
 }
 
@@ -82,6 +167,15 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 	if value := os.Getenv(key); value != "" {
 		if duration, err := time.ParseDuration(value); err == nil {
@@ -18,6 +18,23 @@ func TestConfig_Load(t *testing.T) {
 	assert.Equal(t, "https://api.karenai.click", config.ExternalAPIURL)
 	assert.Equal(t, 5*time.Minute, config.CacheTTL)
 	assert.Equal(t, 100, config.RateLimit)
+	assert.Equal(t, "flag", config.DataQualityPolicy)
+	assert.Equal(t, 1000.0, config.DataQualityMaxTargetChange)
+	assert.Equal(t, 24*time.Hour, config.IngestionRevisionLookback)
+	assert.Equal(t, 5, config.ExternalAPICircuitFailureThreshold)
+	assert.Equal(t, 30*time.Second, config.ExternalAPICircuitOpenTimeout)
+	assert.Equal(t, 1, config.ExternalAPICircuitHalfOpenRequests)
+	assert.Equal(t, "0 1 * * *", config.IngestionSchedule)
+	assert.Equal(t, "8 1 * * *", config.RecommendationSchedule)
+	assert.Equal(t, time.Minute, config.SchedulerJitter)
+	assert.Equal(t, "once", config.SchedulerCatchUp)
+	assert.Equal(t, 30*time.Second, config.SchedulerShutdownTimeout)
+	assert.Equal(t, "UTC", config.SchedulerTimezone)
+	assert.Equal(t, 5, config.JobWorkers)
+	assert.Equal(t, time.Hour, config.JobIngestTimeout)
+	assert.Equal(t, 1, config.JobIngestConcurrency)
+	assert.Equal(t, 1, config.JobRecommendationsConcurrency)
+	assert.Equal(t, 2, config.JobBackfillConcurrency)
 }
 
 func TestConfig_LoadWithEnvironment(t *testing.T) {
@@ -29,6 +46,9 @@ func TestConfig_LoadWithEnvironment(t *testing.T) {
 	os.Setenv("CACHE_TTL", "10m")
 	os.Setenv("RATE_LIMIT", "200")
 	os.Setenv("ADMIN_API_KEY", "admin-key")
+	os.Setenv("EXTERNAL_API_CIRCUIT_FAILURE_THRESHOLD", "3")
+	os.Setenv("EXTERNAL_API_CIRCUIT_OPEN_TIMEOUT", "2m")
+	os.Setenv("EXTERNAL_API_CIRCUIT_HALF_OPEN_REQUESTS", "2")
 
 	defer func() {
 		os.Unsetenv("PORT")
@@ -39,6 +59,9 @@ func TestConfig_LoadWithEnvironment(t *testing.T) {
 		os.Unsetenv("CACHE_TTL")
 		os.Unsetenv("RATE_LIMIT")
 		os.Unsetenv("ADMIN_API_KEY")
+		os.Unsetenv("EXTERNAL_API_CIRCUIT_FAILURE_THRESHOLD")
+		os.Unsetenv("EXTERNAL_API_CIRCUIT_OPEN_TIMEOUT")
+		os.Unsetenv("EXTERNAL_API_CIRCUIT_HALF_OPEN_REQUESTS")
 	}()
 
 	config := Load()
@@ -51,6 +74,9 @@ func TestConfig_LoadWithEnvironment(t *testing.T) {
 	assert.Equal(t, 10*time.Minute, config.CacheTTL)
 	assert.Equal(t, 200, config.RateLimit)
 	assert.Equal(t, "admin-key", config.AdminAPIKey)
+	assert.Equal(t, 3, config.ExternalAPICircuitFailureThreshold)
+	assert.Equal(t, 2*time.Minute, config.ExternalAPICircuitOpenTimeout)
+	assert.Equal(t, 2, config.ExternalAPICircuitHalfOpenRequests)
 }
 
 func TestConfig_Validate(t *testing.T) {
@@ -95,6 +121,26 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "invalid data quality policy",
+			config: &Config{
+				Environment:       "development",
+				DatabaseURL:       "postgres://test",
+				RateLimit:         100,
+				DataQualityPolicy: "strict",
+			},
+			expectError: true,
+		},
+		{
+			name: "invalid scheduler catch-up policy",
+			config: &Config{
+				Environment:      "development",
+				DatabaseURL:      "postgres://test",
+				RateLimit:        100,
+				SchedulerCatchUp: "always",
+			},
+			expectError: true,
+		},
 		{
 			name: "invalid rate limit",
 			config: &Config{
@@ -146,6 +192,20 @@ func TestGetEnvAsInt(t *testing.T) {
 	assert.Equal(t, 789, value)
 }
 
+func TestGetEnvAsFloat(t *testing.T) {
+	os.Setenv("TEST_FLOAT", "250.5")
+	defer os.Unsetenv("TEST_FLOAT")
+
+	value := getEnvAsFloat("TEST_FLOAT", 0)
+	assert.Equal(t, 250.5, value)
+
+	os.Setenv("TEST_INVALID_FLOAT", "lots")
+	defer os.Unsetenv("TEST_INVALID_FLOAT")
+
+	value = getEnvAsFloat("TEST_INVALID_FLOAT", 1000)
+	assert.Equal(t, 1000.0, value)
+}
+
 func TestGetEnvAsDuration(t *testing.T) {
 	os.Setenv("TEST_DURATION", "5m")
 	defer os.Unsetenv("TEST_DURATION")
@@ -0,0 +1,188 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/utils"
+)
+
+const backfillBatchSize = 500
+
+// postMigrationHooks run Go code after the SQL of a migration, for data
+// changes that can't be expressed in SQL. A migration is only recorded once
+// its hook succeeds, so hooks must be safe to re-run.
+var postMigrationHooks = map[string]func(*sql.DB) error{
+	"003_create_reference_tables":  backfillReferenceIDs,
+	"004_add_target_price_columns": backfillTargetPrices,
+}
+
+// normalizedSQL matches model.NormalizeReferenceValue: lowercase with runs of
+// whitespace collapsed.
+const normalizedSQL = `lower(regexp_replace(btrim(%s), '\s+', ' ', 'g'))`
+
+// referenceBackfillStatements map the raw brokerage, rating and action text
+// of existing stocks onto the reference ids, resolving aliases the way
+// ReferenceService.MapStocks does. Unknown brokerages are created; unknown
+// ratings and actions stay NULL.
+var referenceBackfillStatements = []struct {
+	name  string
+	query string
+}{
+	{"brokerages", `
+		INSERT INTO brokerages (name)
+		SELECT DISTINCT ON (` + normalized("s.brokerage") + `) btrim(s.brokerage)
+		FROM stocks s
+		WHERE btrim(s.brokerage) <> ''
+		  AND NOT EXISTS (SELECT 1 FROM brokerages b WHERE ` + normalized("b.name") + ` = ` + normalized("s.brokerage") + `)
+		ORDER BY ` + normalized("s.brokerage") + `, btrim(s.brokerage)
+		ON CONFLICT (name) DO NOTHING`},
+	{"brokerage ids", `
+		UPDATE stocks s SET brokerage_id = b.id
+		FROM brokerages b
+		WHERE s.brokerage_id IS NULL AND ` + normalized("b.name") + ` = ` + normalized("s.brokerage")},
+	{"rating_from ids", `
+		WITH keys AS (` + referenceKeysSQL("ratings", "rating") + `)
+		UPDATE stocks s SET rating_from_id = k.id
+		FROM keys k
+		WHERE s.rating_from_id IS NULL AND k.key = ` + normalized("s.rating_from")},
+	{"rating_to ids", `
+		WITH keys AS (` + referenceKeysSQL("ratings", "rating") + `)
+		UPDATE stocks s SET rating_to_id = k.id
+		FROM keys k
+		WHERE s.rating_to_id IS NULL AND k.key = ` + normalized("s.rating_to")},
+	{"action ids", `
+		WITH keys AS (` + referenceKeysSQL("actions", "action") + `)
+		UPDATE stocks s SET action_id = k.id
+		FROM keys k
+		WHERE s.action_id IS NULL AND k.key = ` + normalized("s.action")},
+}
+
+func normalized(column string) string {
+	return fmt.Sprintf(normalizedSQL, column)
+}
+
+// referenceKeysSQL selects the id of each canonical name and alias of a
+// reference table, keyed by normalized spelling. DISTINCT ON keeps the
+// canonical name when an alias collides with it.
+func referenceKeysSQL(table, kind string) string {
+	return `
+		SELECT DISTINCT ON (key) key, id FROM (
+			SELECT ` + normalized("t.name") + ` AS key, t.id, 0 AS precedence FROM ` + table + ` t
+			UNION ALL
+			SELECT ` + normalized("a.alias") + `, t.id, 1 FROM reference_aliases a
+			JOIN ` + table + ` t ON ` + normalized("t.name") + ` = ` + normalized("a.canonical_name") + `
+			WHERE a.kind = '` + kind + `'
+		) candidates
+		ORDER BY key, precedence`
+}
+
+func backfillReferenceIDs(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range referenceBackfillStatements {
+		result, err := tx.Exec(statement.query)
+		if err != nil {
+			return fmt.Errorf("failed to backfill %s: %w", statement.name, err)
+		}
+		if os.Getenv("LOG_LEVEL") != "error" {
+			affected, _ := result.RowsAffected()
+			log.Printf("Backfilled %s: %d rows", statement.name, affected)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+type priceBackfillRow struct {
+	ticker     string
+	time       time.Time
+	targetFrom sql.NullString
+	targetTo   sql.NullString
+}
+
+func backfillTargetPrices(db *sql.DB) error {
+	total := 0
+	var lastTicker string
+	var lastTime time.Time
+
+	for {
+		rows, err := db.Query(`
+			SELECT ticker, time, target_from, target_to
+			FROM stocks
+			WHERE target_from_price IS NULL AND target_to_price IS NULL
+			  AND (ticker, time) > ($1, $2)
+			ORDER BY ticker, time
+			LIMIT $3
+		`, lastTicker, lastTime, backfillBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to select stocks for price backfill: %w", err)
+		}
+
+		var batch []priceBackfillRow
+		for rows.Next() {
+			var row priceBackfillRow
+			if err := rows.Scan(&row.ticker, &row.time, &row.targetFrom, &row.targetTo); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan stock for price backfill: %w", err)
+			}
+			batch = append(batch, row)
+		}
+		rows.Close()
+
+		if len(batch) == 0 {
+			break
+		}
+
+		if err := updateTargetPrices(db, batch); err != nil {
+			return err
+		}
+
+		total += len(batch)
+		lastTicker = batch[len(batch)-1].ticker
+		lastTime = batch[len(batch)-1].time
+	}
+
+	if os.Getenv("LOG_LEVEL") != "error" {
+		log.Printf("Backfilled target prices for %d stocks", total)
+	}
+	return nil
+}
+
+func updateTargetPrices(db *sql.DB, batch []priceBackfillRow) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`UPDATE stocks SET target_from_price = $1, target_to_price = $2 WHERE ticker = $3 AND time = $4`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, row := range batch {
+		fromPrice := utils.PriceOrNil(row.targetFrom.String)
+		toPrice := utils.PriceOrNil(row.targetTo.String)
+
+		if _, err := stmt.Exec(fromPrice, toPrice, row.ticker, row.time); err != nil {
+			return fmt.Errorf("failed to backfill target prices for %s: %w", row.ticker, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
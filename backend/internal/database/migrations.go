@@ -102,6 +102,11 @@ func (mm *MigrationManager) executeMigration(migration Migration, repo *Migratio
 	if _, err := mm.db.Exec(migration.SQL); err != nil {
 		return err
 	}
+	if hook, ok := postMigrationHooks[migration.ID]; ok {
+		if err := hook(mm.db); err != nil {
+			return fmt.Errorf("post-migration hook failed: %w", err)
+		}
+	}
 	return repo.RecordMigration(migration.ID, migration.Filename)
 }
 
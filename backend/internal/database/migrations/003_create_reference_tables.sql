@@ -0,0 +1,107 @@
+CREATE TABLE IF NOT EXISTS brokerages (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS ratings (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    rank INTEGER NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS actions (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT now()
+);
+
+-- Raw upstream spellings that map onto a canonical rating or action
+CREATE TABLE IF NOT EXISTS reference_aliases (
+    kind TEXT NOT NULL,
+    alias TEXT NOT NULL,
+    canonical_name TEXT NOT NULL,
+    PRIMARY KEY (kind, alias)
+);
+
+-- Raw values seen during ingestion that could not be mapped
+CREATE TABLE IF NOT EXISTS unmapped_reference_values (
+    kind TEXT NOT NULL,
+    raw_value TEXT NOT NULL,
+    occurrences INTEGER NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMPTZ DEFAULT now(),
+    last_seen_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (kind, raw_value)
+);
+
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS brokerage_id INTEGER REFERENCES brokerages(id);
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS rating_from_id INTEGER REFERENCES ratings(id);
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS rating_to_id INTEGER REFERENCES ratings(id);
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS action_id INTEGER REFERENCES actions(id);
+
+CREATE INDEX IF NOT EXISTS idx_stocks_brokerage_id ON stocks(brokerage_id);
+CREATE INDEX IF NOT EXISTS idx_stocks_rating_to_id ON stocks(rating_to_id);
+CREATE INDEX IF NOT EXISTS idx_stocks_action_id ON stocks(action_id);
+
+-- Bullishness rank: 5 = most bullish, 1 = most bearish
+INSERT INTO ratings (name, rank) VALUES
+    ('Strong-Buy', 5),
+    ('Buy', 5),
+    ('Speculative Buy', 4),
+    ('Outperform', 4),
+    ('Market Outperform', 4),
+    ('Sector Outperform', 4),
+    ('Overweight', 4),
+    ('Positive', 4),
+    ('Hold', 3),
+    ('Neutral', 3),
+    ('Equal Weight', 3),
+    ('Market Perform', 3),
+    ('Sector Perform', 3),
+    ('Sector Weight', 3),
+    ('In-Line', 3),
+    ('Peer Perform', 3),
+    ('Underweight', 2),
+    ('Underperform', 2),
+    ('Sector Underperform', 2),
+    ('Reduce', 2),
+    ('Negative', 2),
+    ('Sell', 1),
+    ('Strong Sell', 1)
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO actions (name) VALUES
+    ('target raised by'),
+    ('target lowered by'),
+    ('target set by'),
+    ('target maintained by'),
+    ('upgraded by'),
+    ('downgraded by'),
+    ('initiated by'),
+    ('reiterated by')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO reference_aliases (kind, alias, canonical_name) VALUES
+    ('rating', 'strong buy', 'Strong-Buy'),
+    ('rating', 'equal-weight', 'Equal Weight'),
+    ('rating', 'in line', 'In-Line'),
+    ('rating', 'sector-perform', 'Sector Perform'),
+    ('rating', 'over-weight', 'Overweight'),
+    ('rating', 'under-weight', 'Underweight'),
+    ('rating', 'strong-sell', 'Strong Sell'),
+    ('action', 'target raised', 'target raised by'),
+    ('action', 'price target raised by', 'target raised by'),
+    ('action', 'target lowered', 'target lowered by'),
+    ('action', 'price target lowered by', 'target lowered by'),
+    ('action', 'upgraded', 'upgraded by'),
+    ('action', 'downgraded', 'downgraded by'),
+    ('action', 'initiated', 'initiated by'),
+    ('action', 'coverage initiated by', 'initiated by'),
+    ('action', 'reiterated', 'reiterated by')
+ON CONFLICT (kind, alias) DO NOTHING;
+
+COMMENT ON TABLE brokerages IS 'Canonical brokerages publishing analyst events';
+COMMENT ON TABLE ratings IS 'Canonical analyst ratings with bullishness rank';
+COMMENT ON TABLE actions IS 'Canonical analyst actions';
+COMMENT ON TABLE unmapped_reference_values IS 'Raw ingestion values awaiting a canonical mapping';
@@ -0,0 +1,7 @@
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_from_price DECIMAL(12, 2);
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS target_to_price DECIMAL(12, 2);
+
+CREATE INDEX IF NOT EXISTS idx_stocks_target_to_price ON stocks(target_to_price);
+
+-- Existing rows are backfilled from target_from/target_to by the Go hook
+-- registered for this migration (see database/backfill.go).
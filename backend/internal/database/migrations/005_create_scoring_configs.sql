@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS scoring_configs (
+    id SERIAL PRIMARY KEY,
+    version TEXT NOT NULL UNIQUE,
+    description TEXT,
+    config JSONB NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    activated_at TIMESTAMPTZ
+);
+
+-- At most one active version
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scoring_configs_active ON scoring_configs(is_active) WHERE is_active;
+
+ALTER TABLE recommendations ADD COLUMN IF NOT EXISTS scoring_config_version TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_recommendations_scoring_config_version ON recommendations(scoring_config_version);
+
+COMMENT ON TABLE scoring_configs IS 'Versioned recommendation scoring weights and thresholds';
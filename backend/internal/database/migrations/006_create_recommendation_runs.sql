@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS recommendation_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    status TEXT NOT NULL,
+    strategy TEXT NOT NULL,
+    scoring_config_version TEXT,
+    params JSONB NOT NULL DEFAULT '{}',
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ,
+    recommendation_count INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_recommendation_runs_started_at ON recommendation_runs(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_recommendation_runs_status_finished_at ON recommendation_runs(status, finished_at DESC);
+
+ALTER TABLE recommendations ADD COLUMN IF NOT EXISTS run_id UUID REFERENCES recommendation_runs(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_recommendations_run_id_rank ON recommendations(run_id, rank);
+
+-- Existing rankings become one completed run per run_at
+INSERT INTO recommendation_runs (status, strategy, scoring_config_version, started_at, finished_at, recommendation_count)
+SELECT 'completed', 'additive', MAX(scoring_config_version), run_at, run_at, COUNT(*)
+FROM recommendations
+WHERE run_id IS NULL
+GROUP BY run_at;
+
+UPDATE recommendations SET run_id = (
+    SELECT r.id FROM recommendation_runs r
+    WHERE r.started_at = recommendations.run_at AND r.finished_at = recommendations.run_at
+    LIMIT 1
+)
+WHERE run_id IS NULL;
+
+COMMENT ON TABLE recommendation_runs IS 'History of recommendation calculations and their parameters';
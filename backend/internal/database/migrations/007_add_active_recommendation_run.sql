@@ -0,0 +1,16 @@
+ALTER TABLE recommendation_runs ADD COLUMN IF NOT EXISTS is_active BOOLEAN NOT NULL DEFAULT false;
+
+-- At most one run is published at a time
+CREATE UNIQUE INDEX IF NOT EXISTS idx_recommendation_runs_active ON recommendation_runs(is_active) WHERE is_active;
+
+-- The latest completed run stays the one being served
+UPDATE recommendation_runs SET is_active = true
+WHERE id = (
+    SELECT id FROM recommendation_runs
+    WHERE status = 'completed'
+    ORDER BY finished_at DESC
+    LIMIT 1
+)
+AND NOT EXISTS (SELECT 1 FROM recommendation_runs WHERE is_active);
+
+COMMENT ON COLUMN recommendation_runs.is_active IS 'Run currently served by GET /recommendations';
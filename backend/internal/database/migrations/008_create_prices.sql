@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS prices (
+    ticker TEXT NOT NULL,
+    date DATE NOT NULL,
+    open DECIMAL(12, 4) NOT NULL,
+    high DECIMAL(12, 4) NOT NULL,
+    low DECIMAL(12, 4) NOT NULL,
+    close DECIMAL(12, 4) NOT NULL,
+    volume BIGINT NOT NULL DEFAULT 0,
+    source TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    updated_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (ticker, date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_prices_date ON prices(date DESC);
+
+-- Target price the recommendation was based on, used for upside vs last close
+ALTER TABLE recommendations ADD COLUMN IF NOT EXISTS target_price DECIMAL(12, 2);
+
+COMMENT ON TABLE prices IS 'Daily OHLCV bars from the configured market-data provider';
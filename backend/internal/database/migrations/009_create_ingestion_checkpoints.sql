@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS ingestion_checkpoints (
+    source TEXT PRIMARY KEY,
+    status TEXT NOT NULL,
+    next_page TEXT NOT NULL DEFAULT '',
+    high_water_mark TIMESTAMPTZ,
+    run_high_water_mark TIMESTAMPTZ,
+    pages_committed INTEGER NOT NULL DEFAULT 0,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE ingestion_checkpoints IS 'Pagination cursor and event-time high-water mark of each ingestion source';
+COMMENT ON COLUMN ingestion_checkpoints.run_high_water_mark IS 'Newest event time committed by the current run, promoted to high_water_mark when it completes';
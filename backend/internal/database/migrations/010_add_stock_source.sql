@@ -0,0 +1,6 @@
+-- Upstream that supplied each analyst event; existing rows came from the original API
+ALTER TABLE stocks ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'external_api';
+
+CREATE INDEX IF NOT EXISTS idx_stocks_source ON stocks(source);
+
+COMMENT ON COLUMN stocks.source IS 'Ingestion source that last wrote the event, chosen by source precedence';
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS ingestion_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source TEXT NOT NULL,
+    status TEXT NOT NULL,
+    resumed BOOLEAN NOT NULL DEFAULT false,
+    pages_fetched INTEGER NOT NULL DEFAULT 0,
+    pages_skipped INTEGER NOT NULL DEFAULT 0,
+    pages_resumed INTEGER NOT NULL DEFAULT 0,
+    stocks_fetched INTEGER NOT NULL DEFAULT 0,
+    stocks_inserted INTEGER NOT NULL DEFAULT 0,
+    stocks_updated INTEGER NOT NULL DEFAULT 0,
+    stocks_unchanged INTEGER NOT NULL DEFAULT 0,
+    stocks_rejected INTEGER NOT NULL DEFAULT 0,
+    reached_high_water_mark BOOLEAN NOT NULL DEFAULT false,
+    high_water_mark TIMESTAMPTZ,
+    error_message TEXT,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingestion_runs_started_at ON ingestion_runs(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ingestion_runs_source_started_at ON ingestion_runs(source, started_at DESC);
+
+COMMENT ON TABLE ingestion_runs IS 'History of ingestion runs per source with what each did to the stocks table';
+COMMENT ON COLUMN ingestion_runs.stocks_unchanged IS 'Events already stored identically or kept from a higher-precedence source';
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS stock_rejects (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id UUID REFERENCES ingestion_runs(id) ON DELETE SET NULL,
+    source TEXT NOT NULL,
+    ticker TEXT NOT NULL DEFAULT '',
+    event_time TIMESTAMPTZ,
+    payload JSONB NOT NULL,
+    error_message TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_rejects_status_created_at ON stock_rejects(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_stock_rejects_run_id ON stock_rejects(run_id);
+
+COMMENT ON TABLE stock_rejects IS 'Upstream events that failed validation, kept with their raw payload until replayed or discarded';
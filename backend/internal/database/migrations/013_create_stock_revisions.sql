@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS stock_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticker TEXT NOT NULL,
+    event_time TIMESTAMPTZ NOT NULL,
+    source TEXT NOT NULL,
+    changes JSONB NOT NULL,
+    revised_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_revisions_ticker_event_time ON stock_revisions(ticker, event_time, revised_at);
+
+COMMENT ON TABLE stock_revisions IS 'Field-level before/after values of analyst events corrected by a later upsert';
+COMMENT ON COLUMN stock_revisions.source IS 'Source of the version that replaced the previous values';
@@ -0,0 +1,22 @@
+ALTER TABLE ingestion_runs ADD COLUMN IF NOT EXISTS quality_status TEXT NOT NULL DEFAULT '';
+ALTER TABLE ingestion_runs ADD COLUMN IF NOT EXISTS quality_errors INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE ingestion_runs ADD COLUMN IF NOT EXISTS quality_warnings INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS data_quality_findings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    run_id UUID NOT NULL REFERENCES ingestion_runs(id) ON DELETE CASCADE,
+    source TEXT NOT NULL,
+    rule TEXT NOT NULL,
+    severity TEXT NOT NULL,
+    ticker TEXT NOT NULL,
+    event_time TIMESTAMPTZ NOT NULL,
+    field TEXT NOT NULL DEFAULT '',
+    value TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_quality_findings_run_id ON data_quality_findings(run_id, severity);
+
+COMMENT ON TABLE data_quality_findings IS 'Rule violations found by the data quality checks run after each ingestion';
+COMMENT ON COLUMN ingestion_runs.quality_status IS 'passed, flagged or failed; empty when data quality checks are off';
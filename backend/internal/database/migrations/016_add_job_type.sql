@@ -0,0 +1,4 @@
+-- Kind of work each job does; existing jobs were all ingestions
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS type TEXT NOT NULL DEFAULT 'ingest';
+
+COMMENT ON COLUMN jobs.type IS 'Job type (ingest), which selects the job deadline';
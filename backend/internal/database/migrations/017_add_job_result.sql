@@ -0,0 +1,4 @@
+-- Summary returned by the work of each job, such as row counts
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS result JSONB;
+
+COMMENT ON COLUMN jobs.result IS 'JSON result of the job, set once its work returns';
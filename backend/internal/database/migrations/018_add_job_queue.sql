@@ -0,0 +1,10 @@
+-- Queue order and deduplication key of each job
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS priority INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE jobs ADD COLUMN IF NOT EXISTS key TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_jobs_type_created_at ON jobs(type, created_at);
+CREATE INDEX IF NOT EXISTS idx_jobs_active_key ON jobs(key) WHERE status IN ('pending', 'running');
+
+COMMENT ON COLUMN jobs.type IS 'Job type (ingest, recommendations, backfill), which selects the job deadline and concurrency limit';
+COMMENT ON COLUMN jobs.priority IS 'Queue priority of the job; higher runs first';
+COMMENT ON COLUMN jobs.key IS 'Identifies the work of the job so that only one pending or running job has a given key';
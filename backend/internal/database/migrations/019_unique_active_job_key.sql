@@ -0,0 +1,11 @@
+-- Only one pending or running job may hold a key, across every replica
+UPDATE jobs SET status = 'failed', error = 'job superseded by an equivalent job', ended_at = now(), updated_at = now()
+WHERE key <> '' AND status IN ('pending', 'running')
+  AND id NOT IN (
+      SELECT DISTINCT ON (key) id FROM jobs
+      WHERE key <> '' AND status IN ('pending', 'running')
+      ORDER BY key, created_at
+  );
+
+DROP INDEX IF EXISTS idx_jobs_active_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_jobs_active_key ON jobs(key) WHERE key <> '' AND status IN ('pending', 'running');
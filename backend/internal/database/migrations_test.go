@@ -49,6 +49,21 @@ func TestRunMigrationsWithoutConnection(t *testing.T) {
 	assert.Contains(t, err.Error(), "database connection not established")
 }
 
+func TestPostMigrationHooksMatchMigrationFiles(t *testing.T) {
+	loader := NewMigrationFileLoader("migrations")
+	migrations, err := loader.LoadMigrations()
+	require.NoError(t, err)
+
+	ids := make(map[string]bool)
+	for _, migration := range migrations {
+		ids[migration.ID] = true
+	}
+
+	for id := range postMigrationHooks {
+		assert.True(t, ids[id], "post-migration hook %s has no matching migration file", id)
+	}
+}
+
 func TestMigrationsAreIdempotent(t *testing.T) {
 	testConfig := config.LoadTestConfig()
 	if !testConfig.HasTestDatabase() {
@@ -108,7 +123,21 @@ func TestMigrationsTableStructure(t *testing.T) {
 func cleanupTestDatabase(t *testing.T) {
 	queries := []string{
 		"DROP TABLE IF EXISTS recommendations CASCADE",
+		"DROP TABLE IF EXISTS recommendation_runs CASCADE",
 		"DROP TABLE IF EXISTS stocks CASCADE",
+		"DROP TABLE IF EXISTS unmapped_reference_values CASCADE",
+		"DROP TABLE IF EXISTS reference_aliases CASCADE",
+		"DROP TABLE IF EXISTS actions CASCADE",
+		"DROP TABLE IF EXISTS ratings CASCADE",
+		"DROP TABLE IF EXISTS brokerages CASCADE",
+		"DROP TABLE IF EXISTS scoring_configs CASCADE",
+		"DROP TABLE IF EXISTS prices CASCADE",
+		"DROP TABLE IF EXISTS ingestion_checkpoints CASCADE",
+		"DROP TABLE IF EXISTS stock_revisions CASCADE",
+		"DROP TABLE IF EXISTS data_quality_findings CASCADE",
+		"DROP TABLE IF EXISTS stock_rejects CASCADE",
+		"DROP TABLE IF EXISTS ingestion_runs CASCADE",
+		"DROP TABLE IF EXISTS jobs CASCADE",
 		"DROP TABLE IF EXISTS migrations CASCADE",
 	}
 
@@ -121,7 +150,7 @@ func cleanupTestDatabase(t *testing.T) {
 }
 
 func verifyTablesExist(t *testing.T) {
-	tables := []string{"stocks", "recommendations", "migrations"}
+	tables := []string{"stocks", "recommendations", "migrations", "brokerages", "ratings", "actions", "reference_aliases", "unmapped_reference_values", "scoring_configs", "recommendation_runs", "prices", "ingestion_checkpoints", "ingestion_runs", "stock_rejects", "stock_revisions", "data_quality_findings", "jobs"}
 
 	for _, tableName := range tables {
 		var exists bool
@@ -148,6 +177,27 @@ func verifyIndexesExist(t *testing.T) {
 		"idx_recommendations_rank",
 		"idx_recommendations_ticker",
 		"idx_recommendations_run_at_score",
+		"idx_stocks_brokerage_id",
+		"idx_stocks_rating_to_id",
+		"idx_stocks_action_id",
+		"idx_stocks_target_to_price",
+		"idx_scoring_configs_active",
+		"idx_recommendations_scoring_config_version",
+		"idx_recommendation_runs_started_at",
+		"idx_recommendation_runs_status_finished_at",
+		"idx_recommendations_run_id_rank",
+		"idx_recommendation_runs_active",
+		"idx_prices_date",
+		"idx_stocks_source",
+		"idx_ingestion_runs_started_at",
+		"idx_ingestion_runs_source_started_at",
+		"idx_stock_rejects_status_created_at",
+		"idx_stock_rejects_run_id",
+		"idx_stock_revisions_ticker_event_time",
+		"idx_data_quality_findings_run_id",
+		"idx_jobs_created_at",
+		"idx_jobs_type_created_at",
+		"idx_jobs_active_key",
 	}
 
 	for _, indexName := range indexes {
@@ -1 +1,22 @@
 package request
+
+import (
+	"encoding/json"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+// CreateScoringConfigRequest is the body of POST /admin/scoring-configs.
+// Weights omitted from config keep their default value.
+type CreateScoringConfigRequest struct {
+	Version     string               `json:"version" binding:"required"`
+	Description string               `json:"description"`
+	Config      *model.ScoringConfig `json:"config"`
+}
+
+// ReplayStockRejectRequest is the optional body of
+// POST /admin/rejects/:id/replay. A payload replaces the quarantined event
+// before it is replayed.
+type ReplayStockRejectRequest struct {
+	Payload json.RawMessage `json:"payload"`
+}
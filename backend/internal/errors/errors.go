@@ -1,8 +1,10 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 type ErrorType string
@@ -13,6 +15,7 @@ const (
 	ErrorTypeDatabase   ErrorType = "DATABASE_ERROR"
 	ErrorTypeInternal   ErrorType = "INTERNAL_ERROR"
 	ErrorTypeExternal   ErrorType = "EXTERNAL_ERROR"
+	ErrorTypeConflict   ErrorType = "CONFLICT"
 )
 
 type AppError struct {
@@ -20,6 +23,10 @@ type AppError struct {
 	Message string    `json:"message"`
 	Code    int       `json:"code"`
 	Err     error     `json:"-"`
+	// Retryable marks transient failures worth another attempt.
+	Retryable bool `json:"-"`
+	// RetryAfter is the wait requested by the upstream, if any.
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *AppError) Error() string {
@@ -78,9 +85,45 @@ func NewExternalError(message string, err error) *AppError {
 	}
 }
 
+// NewConflictError reports work that cannot run because other work holds what
+// it needs, such as an ingestion of the same source.
+func NewConflictError(message string, err error) *AppError {
+	return &AppError{
+		Type:    ErrorTypeConflict,
+		Message: message,
+		Code:    http.StatusConflict,
+		Err:     err,
+	}
+}
+
+// NewRetryableExternalError is an external error that may succeed when retried,
+// such as a 5xx, a 429 or a network failure.
+func NewRetryableExternalError(message string, err error, retryAfter time.Duration) *AppError {
+	appErr := NewExternalError(message, err)
+	appErr.Retryable = true
+	appErr.RetryAfter = retryAfter
+	return appErr
+}
+
+// IsRetryable reports whether err, or an AppError it wraps, is retryable.
+func IsRetryable(err error) bool {
+	var appErr *AppError
+	if stderrors.As(err, &appErr) {
+		return appErr.Retryable
+	}
+	return false
+}
+
 func IsNotFoundError(err error) bool {
 	if appErr, ok := err.(*AppError); ok {
 		return appErr.Type == ErrorTypeNotFound
 	}
 	return false
 }
+
+func IsConflictError(err error) bool {
+	if appErr, ok := err.(*AppError); ok {
+		return appErr.Type == ErrorTypeConflict
+	}
+	return false
+}
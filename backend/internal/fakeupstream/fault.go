@@ -0,0 +1,124 @@
+package fakeupstream
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FaultKind selects how an injected fault breaks a response.
+type FaultKind string
+
+const (
+	// FaultStatus answers with Fault.Status, 500 by default.
+	FaultStatus FaultKind = "status"
+	// FaultRateLimit answers 429 with a Retry-After header.
+	FaultRateLimit FaultKind = "rate_limit"
+	// FaultMalformed answers 200 with a body that is not JSON.
+	FaultMalformed FaultKind = "malformed"
+	// FaultTruncated answers 200 with the first half of the page body.
+	FaultTruncated FaultKind = "truncated"
+)
+
+// Fault describes a failure to inject. It applies to requests for Page (1
+// based, zero for every page), at most Times times (zero for always), and
+// with probability Rate (zero for every match).
+type Fault struct {
+	Kind       FaultKind
+	Page       int
+	Times      int
+	Rate       float64
+	Status     int
+	RetryAfter time.Duration
+}
+
+type faultState struct {
+	Fault
+	used int
+}
+
+func (f Fault) withDefaults() Fault {
+	if f.Kind == FaultStatus && f.Status == 0 {
+		f.Status = http.StatusInternalServerError
+	}
+	return f
+}
+
+func (f Fault) write(w http.ResponseWriter, body []byte) {
+	switch f.Kind {
+	case FaultRateLimit:
+		if f.RetryAfter > 0 {
+			seconds := int((f.RetryAfter + time.Second - 1) / time.Second)
+			w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		}
+		http.Error(w, `{"error": "rate limit exceeded"}`, http.StatusTooManyRequests)
+	case FaultMalformed:
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"items": [{"ticker": "AAPL",, }`))
+	case FaultTruncated:
+		if len(body) == 0 {
+			body = []byte(`{"items": []}`)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body[:len(body)/2])
+	default:
+		http.Error(w, fmt.Sprintf(`{"error": "injected status %d"}`, f.Status), f.Status)
+	}
+}
+
+// ParseFault reads a fault from comma-separated key=value pairs, for example
+// "kind=status,status=503,page=2,times=1" or "kind=rate_limit,retry_after=2s".
+// A bare status code such as "503" is shorthand for a status fault.
+func ParseFault(spec string) (Fault, error) {
+	spec = strings.TrimSpace(spec)
+	if code, err := strconv.Atoi(spec); err == nil {
+		return Fault{Kind: FaultStatus, Status: code}, nil
+	}
+
+	var fault Fault
+	for _, pair := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return Fault{}, fmt.Errorf("fault %q: expected key=value, got %q", spec, pair)
+		}
+
+		var err error
+		switch key {
+		case "kind":
+			fault.Kind = FaultKind(value)
+		case "page":
+			fault.Page, err = strconv.Atoi(value)
+		case "times":
+			fault.Times, err = strconv.Atoi(value)
+		case "rate":
+			fault.Rate, err = strconv.ParseFloat(value, 64)
+		case "status":
+			fault.Status, err = strconv.Atoi(value)
+		case "retry_after":
+			fault.RetryAfter, err = time.ParseDuration(value)
+		default:
+			return Fault{}, fmt.Errorf("fault %q: unknown key %q", spec, key)
+		}
+		if err != nil {
+			return Fault{}, fmt.Errorf("fault %q: invalid %s: %w", spec, key, err)
+		}
+	}
+
+	switch fault.Kind {
+	case FaultStatus, FaultRateLimit, FaultMalformed, FaultTruncated:
+	case "":
+		if fault.Status == 0 {
+			return Fault{}, fmt.Errorf("fault %q: kind is required", spec)
+		}
+		fault.Kind = FaultStatus
+	default:
+		return Fault{}, fmt.Errorf("fault %q: unknown kind %q", spec, fault.Kind)
+	}
+
+	if fault.Rate < 0 || fault.Rate > 1 {
+		return Fault{}, fmt.Errorf("fault %q: rate must be between 0 and 1", spec)
+	}
+	return fault, nil
+}
@@ -0,0 +1,83 @@
+package fakeupstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+// LoadFixture reads events from a JSON file holding either an array of events
+// or a single upstream page ({"items": [...]}).
+func LoadFixture(path string) ([]model.Stock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("[")) {
+		var events []model.Stock
+		if err := json.Unmarshal(data, &events); err != nil {
+			return nil, fmt.Errorf("parse fixture %s: %w", path, err)
+		}
+		return events, nil
+	}
+
+	var page model.ExternalAPIResponse
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("parse fixture %s: %w", path, err)
+	}
+	return page.Items, nil
+}
+
+var (
+	generatedCompanies = []struct{ ticker, company string }{
+		{"AAPL", "Apple Inc."},
+		{"MSFT", "Microsoft Corporation"},
+		{"NVDA", "NVIDIA Corporation"},
+		{"AMZN", "Amazon.com, Inc."},
+		{"GOOGL", "Alphabet Inc."},
+		{"META", "Meta Platforms, Inc."},
+		{"TSLA", "Tesla, Inc."},
+		{"AMD", "Advanced Micro Devices, Inc."},
+		{"NFLX", "Netflix, Inc."},
+		{"CRM", "Salesforce, Inc."},
+		{"ORCL", "Oracle Corporation"},
+		{"ADBE", "Adobe Inc."},
+	}
+	generatedBrokerages = []string{"Goldman Sachs", "Morgan Stanley", "JPMorgan Chase & Co.", "Barclays", "UBS Group", "Wells Fargo & Company"}
+	generatedActions    = []string{"target raised by", "target lowered by", "upgraded by", "downgraded by", "reiterated by", "initiated by"}
+	generatedRatings    = []string{"Buy", "Outperform", "Overweight", "Neutral", "Hold", "Underweight", "Sell"}
+)
+
+// Generate returns count plausible events, newest first, spaced an hour apart
+// before now. The same seed always yields the same events.
+func Generate(count int, seed int64, now time.Time) []model.Stock {
+	rng := rand.New(rand.NewSource(seed))
+	now = now.UTC().Truncate(time.Hour)
+
+	events := make([]model.Stock, 0, count)
+	for i := 0; i < count; i++ {
+		company := generatedCompanies[rng.Intn(len(generatedCompanies))]
+		from := 50 + rng.Float64()*450
+		to := from * (0.8 + rng.Float64()*0.4)
+
+		events = append(events, model.Stock{
+			Ticker:     company.ticker,
+			Company:    company.company,
+			TargetFrom: fmt.Sprintf("$%.2f", from),
+			TargetTo:   fmt.Sprintf("$%.2f", to),
+			Action:     generatedActions[rng.Intn(len(generatedActions))],
+			Brokerage:  generatedBrokerages[rng.Intn(len(generatedBrokerages))],
+			RatingFrom: generatedRatings[rng.Intn(len(generatedRatings))],
+			RatingTo:   generatedRatings[rng.Intn(len(generatedRatings))],
+			Time:       now.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+	return events
+}
@@ -0,0 +1,196 @@
+package fakeupstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// recording is the file format of one recorded page.
+type recording struct {
+	Cursor     string          `json:"cursor"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// Recorder is an http.Handler that proxies requests to the real upstream and
+// saves every successful page into a directory that NewReplay can serve.
+// Failed responses are passed through without being recorded.
+type Recorder struct {
+	mu         sync.Mutex
+	target     string
+	apiKey     string
+	dir        string
+	httpClient *http.Client
+	count      int
+	logger     *logrus.Logger
+}
+
+// NewRecorder returns a Recorder forwarding to target with apiKey and writing
+// pages under dir, which is created if needed. A nil logger logs nothing.
+func NewRecorder(target, apiKey, dir string, logger *logrus.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create recording directory: %w", err)
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, "page-*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("list recordings: %w", err)
+	}
+
+	if logger == nil {
+		logger = logrus.New()
+		logger.SetLevel(logrus.PanicLevel)
+	}
+
+	return &Recorder{
+		target:     target,
+		apiKey:     apiKey,
+		dir:        dir,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		count:      len(existing),
+		logger:     logger,
+	}, nil
+}
+
+func (rec *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cursor := r.URL.Query().Get("next_page")
+
+	requestURL := rec.target
+	if cursor != "" {
+		requestURL = fmt.Sprintf("%s?next_page=%s", rec.target, url.QueryEscape(cursor))
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, requestURL, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if rec.apiKey != "" {
+		req.Header.Set("Authorization", rec.apiKey)
+	}
+
+	resp, err := rec.httpClient.Do(req)
+	if err != nil {
+		rec.logger.WithError(err).WithField("next_page", cursor).Error("Upstream request failed")
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	if resp.StatusCode == http.StatusOK && json.Valid(body) {
+		if err := rec.save(cursor, body); err != nil {
+			rec.logger.WithError(err).WithField("next_page", cursor).Error("Failed to record page")
+		}
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		w.Header().Set("Retry-After", retryAfter)
+	}
+	w.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	w.WriteHeader(resp.StatusCode)
+	w.Write(body)
+}
+
+func (rec *Recorder) save(cursor string, body []byte) error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	data, err := json.MarshalIndent(recording{Cursor: cursor, RecordedAt: time.Now().UTC(), Body: body}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	rec.count++
+	path := filepath.Join(rec.dir, fmt.Sprintf("page-%04d.json", rec.count))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return err
+	}
+
+	rec.logger.WithFields(logrus.Fields{
+		"next_page": cursor,
+		"file":      path,
+	}).Info("Recorded page")
+	return nil
+}
+
+// NewReplay returns a Server answering with the pages a Recorder saved in
+// dir. Pages are served by their recorded cursor; a cursor recorded more than
+// once replays its latest recording.
+func NewReplay(dir string, options Options) (*Server, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "page-*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("list recordings: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recordings found in %s", dir)
+	}
+	sort.Strings(files)
+
+	pages := make(map[string][]byte, len(files))
+	var cursors []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read recording: %w", err)
+		}
+
+		var rec recording
+		if err := json.Unmarshal(data, &rec); err != nil {
+			return nil, fmt.Errorf("parse recording %s: %w", file, err)
+		}
+
+		if _, seen := pages[rec.Cursor]; !seen {
+			cursors = append(cursors, rec.Cursor)
+		}
+		pages[rec.Cursor] = rec.Body
+	}
+
+	return newServer(pages, orderCursors(pages, cursors), options), nil
+}
+
+// orderCursors follows next_page links from the first page so page numbers
+// match the upstream's order; pages off that chain keep their file order.
+func orderCursors(pages map[string][]byte, cursors []string) []string {
+	ordered := make([]string, 0, len(cursors))
+	placed := make(map[string]bool, len(cursors))
+
+	cursor, ok := "", true
+	for ok && !placed[cursor] {
+		body, found := pages[cursor]
+		if !found {
+			break
+		}
+		ordered = append(ordered, cursor)
+		placed[cursor] = true
+
+		var page struct {
+			NextPage string `json:"next_page"`
+		}
+		ok = json.Unmarshal(body, &page) == nil && strings.TrimSpace(page.NextPage) != ""
+		cursor = page.NextPage
+	}
+
+	for _, cursor := range cursors {
+		if !placed[cursor] {
+			ordered = append(ordered, cursor)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,304 @@
+// Package fakeupstream serves the analyst-ratings API format locally so
+// ingestion can be developed and tested without the real upstream. A Server
+// paginates fixture, generated or recorded events with next_page cursors and
+// can inject latency and faults.
+package fakeupstream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+const defaultPageSize = 10
+
+// Options configures a Server. The zero value serves pages of ten events with
+// no latency, no authentication and no faults.
+type Options struct {
+	PageSize int
+	// Latency delays every response; Jitter adds a random extra delay up to
+	// its value.
+	Latency time.Duration
+	Jitter  time.Duration
+	// APIKey, when set, is required in the Authorization header, with or
+	// without a "Bearer " prefix.
+	APIKey string
+	Faults []Fault
+	// Seed drives jitter and fault rates; zero uses the current time.
+	Seed int64
+	// Logger reports served pages and injected faults; nil logs nothing.
+	Logger *logrus.Logger
+}
+
+// Server is an http.Handler speaking the upstream's ExternalAPIResponse
+// format. It is safe for concurrent use.
+type Server struct {
+	mu       sync.Mutex
+	pages    map[string][]byte
+	cursors  []string
+	options  Options
+	faults   []*faultState
+	requests []string
+	rand     *rand.Rand
+	logger   *logrus.Logger
+}
+
+// upstreamEvent mirrors the fields the real API sends for each item.
+type upstreamEvent struct {
+	Ticker     string    `json:"ticker"`
+	Company    string    `json:"company"`
+	TargetFrom string    `json:"target_from"`
+	TargetTo   string    `json:"target_to"`
+	Action     string    `json:"action"`
+	Brokerage  string    `json:"brokerage"`
+	RatingFrom string    `json:"rating_from"`
+	RatingTo   string    `json:"rating_to"`
+	Time       time.Time `json:"time"`
+}
+
+type upstreamPage struct {
+	Items    []upstreamEvent `json:"items"`
+	NextPage string          `json:"next_page,omitempty"`
+}
+
+// New returns a Server paginating events in the order given. As upstream
+// does, the cursor of each page is the ticker of the last event before it.
+func New(events []model.Stock, options Options) *Server {
+	pageSize := options.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	var chunks [][]model.Stock
+	for start := 0; start < len(events); start += pageSize {
+		end := start + pageSize
+		if end > len(events) {
+			end = len(events)
+		}
+		chunks = append(chunks, events[start:end])
+	}
+	if len(chunks) == 0 {
+		chunks = append(chunks, nil)
+	}
+
+	cursors := make([]string, len(chunks))
+	used := map[string]bool{"": true}
+	for i := 1; i < len(chunks); i++ {
+		previous := chunks[i-1]
+		cursor := previous[len(previous)-1].Ticker
+		for n := 2; used[cursor]; n++ {
+			cursor = fmt.Sprintf("%s~%d", previous[len(previous)-1].Ticker, n)
+		}
+		used[cursor] = true
+		cursors[i] = cursor
+	}
+
+	pages := make(map[string][]byte, len(chunks))
+	for i, chunk := range chunks {
+		page := upstreamPage{Items: make([]upstreamEvent, 0, len(chunk))}
+		for _, stock := range chunk {
+			page.Items = append(page.Items, upstreamEvent{
+				Ticker:     stock.Ticker,
+				Company:    stock.Company,
+				TargetFrom: stock.TargetFrom,
+				TargetTo:   stock.TargetTo,
+				Action:     stock.Action,
+				Brokerage:  stock.Brokerage,
+				RatingFrom: stock.RatingFrom,
+				RatingTo:   stock.RatingTo,
+				Time:       stock.Time,
+			})
+		}
+		if i+1 < len(chunks) {
+			page.NextPage = cursors[i+1]
+		}
+		body, _ := json.Marshal(page)
+		pages[cursors[i]] = body
+	}
+
+	return newServer(pages, cursors, options)
+}
+
+func newServer(pages map[string][]byte, cursors []string, options Options) *Server {
+	seed := options.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	logger := options.Logger
+	if logger == nil {
+		logger = logrus.New()
+		logger.SetLevel(logrus.PanicLevel)
+	}
+
+	s := &Server{
+		pages:   pages,
+		cursors: cursors,
+		options: options,
+		rand:    rand.New(rand.NewSource(seed)),
+		logger:  logger,
+	}
+	for _, fault := range options.Faults {
+		s.AddFault(fault)
+	}
+	return s
+}
+
+// NewTestServer starts an httptest.Server around New(events, options). The
+// caller closes the returned httptest.Server.
+func NewTestServer(events []model.Stock, options Options) (*Server, *httptest.Server) {
+	server := New(events, options)
+	return server, httptest.NewServer(server)
+}
+
+// AddFault injects a fault into subsequent requests.
+func (s *Server) AddFault(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &faultState{Fault: fault.withDefaults()})
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the next_page cursor of every request served so far,
+// including failed ones; the first page is the empty cursor.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// ResetRequests forgets the requests recorded so far.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// Cursors returns the cursor of every page in order, starting with the empty
+// cursor of the first page.
+func (s *Server) Cursors() []string {
+	return append([]string(nil), s.cursors...)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if s.options.APIKey != "" {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if key != s.options.APIKey {
+			http.Error(w, `{"error": "unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+	}
+
+	// HEAD is a health probe: it is neither recorded nor faulted
+	if r.Method == http.MethodHead {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	cursor := r.URL.Query().Get("next_page")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, cursor)
+	body, found := s.pages[cursor]
+	page := s.pageNumber(cursor)
+	fault := s.matchFault(page)
+	delay := s.options.Latency
+	if s.options.Jitter > 0 {
+		delay += time.Duration(s.rand.Int63n(int64(s.options.Jitter) + 1))
+	}
+	s.mu.Unlock()
+
+	if err := sleepContext(r.Context(), delay); err != nil {
+		return
+	}
+
+	if fault != nil {
+		s.logger.WithFields(logrus.Fields{
+			"page":      page,
+			"next_page": cursor,
+			"fault":     fault.Kind,
+		}).Info("Injecting fault")
+		fault.write(w, body)
+		return
+	}
+
+	if !found {
+		http.Error(w, fmt.Sprintf(`{"error": "unknown next_page %q"}`, cursor), http.StatusBadRequest)
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"page":      page,
+		"next_page": cursor,
+	}).Debug("Serving page")
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+// pageNumber returns the 1-based position of cursor, or 0 if it is unknown.
+func (s *Server) pageNumber(cursor string) int {
+	for i, c := range s.cursors {
+		if c == cursor {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// matchFault returns the first fault that applies to this request of page
+// and uses up one of its times. Callers hold s.mu.
+func (s *Server) matchFault(page int) *Fault {
+	for _, state := range s.faults {
+		if state.Page != 0 && state.Page != page {
+			continue
+		}
+		if state.Times > 0 && state.used >= state.Times {
+			continue
+		}
+		if state.Rate > 0 && s.rand.Float64() >= state.Rate {
+			continue
+		}
+		state.used++
+		fault := state.Fault
+		return &fault
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,242 @@
+package fakeupstream
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func getPage(t *testing.T, serverURL, cursor string) (*http.Response, []byte) {
+	t.Helper()
+
+	requestURL := serverURL
+	if cursor != "" {
+		requestURL += "?next_page=" + url.QueryEscape(cursor)
+	}
+	resp, err := http.Get(requestURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body json.RawMessage
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	return resp, body
+}
+
+func TestServer_Paginates(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+	events := Generate(25, 7, now)
+	server, httpServer := NewTestServer(events, Options{PageSize: 10})
+	defer httpServer.Close()
+
+	var tickers []string
+	cursor := ""
+	for pages := 0; pages < 10; pages++ {
+		resp, body := getPage(t, httpServer.URL, cursor)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var page model.ExternalAPIResponse
+		require.NoError(t, json.Unmarshal(body, &page))
+		for _, item := range page.Items {
+			tickers = append(tickers, item.Ticker)
+		}
+		if page.NextPage == "" {
+			break
+		}
+		cursor = page.NextPage
+	}
+
+	require.Len(t, tickers, 25)
+	for i, event := range events {
+		assert.Equal(t, event.Ticker, tickers[i])
+	}
+	assert.Len(t, server.Cursors(), 3)
+	assert.Equal(t, server.Cursors(), server.Requests())
+}
+
+func TestServer_UniqueCursorsForRepeatedTickers(t *testing.T) {
+	events := []model.Stock{{Ticker: "AAPL"}, {Ticker: "AAPL"}, {Ticker: "AAPL"}}
+	server := New(events, Options{PageSize: 1})
+
+	assert.Equal(t, []string{"", "AAPL", "AAPL~2"}, server.Cursors())
+}
+
+func TestServer_RequiresAPIKey(t *testing.T) {
+	_, httpServer := NewTestServer(Generate(3, 1, time.Now()), Options{APIKey: "secret"})
+	defer httpServer.Close()
+
+	resp, _ := getPage(t, httpServer.URL, "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, httpServer.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServer_InjectsFaults(t *testing.T) {
+	events := Generate(4, 1, time.Now())
+	server, httpServer := NewTestServer(events, Options{PageSize: 2})
+	defer httpServer.Close()
+	second := server.Cursors()[1]
+
+	server.AddFault(Fault{Kind: FaultStatus, Status: http.StatusServiceUnavailable, Page: 2, Times: 1})
+	server.AddFault(Fault{Kind: FaultRateLimit, Page: 1, Times: 1, RetryAfter: 1500 * time.Millisecond})
+
+	resp, _ := getPage(t, httpServer.URL, "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+
+	resp, _ = getPage(t, httpServer.URL, second)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// Both faults are used up
+	resp, _ = getPage(t, httpServer.URL, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	resp, _ = getPage(t, httpServer.URL, second)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServer_InjectsBrokenBodies(t *testing.T) {
+	server, httpServer := NewTestServer(Generate(2, 1, time.Now()), Options{})
+	defer httpServer.Close()
+
+	server.AddFault(Fault{Kind: FaultTruncated, Times: 1})
+	server.AddFault(Fault{Kind: FaultMalformed, Times: 1})
+
+	for range 2 {
+		resp, err := http.Get(httpServer.URL)
+		require.NoError(t, err)
+		var page model.ExternalAPIResponse
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Error(t, json.NewDecoder(resp.Body).Decode(&page))
+		resp.Body.Close()
+	}
+
+	server.ClearFaults()
+	resp, _ := getPage(t, httpServer.URL, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestServer_Latency(t *testing.T) {
+	_, httpServer := NewTestServer(nil, Options{Latency: 50 * time.Millisecond})
+	defer httpServer.Close()
+
+	start := time.Now()
+	resp, body := getPage(t, httpServer.URL, "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"items": []}`, string(body))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestParseFault(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Fault
+		wantErr bool
+	}{
+		{spec: "503", want: Fault{Kind: FaultStatus, Status: 503}},
+		{spec: "kind=status,status=502,page=2,times=1", want: Fault{Kind: FaultStatus, Status: 502, Page: 2, Times: 1}},
+		{spec: "kind=rate_limit,retry_after=2s", want: Fault{Kind: FaultRateLimit, RetryAfter: 2 * time.Second}},
+		{spec: "kind=truncated,rate=0.25", want: Fault{Kind: FaultTruncated, Rate: 0.25}},
+		{spec: "status=500", want: Fault{Kind: FaultStatus, Status: 500}},
+		{spec: "kind=teapot", wantErr: true},
+		{spec: "kind=malformed,rate=2", wantErr: true},
+		{spec: "page=2", wantErr: true},
+		{spec: "kind=status,times=x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			fault, err := ParseFault(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, fault)
+		})
+	}
+}
+
+func TestLoadFixture(t *testing.T) {
+	dir := t.TempDir()
+
+	array := filepath.Join(dir, "array.json")
+	require.NoError(t, os.WriteFile(array, []byte(`[{"ticker": "AAPL"}, {"ticker": "MSFT"}]`), 0o644))
+	events, err := LoadFixture(array)
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	page := filepath.Join(dir, "page.json")
+	require.NoError(t, os.WriteFile(page, []byte(`{"items": [{"ticker": "NVDA"}], "next_page": "NVDA"}`), 0o644))
+	events, err = LoadFixture(page)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "NVDA", events[0].Ticker)
+
+	_, err = LoadFixture(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestGenerate_IsDeterministic(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 10, 30, 0, 0, time.UTC)
+
+	first := Generate(5, 42, now)
+	assert.Equal(t, first, Generate(5, 42, now))
+	assert.Equal(t, now.Truncate(time.Hour), first[0].Time)
+	assert.True(t, first[0].Time.After(first[4].Time))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	upstream, upstreamServer := NewTestServer(Generate(5, 3, time.Now()), Options{PageSize: 2, APIKey: "real-key"})
+	defer upstreamServer.Close()
+
+	dir := t.TempDir()
+	recorder, err := NewRecorder(upstreamServer.URL, "real-key", dir, nil)
+	require.NoError(t, err)
+	recorderServer := httptest.NewServer(recorder)
+	defer recorderServer.Close()
+
+	var recorded [][]byte
+	cursor := ""
+	for {
+		resp, body := getPage(t, recorderServer.URL, cursor)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		recorded = append(recorded, body)
+
+		var page model.ExternalAPIResponse
+		require.NoError(t, json.Unmarshal(body, &page))
+		if page.NextPage == "" {
+			break
+		}
+		cursor = page.NextPage
+	}
+	require.Len(t, recorded, 3)
+
+	replay, err := NewReplay(dir, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, upstream.Cursors(), replay.Cursors())
+
+	replayServer := httptest.NewServer(replay)
+	defer replayServer.Close()
+	for i, cursor := range replay.Cursors() {
+		resp, body := getPage(t, replayServer.URL, cursor)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, string(recorded[i]), string(body))
+	}
+
+	resp, _ := getPage(t, replayServer.URL, "unknown")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -75,6 +76,18 @@ func parseFilterParams(c *gin.Context) (rating, sortBy, order string) {
 	return rating, sortBy, order
 }
 
+// requestUser names the admin making the request: the subject of the token
+// accepted by AuthMiddleware, or "" for unauthenticated requests.
+func requestUser(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	if subject, ok := c.Get("user_subject"); ok && subject != nil {
+		return fmt.Sprint(subject)
+	}
+	return ""
+}
+
 func handleError(c *gin.Context, err error, operation string, logger *logrus.Logger) {
 	logger.WithError(err).Errorf("Failed to %s", operation)
 
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/job"
+)
+
+// dateLayout is the date-only form accepted by the from and to query
+// parameters, besides RFC 3339 timestamps.
+const dateLayout = "2006-01-02"
+
+type JobsHandler struct {
+	jobManager job.JobManagerInterface
+	logger     *logrus.Logger
+}
+
+func NewJobsHandler(jobManager job.JobManagerInterface, logger *logrus.Logger) *JobsHandler {
+	return &JobsHandler{
+		jobManager: jobManager,
+		logger:     logger,
+	}
+}
+
+// ListJobs lists jobs newest first, filtered by the type and status query
+// parameters and by creation time with from and to.
+func (h *JobsHandler) ListJobs(c *gin.Context) {
+	limit, offset, _, _ := parsePaginationParams(c)
+
+	filter, err := parseJobFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	jobs, total, err := h.jobManager.ListJobs(filter, limit, offset)
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to list jobs")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to list jobs",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs":   jobs,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *JobsHandler) GetJobStatus(c *gin.Context) {
+	jobID := c.Param("jobId")
+	if jobID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status":  "error",
+			"message": "Job ID is required",
+		})
+		return
+	}
+
+	job, exists := h.jobManager.GetJob(jobID)
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Job not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"job":    job,
+	})
+}
+
+// CancelJob cancels a pending or running job; it serves both DELETE
+// /jobs/:jobId and POST /jobs/:jobId/cancel.
+func (h *JobsHandler) CancelJob(c *gin.Context) {
+	jobID := c.Param("jobId")
+	h.logger.WithFields(logrus.Fields{
+		"job_id":  jobID,
+		"user_id": requestUser(c),
+	}).Info("Job cancellation requested")
+
+	cancelled, err := h.jobManager.CancelJob(jobID)
+	switch {
+	case stderrors.Is(err, job.ErrJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"status":  "error",
+			"message": "Job not found",
+		})
+		return
+	case stderrors.Is(err, job.ErrJobFinished):
+		c.JSON(http.StatusConflict, gin.H{
+			"status":  "error",
+			"message": "Job already " + string(cancelled.Status),
+			"job":     cancelled,
+		})
+		return
+	case err != nil:
+		h.logger.WithError(err).WithField("job_id", jobID).Error("Failed to cancel job")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"status":  "error",
+			"message": "Failed to cancel job",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Job cancelled",
+		"job":     cancelled,
+	})
+}
+
+func parseJobFilter(c *gin.Context) (job.JobFilter, error) {
+	filter := job.JobFilter{
+		Type:   job.JobType(c.Query("type")),
+		Status: job.JobStatus(c.Query("status")),
+	}
+
+	if filter.Type != "" && !filter.Type.IsValid() {
+		return filter, fmt.Errorf("invalid job type %q: must be ingest, recommendations or backfill", filter.Type)
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return filter, fmt.Errorf("invalid job status %q: must be pending, running, completed, failed or cancelled", filter.Status)
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseJobTime(c.Query("from"), false); err != nil {
+		return filter, fmt.Errorf("invalid from: %w", err)
+	}
+	if filter.CreatedTo, err = parseJobTime(c.Query("to"), true); err != nil {
+		return filter, fmt.Errorf("invalid to: %w", err)
+	}
+
+	return filter, nil
+}
+
+// parseJobTime parses an RFC 3339 timestamp or a date. As an upper bound a
+// date includes the whole day.
+func parseJobTime(value string, upper bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 timestamp nor a YYYY-MM-DD date", value)
+	}
+	if upper {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+// parseJobPriority reads the priority query parameter of requests that
+// enqueue a job; it defaults to 0.
+func parseJobPriority(c *gin.Context) (int, error) {
+	value := c.Query("priority")
+	if value == "" {
+		return 0, nil
+	}
+
+	priority, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid priority %q: must be an integer", value)
+	}
+	return priority, nil
+}
+
+// enqueuedJobResponse answers a request that enqueued a job: 202 with the
+// new job, or 200 with the pending or running job that already does the
+// same work.
+func enqueuedJobResponse(enqueued *job.Job, existing bool, message string) (int, gin.H) {
+	if existing {
+		return http.StatusOK, gin.H{
+			"status":  "existing",
+			"message": fmt.Sprintf("An equivalent %s job is already %s", enqueued.Type, enqueued.Status),
+			"job_id":  enqueued.ID,
+			"job":     enqueued,
+		}
+	}
+
+	return http.StatusAccepted, gin.H{
+		"status":  "accepted",
+		"message": message,
+		"job_id":  enqueued.ID,
+		"job":     enqueued,
+	}
+}
@@ -0,0 +1,332 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/valeriapadilla/stock-insights/internal/job"
+)
+
+type MockJobManager struct {
+	mock.Mock
+}
+
+func (m *MockJobManager) CreateJob(jobType job.JobType, createdBy string) (*job.Job, error) {
+	args := m.Called(jobType, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*job.Job), args.Error(1)
+}
+
+func (m *MockJobManager) GetJob(jobID string) (*job.Job, bool) {
+	args := m.Called(jobID)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1)
+	}
+	return args.Get(0).(*job.Job), args.Bool(1)
+}
+
+func (m *MockJobManager) ListJobs(filter job.JobFilter, limit, offset int) ([]*job.Job, int, error) {
+	args := m.Called(filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*job.Job), args.Int(1), args.Error(2)
+}
+
+func (m *MockJobManager) UpdateJob(jobID string, status job.JobStatus, progress int, message string) {
+	m.Called(jobID, status, progress, message)
+}
+
+func (m *MockJobManager) SetJobError(jobID string, err error) {
+	m.Called(jobID, err)
+}
+
+func (m *MockJobManager) RunJobAsync(jobID string, work job.WorkFunc) error {
+	args := m.Called(jobID, work)
+	return args.Error(0)
+}
+
+func (m *MockJobManager) Enqueue(request job.JobRequest, work job.WorkFunc) (*job.Job, bool, error) {
+	args := m.Called(request, work)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).(*job.Job), args.Bool(1), args.Error(2)
+}
+
+func (m *MockJobManager) CancelJob(jobID string) (*job.Job, error) {
+	args := m.Called(jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*job.Job), args.Error(1)
+}
+
+func (m *MockJobManager) CleanupOldJobs(maxAge time.Duration) {
+	m.Called(maxAge)
+}
+
+func TestJobsHandler_GetJobStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		jobID          string
+		mockJob        *job.Job
+		expectedStatus int
+		setupMocks     func(*MockJobManager)
+	}{
+		{
+			name:  "successful get status",
+			jobID: "test-job-id",
+			mockJob: &job.Job{
+				ID:        "test-job-id",
+				Status:    job.JobStatusCompleted,
+				CreatedAt: time.Now(),
+				StartedAt: &time.Time{},
+				Progress:  100,
+				Message:   "Job completed successfully",
+			},
+			expectedStatus: http.StatusOK,
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("GetJob", "test-job-id").Return(&job.Job{
+					ID:        "test-job-id",
+					Status:    job.JobStatusCompleted,
+					CreatedAt: time.Now(),
+					StartedAt: &time.Time{},
+					Progress:  100,
+					Message:   "Job completed successfully",
+				}, true)
+			},
+		},
+		{
+			name:           "job not found",
+			jobID:          "invalid-job-id",
+			mockJob:        nil,
+			expectedStatus: http.StatusNotFound,
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("GetJob", "invalid-job-id").Return(nil, false)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			mockJobManager := &MockJobManager{}
+			tt.setupMocks(mockJobManager)
+
+			handler := &JobsHandler{
+				jobManager: mockJobManager,
+				logger:     logrus.New(),
+			}
+
+			req, _ := http.NewRequest("GET", "/api/v1/admin/jobs/"+tt.jobID, nil)
+			w := httptest.NewRecorder()
+
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "jobId", Value: tt.jobID}}
+
+			handler.GetJobStatus(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			mockJobManager.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobsHandler_CancelJob(t *testing.T) {
+	tests := []struct {
+		name           string
+		jobID          string
+		expectedStatus int
+		expectedBody   string
+		setupMocks     func(*MockJobManager)
+	}{
+		{
+			name:           "running job cancelled",
+			jobID:          "test-job-id",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"status":"cancelled"`,
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("CancelJob", "test-job-id").Return(&job.Job{ID: "test-job-id", Status: job.JobStatusCancelled}, nil)
+			},
+		},
+		{
+			name:           "job not found",
+			jobID:          "invalid-job-id",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "Job not found",
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("CancelJob", "invalid-job-id").Return(nil, job.ErrJobNotFound)
+			},
+		},
+		{
+			name:           "job already finished",
+			jobID:          "test-job-id",
+			expectedStatus: http.StatusConflict,
+			expectedBody:   "Job already completed",
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("CancelJob", "test-job-id").Return(&job.Job{ID: "test-job-id", Status: job.JobStatusCompleted}, job.ErrJobFinished)
+			},
+		},
+		{
+			name:           "store failure",
+			jobID:          "test-job-id",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to cancel job",
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("CancelJob", "test-job-id").Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockJobManager := &MockJobManager{}
+			tt.setupMocks(mockJobManager)
+
+			handler := &JobsHandler{
+				jobManager: mockJobManager,
+				logger:     logrus.New(),
+			}
+
+			// Create request
+			req, _ := http.NewRequest("DELETE", "/api/v1/admin/jobs/"+tt.jobID, nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "jobId", Value: tt.jobID}}
+
+			// Execute
+			handler.CancelJob(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+
+			// Verify mocks
+			mockJobManager.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJobsHandler_ListJobs(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedBody   string
+		setupMocks     func(*MockJobManager)
+	}{
+		{
+			name:           "all jobs",
+			query:          "",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"total":2`,
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("ListJobs", job.JobFilter{}, 50, 0).Return([]*job.Job{
+					{ID: "job-2", Type: job.JobTypeRecommendations, Status: job.JobStatusPending},
+					{ID: "job-1", Type: job.JobTypeIngest, Status: job.JobStatusRunning},
+				}, 2, nil)
+			},
+		},
+		{
+			name:           "filtered by type, status and date",
+			query:          "?type=ingest&status=running&from=2026-10-01&to=2026-10-02T12:00:00Z&limit=10&offset=20",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"limit":10`,
+			setupMocks: func(jobManager *MockJobManager) {
+				filter := job.JobFilter{
+					Type:        job.JobTypeIngest,
+					Status:      job.JobStatusRunning,
+					CreatedFrom: from,
+					CreatedTo:   time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC),
+				}
+				jobManager.On("ListJobs", filter, 10, 20).Return([]*job.Job{}, 0, nil)
+			},
+		},
+		{
+			name:           "date upper bound includes the whole day",
+			query:          "?to=2026-10-01",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"jobs":[]`,
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("ListJobs", job.JobFilter{CreatedTo: from.AddDate(0, 0, 1)}, 50, 0).Return([]*job.Job{}, 0, nil)
+			},
+		},
+		{
+			name:           "invalid type",
+			query:          "?type=reindex",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid job type",
+			setupMocks:     func(jobManager *MockJobManager) {},
+		},
+		{
+			name:           "invalid status",
+			query:          "?status=done",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid job status",
+			setupMocks:     func(jobManager *MockJobManager) {},
+		},
+		{
+			name:           "invalid date",
+			query:          "?from=yesterday",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid from",
+			setupMocks:     func(jobManager *MockJobManager) {},
+		},
+		{
+			name:           "store failure",
+			query:          "",
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to list jobs",
+			setupMocks: func(jobManager *MockJobManager) {
+				jobManager.On("ListJobs", mock.Anything, 50, 0).Return(nil, 0, assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockJobManager := &MockJobManager{}
+			tt.setupMocks(mockJobManager)
+
+			handler := NewJobsHandler(mockJobManager, logrus.New())
+
+			// Create request
+			req, _ := http.NewRequest("GET", "/api/v1/admin/jobs"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			// Execute
+			handler.ListJobs(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+
+			// Verify mocks
+			mockJobManager.AssertExpectations(t)
+		})
+	}
+}
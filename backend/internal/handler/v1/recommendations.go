@@ -1,26 +1,35 @@
 package v1
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/job"
+	"github.com/valeriapadilla/stock-insights/internal/model"
 	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
 	"github.com/valeriapadilla/stock-insights/internal/validator"
 )
 
 type RecommendationsHandler struct {
 	recommendationService interfaces.RecommendationServiceInterface
+	jobManager            job.JobManagerInterface
 	logger                *logrus.Logger
 }
 
 func NewRecommendationsHandler(
 	recommendationService interfaces.RecommendationServiceInterface,
+	jobManager job.JobManagerInterface,
 	logger *logrus.Logger,
 ) *RecommendationsHandler {
 	return &RecommendationsHandler{
 		recommendationService: recommendationService,
+		jobManager:            jobManager,
 		logger:                logger,
 	}
 }
@@ -48,28 +57,220 @@ func (h *RecommendationsHandler) GetRecommendations(c *gin.Context) {
 	})
 }
 
+// calculationPollInterval is how often a synchronous calculation checks
+// whether its job ended without a result, for instance cancelled.
+const calculationPollInterval = 500 * time.Millisecond
+
+// calculation is the outcome of the work of a recommendations job; stage
+// names the step that failed.
+type calculation struct {
+	run   *model.RecommendationRun
+	stage string
+	err   error
+}
+
+// CalculateRecommendations calculates and saves a recommendation run as a
+// recommendations job, so it is deduplicated with and queued behind the
+// other jobs. It waits for the run, or with async=true answers with the job
+// right away. While a job with the same parameters is pending or running, no
+// other is queued: async callers get that job and the others wait for its
+// run.
 func (h *RecommendationsHandler) CalculateRecommendations(c *gin.Context) {
 	params := h.parseRecommendationParams(c)
 
-	recommendations, err := h.recommendationService.CalculateRecommendations(params)
+	async, err := strconv.ParseBool(c.DefaultQuery("async", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": "async must be true or false",
+		})
+		return
+	}
+	priority, err := parseJobPriority(c)
 	if err != nil {
-		handleError(c, err, "calculate recommendations", h.logger)
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": err.Error(),
+		})
 		return
 	}
 
-	if err := h.recommendationService.SaveRecommendations(recommendations); err != nil {
-		handleError(c, err, "save recommendations", h.logger)
+	var outcome chan calculation
+	if !async {
+		outcome = make(chan calculation, 1)
+	}
+	request := job.JobRequest{
+		Type:      job.JobTypeRecommendations,
+		Priority:  priority,
+		Key:       recommendationJobKey(params),
+		CreatedBy: requestUser(c),
+	}
+	enqueued, existing, err := h.jobManager.Enqueue(request, h.calculationWork(params, outcome))
+	if err != nil {
+		handleError(c, err, "enqueue recommendations job", h.logger)
+		return
+	}
+
+	if async {
+		c.JSON(enqueuedJobResponse(enqueued, existing, "Recommendations job queued"))
+		return
+	}
+	if existing {
+		// the work of the existing job reports elsewhere
+		outcome = nil
+	}
+	h.awaitCalculation(c, enqueued.ID, outcome)
+}
+
+// recommendationJobKey deduplicates calculations with the same parameters,
+// after the defaults the service applies.
+func recommendationJobKey(params validator.RecommendationParams) string {
+	params = validator.NewRecommendationValidator().ValidateRecommendationParams(params)
+	strategy := params.Strategy
+	if strategy == "" {
+		strategy = "default"
+	}
+	return fmt.Sprintf("recommendations:%s:%d:%d:%d", strategy, params.DaysBack, params.MinScore, params.MaxResults)
+}
+
+// calculationWork calculates and saves a run, sending the outcome on outcome
+// when it is not nil. A job cancelled or past its deadline fails its run
+// instead of saving it.
+func (h *RecommendationsHandler) calculationWork(params validator.RecommendationParams, outcome chan<- calculation) job.WorkFunc {
+	report := func(result calculation) {
+		if outcome != nil {
+			outcome <- result
+		}
+	}
+
+	return func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+		progress.Report(0, "Calculating recommendations")
+		run, err := h.recommendationService.CalculateRecommendations(ctx, params)
+		if err != nil {
+			report(calculation{stage: "calculate recommendations", err: err})
+			return nil, err
+		}
+		if ctx.Err() != nil {
+			h.recommendationService.FailRun(run, context.Cause(ctx))
+			return nil, ctx.Err()
+		}
+
+		progress.Report(80, "Saving recommendations")
+		if err := h.recommendationService.SaveRecommendations(run); err != nil {
+			report(calculation{stage: "save recommendations", err: err})
+			return nil, err
+		}
+		report(calculation{run: run})
+		return map[string]any{
+			"run_id": run.ID,
+			"total":  len(run.Recommendations),
+		}, nil
+	}
+}
+
+// awaitCalculation answers with the outcome of the job jobID, taken from
+// outcome or, for a job started by another request, from the run it saved.
+// When the client goes away the job carries on.
+func (h *RecommendationsHandler) awaitCalculation(c *gin.Context, jobID string, outcome <-chan calculation) {
+	ticker := time.NewTicker(calculationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case result := <-outcome:
+			h.writeCalculation(c, result)
+			return
+		case <-c.Request.Context().Done():
+			h.logger.WithField("job_id", jobID).Info("Client left before recommendations were calculated, job continues")
+			return
+		case <-ticker.C:
+			stored, exists := h.jobManager.GetJob(jobID)
+			if !exists || !stored.Status.IsFinished() {
+				continue
+			}
+			select {
+			case result := <-outcome:
+				h.writeCalculation(c, result)
+			default:
+				if run := h.savedRun(stored); run != nil {
+					h.writeCalculation(c, calculation{run: run})
+					return
+				}
+				c.JSON(http.StatusConflict, gin.H{
+					"status":  "error",
+					"message": "Recommendations job " + string(stored.Status),
+					"job_id":  jobID,
+					"job":     stored,
+				})
+			}
+			return
+		}
+	}
+}
+
+// savedRun returns the run a completed recommendations job saved, or nil.
+func (h *RecommendationsHandler) savedRun(finished *job.Job) *model.RecommendationRun {
+	if finished.Status != job.JobStatusCompleted || len(finished.Result) == 0 {
+		return nil
+	}
+
+	var result struct {
+		RunID string `json:"run_id"`
+	}
+	if err := json.Unmarshal(finished.Result, &result); err != nil || result.RunID == "" {
+		return nil
+	}
+	run, err := h.recommendationService.GetRun(result.RunID)
+	if err != nil {
+		h.logger.WithError(err).WithField("run_id", result.RunID).Warn("Failed to load run of recommendations job")
+		return nil
+	}
+	return run
+}
+
+func (h *RecommendationsHandler) writeCalculation(c *gin.Context, result calculation) {
+	if result.err != nil {
+		handleError(c, result.err, result.stage, h.logger)
 		return
 	}
 
+	run := result.run
 	c.JSON(http.StatusOK, gin.H{
 		"message":         "Recommendations calculated and saved successfully",
-		"recommendations": recommendations,
-		"total":           len(recommendations),
-		"run_at":          recommendations[0].RunAt,
+		"recommendations": run.Recommendations,
+		"total":           len(run.Recommendations),
+		"run_id":          run.ID,
+		"run_at":          run.StartedAt,
 	})
 }
 
+func (h *RecommendationsHandler) GetRuns(c *gin.Context) {
+	limit, offset, _, _ := parsePaginationParams(c)
+
+	runs, total, err := h.recommendationService.GetRuns(limit, offset)
+	if err != nil {
+		handleError(c, err, "retrieve recommendation runs", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"runs":   runs,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+func (h *RecommendationsHandler) GetRun(c *gin.Context) {
+	run, err := h.recommendationService.GetRun(c.Param("id"))
+	if err != nil {
+		handleError(c, err, "retrieve recommendation run", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
 func (h *RecommendationsHandler) parseLimitParam(c *gin.Context) (int, error) {
 	limitStr := c.DefaultQuery("limit", "10")
 	limit, err := strconv.Atoi(limitStr)
@@ -83,6 +284,7 @@ func (h *RecommendationsHandler) parseRecommendationParams(c *gin.Context) valid
 	daysBackStr := c.DefaultQuery("days_back", "7")
 	maxResultsStr := c.DefaultQuery("max_results", "30")
 	minScoreStr := c.DefaultQuery("min_score", "80")
+	strategy := c.DefaultQuery("strategy", "")
 
 	daysBack, _ := strconv.Atoi(daysBackStr)
 	maxResults, _ := strconv.Atoi(maxResultsStr)
@@ -92,5 +294,6 @@ func (h *RecommendationsHandler) parseRecommendationParams(c *gin.Context) valid
 		DaysBack:   daysBack,
 		MaxResults: maxResults,
 		MinScore:   minScore,
+		Strategy:   strategy,
 	}
 }
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,9 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/job"
 	"github.com/valeriapadilla/stock-insights/internal/model"
 	"github.com/valeriapadilla/stock-insights/internal/validator"
 )
@@ -21,9 +25,12 @@ type MockRecommendationService struct {
 	mock.Mock
 }
 
-func (m *MockRecommendationService) CalculateRecommendations(params validator.RecommendationParams) ([]*model.Recommendation, error) {
-	args := m.Called(params)
-	return args.Get(0).([]*model.Recommendation), args.Error(1)
+func (m *MockRecommendationService) CalculateRecommendations(ctx context.Context, params validator.RecommendationParams) (*model.RecommendationRun, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecommendationRun), args.Error(1)
 }
 
 func (m *MockRecommendationService) GetLatestRecommendations(limit int) ([]*model.Recommendation, error) {
@@ -31,11 +38,36 @@ func (m *MockRecommendationService) GetLatestRecommendations(limit int) ([]*mode
 	return args.Get(0).([]*model.Recommendation), args.Error(1)
 }
 
-func (m *MockRecommendationService) SaveRecommendations(recommendations []*model.Recommendation) error {
-	args := m.Called(recommendations)
+func (m *MockRecommendationService) SaveRecommendations(run *model.RecommendationRun) error {
+	args := m.Called(run)
 	return args.Error(0)
 }
 
+func (m *MockRecommendationService) FailRun(run *model.RecommendationRun, cause error) {
+	m.Called(run, cause)
+}
+
+func (m *MockRecommendationService) GetLastRunTime() (*time.Time, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockRecommendationService) GetRuns(limit, offset int) ([]*model.RecommendationRun, int, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]*model.RecommendationRun), args.Int(1), args.Error(2)
+}
+
+func (m *MockRecommendationService) GetRun(runID string) (*model.RecommendationRun, error) {
+	args := m.Called(runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecommendationRun), args.Error(1)
+}
+
 // Tests
 func TestRecommendationsHandler_GetRecommendations(t *testing.T) {
 	tests := []struct {
@@ -116,10 +148,7 @@ func TestRecommendationsHandler_GetRecommendations(t *testing.T) {
 			mockService := &MockRecommendationService{}
 			tt.setupMocks(mockService)
 
-			handler := &RecommendationsHandler{
-				recommendationService: mockService,
-				logger:                logrus.New(),
-			}
+			handler := NewRecommendationsHandler(mockService, job.NewJobManager(2, logrus.New()), logrus.New())
 
 			// Create request
 			req, _ := http.NewRequest("GET", "/api/v1/public/recommendations"+tt.queryParams, nil)
@@ -179,14 +208,19 @@ func TestRecommendationsHandler_CalculateRecommendations(t *testing.T) {
 				"total":           1,
 			},
 			setupMocks: func(service *MockRecommendationService) {
-				service.On("CalculateRecommendations", mock.Anything).Return([]*model.Recommendation{
-					{
-						ID:          "1",
-						Ticker:      "AAPL",
-						Score:       95,
-						Explanation: "Test explanation",
-						RunAt:       time.Now(),
-						Rank:        1,
+				service.On("CalculateRecommendations", mock.Anything, mock.Anything).Return(&model.RecommendationRun{
+					ID:        "run-1",
+					Status:    model.RecommendationRunStatusRunning,
+					StartedAt: time.Now(),
+					Recommendations: []*model.Recommendation{
+						{
+							ID:          "1",
+							Ticker:      "AAPL",
+							Score:       95,
+							Explanation: "Test explanation",
+							RunAt:       time.Now(),
+							Rank:        1,
+						},
 					},
 				}, nil)
 				service.On("SaveRecommendations", mock.Anything).Return(nil)
@@ -206,7 +240,7 @@ func TestRecommendationsHandler_CalculateRecommendations(t *testing.T) {
 				"message": "Failed to calculate recommendations",
 			},
 			setupMocks: func(service *MockRecommendationService) {
-				service.On("CalculateRecommendations", mock.Anything).Return([]*model.Recommendation{}, assert.AnError)
+				service.On("CalculateRecommendations", mock.Anything, mock.Anything).Return(nil, assert.AnError)
 			},
 		},
 	}
@@ -218,10 +252,7 @@ func TestRecommendationsHandler_CalculateRecommendations(t *testing.T) {
 			mockService := &MockRecommendationService{}
 			tt.setupMocks(mockService)
 
-			handler := &RecommendationsHandler{
-				recommendationService: mockService,
-				logger:                logrus.New(),
-			}
+			handler := NewRecommendationsHandler(mockService, job.NewJobManager(2, logrus.New()), logrus.New())
 
 			// Create request body
 			body, _ := json.Marshal(tt.requestBody)
@@ -248,3 +279,299 @@ func TestRecommendationsHandler_CalculateRecommendations(t *testing.T) {
 		})
 	}
 }
+
+func TestRecommendationsHandler_CalculateRecommendationsStrategy(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := &MockRecommendationService{}
+	mockService.On("CalculateRecommendations", mock.Anything, validator.RecommendationParams{
+		DaysBack:   7,
+		MaxResults: 30,
+		MinScore:   80,
+		Strategy:   "consensus",
+	}).Return(nil, errors.NewValidationError("unknown scoring strategy", nil))
+
+	handler := NewRecommendationsHandler(mockService, job.NewJobManager(2, logrus.New()), logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/api/v1/admin/recommendations/calculate?strategy=consensus", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.CalculateRecommendations(c)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Verify mocks
+	mockService.AssertExpectations(t)
+}
+
+func TestRecommendationsHandler_CalculateRecommendationsEmptyRun(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := &MockRecommendationService{}
+	run := &model.RecommendationRun{ID: "run-1", StartedAt: time.Now()}
+	mockService.On("CalculateRecommendations", mock.Anything, mock.Anything).Return(run, nil)
+	mockService.On("SaveRecommendations", run).Return(nil)
+
+	handler := NewRecommendationsHandler(mockService, job.NewJobManager(2, logrus.New()), logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/api/v1/admin/recommendations/calculate", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.CalculateRecommendations(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "run-1", response["run_id"])
+	assert.Equal(t, float64(0), response["total"])
+
+	// Verify mocks
+	mockService.AssertExpectations(t)
+}
+
+func TestRecommendationsHandler_CalculateRecommendationsAsync(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := &MockRecommendationService{}
+	run := &model.RecommendationRun{
+		ID:              "run-1",
+		StartedAt:       time.Now(),
+		Recommendations: []*model.Recommendation{{Ticker: "AAPL"}},
+	}
+	mockService.On("CalculateRecommendations", mock.Anything, mock.Anything).Return(run, nil)
+	mockService.On("SaveRecommendations", run).Return(nil)
+
+	var work job.WorkFunc
+	mockJobManager := &MockJobManager{}
+	request := job.JobRequest{Type: job.JobTypeRecommendations, Key: "recommendations:default:7:80:30", CreatedBy: "admin"}
+	mockJobManager.On("Enqueue", request, mock.Anything).Run(func(args mock.Arguments) {
+		work = args.Get(1).(job.WorkFunc)
+	}).Return(&job.Job{ID: "test-job-id", Type: job.JobTypeRecommendations, Status: job.JobStatusPending}, false, nil)
+
+	handler := NewRecommendationsHandler(mockService, mockJobManager, logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/api/v1/admin/recommendations/calculate?async=true", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Set("user_subject", "admin")
+
+	// Execute
+	handler.CalculateRecommendations(c)
+	require.NotNil(t, work)
+	result, err := work(context.Background(), nil)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"job_id":"test-job-id"`)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"run_id": "run-1", "total": 1}, result)
+
+	// Verify mocks
+	mockService.AssertExpectations(t)
+	mockJobManager.AssertExpectations(t)
+}
+
+func TestRecommendationsHandler_CalculateRecommendationsCancelled(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := &MockRecommendationService{}
+	run := &model.RecommendationRun{ID: "run-1", StartedAt: time.Now()}
+	mockService.On("CalculateRecommendations", mock.Anything, mock.Anything).Return(run, nil)
+	mockService.On("FailRun", run, job.ErrJobCancelled).Return()
+
+	var work job.WorkFunc
+	mockJobManager := &MockJobManager{}
+	mockJobManager.On("Enqueue", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		work = args.Get(1).(job.WorkFunc)
+	}).Return(&job.Job{ID: "test-job-id", Type: job.JobTypeRecommendations, Status: job.JobStatusPending}, false, nil)
+
+	handler := NewRecommendationsHandler(mockService, mockJobManager, logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/api/v1/admin/recommendations/calculate?async=true", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.CalculateRecommendations(c)
+	require.NotNil(t, work)
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(job.ErrJobCancelled)
+	_, err := work(ctx, nil)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// Verify mocks
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "SaveRecommendations", mock.Anything)
+}
+
+func TestRecommendationsHandler_CalculateRecommendationsExisting(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := &MockRecommendationService{}
+	run := &model.RecommendationRun{ID: "run-1", StartedAt: time.Now(), Recommendations: []*model.Recommendation{{Ticker: "AAPL"}}}
+	mockService.On("GetRun", "run-1").Return(run, nil)
+
+	mockJobManager := &MockJobManager{}
+	request := job.JobRequest{Type: job.JobTypeRecommendations, Key: "recommendations:consensus:7:80:30"}
+	mockJobManager.On("Enqueue", request, mock.Anything).
+		Return(&job.Job{ID: "running-job-id", Type: job.JobTypeRecommendations, Status: job.JobStatusRunning}, true, nil)
+	mockJobManager.On("GetJob", "running-job-id").
+		Return(&job.Job{ID: "running-job-id", Status: job.JobStatusCompleted, Result: []byte(`{"run_id": "run-1", "total": 1}`)}, true)
+
+	handler := NewRecommendationsHandler(mockService, mockJobManager, logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/api/v1/admin/recommendations/calculate?strategy=Consensus", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.CalculateRecommendations(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"run_id":"run-1"`)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	// Verify mocks
+	mockService.AssertNotCalled(t, "CalculateRecommendations", mock.Anything, mock.Anything)
+	mockService.AssertExpectations(t)
+	mockJobManager.AssertExpectations(t)
+}
+
+func TestRecommendationJobKey(t *testing.T) {
+	assert.Equal(t, "recommendations:default:7:80:30", recommendationJobKey(validator.RecommendationParams{DaysBack: 7, MinScore: 80, MaxResults: 30}))
+	assert.Equal(t, "recommendations:consensus:7:0:0", recommendationJobKey(validator.RecommendationParams{Strategy: " Consensus "}))
+	assert.NotEqual(t,
+		recommendationJobKey(validator.RecommendationParams{DaysBack: 7, MinScore: 80, MaxResults: 30}),
+		recommendationJobKey(validator.RecommendationParams{DaysBack: 14, MinScore: 80, MaxResults: 30}))
+}
+
+func TestRecommendationsHandler_GetRuns(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := &MockRecommendationService{}
+	mockService.On("GetRuns", 20, 40).Return([]*model.RecommendationRun{
+		{ID: "run-1", Status: model.RecommendationRunStatusCompleted, Strategy: "additive"},
+	}, 41, nil)
+
+	handler := NewRecommendationsHandler(mockService, job.NewJobManager(2, logrus.New()), logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/api/v1/public/recommendations/runs?limit=20&offset=40", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.GetRuns(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(41), response["total"])
+	assert.Len(t, response["runs"], 1)
+
+	// Verify mocks
+	mockService.AssertExpectations(t)
+}
+
+func TestRecommendationsHandler_GetRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		runID          string
+		expectedStatus int
+		setupMocks     func(*MockRecommendationService)
+	}{
+		{
+			name:           "existing run",
+			runID:          "run-1",
+			expectedStatus: http.StatusOK,
+			setupMocks: func(service *MockRecommendationService) {
+				service.On("GetRun", "run-1").Return(&model.RecommendationRun{
+					ID:              "run-1",
+					Recommendations: []*model.Recommendation{{Ticker: "AAPL"}},
+				}, nil)
+			},
+		},
+		{
+			name:           "missing run",
+			runID:          "run-2",
+			expectedStatus: http.StatusNotFound,
+			setupMocks: func(service *MockRecommendationService) {
+				service.On("GetRun", "run-2").Return(nil, errors.NewNotFoundError("recommendation run not found", nil))
+			},
+		},
+		{
+			name:           "invalid id",
+			runID:          "bogus",
+			expectedStatus: http.StatusBadRequest,
+			setupMocks: func(service *MockRecommendationService) {
+				service.On("GetRun", "bogus").Return(nil, errors.NewValidationError("run id must be a valid UUID", nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockService := &MockRecommendationService{}
+			tt.setupMocks(mockService)
+
+			handler := NewRecommendationsHandler(mockService, job.NewJobManager(2, logrus.New()), logrus.New())
+
+			// Create request
+			req, _ := http.NewRequest("GET", "/api/v1/public/recommendations/runs/"+tt.runID, nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: tt.runID}}
+
+			// Execute
+			handler.GetRun(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+type ReferenceHandler struct {
+	referenceService interfaces.ReferenceServiceInterface
+	logger           *logrus.Logger
+}
+
+func NewReferenceHandler(referenceService interfaces.ReferenceServiceInterface, logger *logrus.Logger) *ReferenceHandler {
+	return &ReferenceHandler{
+		referenceService: referenceService,
+		logger:           logger,
+	}
+}
+
+func (h *ReferenceHandler) GetUnmappedValues(c *gin.Context) {
+	limit, offset, _, _ := parsePaginationParams(c)
+	kind := model.ReferenceKind(c.Query("kind"))
+
+	if kind != "" && kind != model.ReferenceKindRating && kind != model.ReferenceKindAction {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": "kind must be one of: rating, action",
+		})
+		return
+	}
+
+	values, err := h.referenceService.GetUnmappedValues(kind, limit, offset)
+	if err != nil {
+		handleError(c, err, "retrieve unmapped reference values", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unmapped": values,
+		"total":    len(values),
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type MockReferenceService struct {
+	mock.Mock
+}
+
+func (m *MockReferenceService) MapStocks(stocks []*model.Stock) error {
+	args := m.Called(stocks)
+	return args.Error(0)
+}
+
+func (m *MockReferenceService) CanonicalRating(raw string) string {
+	args := m.Called(raw)
+	return args.String(0)
+}
+
+func (m *MockReferenceService) CanonicalAction(raw string) string {
+	args := m.Called(raw)
+	return args.String(0)
+}
+
+func (m *MockReferenceService) RatingRank(raw string) (int, bool) {
+	args := m.Called(raw)
+	return args.Int(0), args.Bool(1)
+}
+
+func (m *MockReferenceService) GetUnmappedValues(kind model.ReferenceKind, limit, offset int) ([]*model.UnmappedReferenceValue, error) {
+	args := m.Called(kind, limit, offset)
+	return args.Get(0).([]*model.UnmappedReferenceValue), args.Error(1)
+}
+
+func TestReferenceHandler_GetUnmappedValues(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		setupMocks     func(*MockReferenceService)
+	}{
+		{
+			name:           "successful list",
+			queryParams:    "?kind=rating&limit=10",
+			expectedStatus: http.StatusOK,
+			setupMocks: func(service *MockReferenceService) {
+				service.On("GetUnmappedValues", model.ReferenceKindRating, 10, 0).Return([]*model.UnmappedReferenceValue{
+					{Kind: model.ReferenceKindRating, RawValue: "mystery rating", Occurrences: 3},
+				}, nil)
+			},
+		},
+		{
+			name:           "invalid kind",
+			queryParams:    "?kind=ticker",
+			expectedStatus: http.StatusBadRequest,
+			setupMocks:     func(service *MockReferenceService) {},
+		},
+		{
+			name:           "service error",
+			queryParams:    "",
+			expectedStatus: http.StatusInternalServerError,
+			setupMocks: func(service *MockReferenceService) {
+				service.On("GetUnmappedValues", model.ReferenceKind(""), 50, 0).Return([]*model.UnmappedReferenceValue{}, assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockService := &MockReferenceService{}
+			tt.setupMocks(mockService)
+
+			handler := NewReferenceHandler(mockService, logrus.New())
+
+			// Create request
+			req, _ := http.NewRequest("GET", "/api/v1/admin/reference/unmapped"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			// Execute
+			handler.GetUnmappedValues(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockService.AssertExpectations(t)
+		})
+	}
+}
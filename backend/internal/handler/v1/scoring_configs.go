@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/dto/request"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+type ScoringConfigsHandler struct {
+	scoringConfigService interfaces.ScoringConfigServiceInterface
+	logger               *logrus.Logger
+}
+
+func NewScoringConfigsHandler(scoringConfigService interfaces.ScoringConfigServiceInterface, logger *logrus.Logger) *ScoringConfigsHandler {
+	return &ScoringConfigsHandler{
+		scoringConfigService: scoringConfigService,
+		logger:               logger,
+	}
+}
+
+func (h *ScoringConfigsHandler) ListConfigs(c *gin.Context) {
+	limit, offset, _, _ := parsePaginationParams(c)
+
+	configs, total, err := h.scoringConfigService.ListConfigs(limit, offset)
+	if err != nil {
+		handleError(c, err, "retrieve scoring configs", h.logger)
+		return
+	}
+
+	_, activeVersion, err := h.scoringConfigService.GetActiveConfig()
+	if err != nil {
+		handleError(c, err, "resolve active scoring config", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"configs":        configs,
+		"active_version": activeVersion,
+		"total":          total,
+		"limit":          limit,
+		"offset":         offset,
+	})
+}
+
+func (h *ScoringConfigsHandler) CreateConfig(c *gin.Context) {
+	req := request.CreateScoringConfigRequest{Config: model.DefaultScoringConfig()}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	config, err := h.scoringConfigService.CreateConfig(interfaces.CreateScoringConfigParams{
+		Version:     req.Version,
+		Description: req.Description,
+		Config:      req.Config,
+	})
+	if err != nil {
+		handleError(c, err, "create scoring config", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Scoring config created",
+		"config":  config,
+	})
+}
+
+func (h *ScoringConfigsHandler) ActivateConfig(c *gin.Context) {
+	version := c.Param("version")
+
+	config, err := h.scoringConfigService.ActivateConfig(version)
+	if err != nil {
+		handleError(c, err, "activate scoring config", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Scoring config activated",
+		"config":  config,
+	})
+}
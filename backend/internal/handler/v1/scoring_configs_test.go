@@ -0,0 +1,198 @@
+package v1
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+type MockScoringConfigService struct {
+	mock.Mock
+}
+
+func (m *MockScoringConfigService) GetActiveConfig() (*model.ScoringConfig, string, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).(*model.ScoringConfig), args.String(1), args.Error(2)
+}
+
+func (m *MockScoringConfigService) ListConfigs(limit, offset int) ([]*model.ScoringConfigVersion, int, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]*model.ScoringConfigVersion), args.Int(1), args.Error(2)
+}
+
+func (m *MockScoringConfigService) CreateConfig(params interfaces.CreateScoringConfigParams) (*model.ScoringConfigVersion, error) {
+	args := m.Called(params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ScoringConfigVersion), args.Error(1)
+}
+
+func (m *MockScoringConfigService) ActivateConfig(version string) (*model.ScoringConfigVersion, error) {
+	args := m.Called(version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ScoringConfigVersion), args.Error(1)
+}
+
+func TestScoringConfigsHandler_ListConfigs(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockService := &MockScoringConfigService{}
+	mockService.On("ListConfigs", 10, 0).Return([]*model.ScoringConfigVersion{
+		{ID: 1, Version: "v1", IsActive: true},
+	}, 1, nil)
+	mockService.On("GetActiveConfig").Return(model.DefaultScoringConfig(), "v1", nil)
+
+	handler := NewScoringConfigsHandler(mockService, logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/api/v1/admin/scoring-configs?limit=10", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.ListConfigs(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"active_version":"v1"`)
+
+	// Verify mocks
+	mockService.AssertExpectations(t)
+}
+
+func TestScoringConfigsHandler_CreateConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		setupMocks     func(*MockScoringConfigService)
+	}{
+		{
+			name:           "successful create with partial weights",
+			body:           `{"version": "v2", "description": "tuned", "config": {"buy_score": 20}}`,
+			expectedStatus: http.StatusCreated,
+			setupMocks: func(service *MockScoringConfigService) {
+				service.On("CreateConfig", mock.MatchedBy(func(params interfaces.CreateScoringConfigParams) bool {
+					// Omitted weights keep their defaults
+					return params.Version == "v2" && params.Config.BuyScore == 20 && params.Config.TargetRaisedScore == 40
+				})).Return(&model.ScoringConfigVersion{ID: 2, Version: "v2"}, nil)
+			},
+		},
+		{
+			name:           "missing version",
+			body:           `{"config": {"buy_score": 20}}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMocks:     func(service *MockScoringConfigService) {},
+		},
+		{
+			name:           "invalid weights",
+			body:           `{"version": "v3", "config": {"today_score": 90}}`,
+			expectedStatus: http.StatusBadRequest,
+			setupMocks: func(service *MockScoringConfigService) {
+				service.On("CreateConfig", mock.Anything).Return(nil, errors.NewValidationError("maximum attainable score must be 100 or less", nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockService := &MockScoringConfigService{}
+			tt.setupMocks(mockService)
+
+			handler := NewScoringConfigsHandler(mockService, logrus.New())
+
+			// Create request
+			req, _ := http.NewRequest("POST", "/api/v1/admin/scoring-configs", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			// Execute
+			handler.CreateConfig(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScoringConfigsHandler_ActivateConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		version        string
+		expectedStatus int
+		setupMocks     func(*MockScoringConfigService)
+	}{
+		{
+			name:           "successful activation",
+			version:        "v2",
+			expectedStatus: http.StatusOK,
+			setupMocks: func(service *MockScoringConfigService) {
+				service.On("ActivateConfig", "v2").Return(&model.ScoringConfigVersion{Version: "v2", IsActive: true}, nil)
+			},
+		},
+		{
+			name:           "version not found",
+			version:        "missing",
+			expectedStatus: http.StatusNotFound,
+			setupMocks: func(service *MockScoringConfigService) {
+				service.On("ActivateConfig", "missing").Return(nil, errors.NewNotFoundError("scoring config version missing not found", nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockService := &MockScoringConfigService{}
+			tt.setupMocks(mockService)
+
+			handler := NewScoringConfigsHandler(mockService, logrus.New())
+
+			// Create request
+			req, _ := http.NewRequest("POST", "/api/v1/admin/scoring-configs/"+tt.version+"/activate", nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "version", Value: tt.version}}
+
+			// Execute
+			handler.ActivateConfig(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockService.AssertExpectations(t)
+		})
+	}
+}
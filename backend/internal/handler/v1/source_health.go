@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+// SourcesHealth checks every stock source and reports its circuit breaker.
+// It answers 503 when any source is unhealthy.
+func (h *StocksIngestionHandler) SourcesHealth(c *gin.Context) {
+	sources := h.ingestionService.GetSourcesHealth(c.Request.Context())
+
+	status, code := "healthy", http.StatusOK
+	for _, source := range sources {
+		if !source.Healthy {
+			status, code = "degraded", http.StatusServiceUnavailable
+			break
+		}
+	}
+
+	c.JSON(code, gin.H{
+		"status":  status,
+		"sources": sources,
+	})
+}
+
+// circuitStateValues maps circuit states to the stock_source_circuit_state
+// gauge.
+var circuitStateValues = map[model.CircuitState]int{
+	model.CircuitClosed:   0,
+	model.CircuitHalfOpen: 1,
+	model.CircuitOpen:     2,
+}
+
+// Metrics exposes the circuit breaker of every stock source in the
+// Prometheus text format.
+func (h *StocksIngestionHandler) Metrics(c *gin.Context) {
+	sources, err := h.ingestionService.GetSources()
+	if err != nil {
+		handleError(c, err, "retrieve ingestion sources", h.logger)
+		return
+	}
+
+	var b strings.Builder
+	writeMetric := func(name, kind, help string, samples func(source *model.IngestionSource, circuit *model.CircuitBreakerStatus)) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+		for _, source := range sources {
+			if source.Circuit != nil {
+				samples(source, source.Circuit)
+			}
+		}
+	}
+
+	writeMetric("stock_source_circuit_state", "gauge", "Circuit breaker state of the stock source (0 closed, 1 half-open, 2 open).",
+		func(source *model.IngestionSource, circuit *model.CircuitBreakerStatus) {
+			fmt.Fprintf(&b, "stock_source_circuit_state{source=%q} %d\n", source.Name, circuitStateValues[circuit.State])
+		})
+	writeMetric("stock_source_requests_total", "counter", "Requests made to the stock source by result.",
+		func(source *model.IngestionSource, circuit *model.CircuitBreakerStatus) {
+			fmt.Fprintf(&b, "stock_source_requests_total{source=%q,result=\"success\"} %d\n", source.Name, circuit.Successes)
+			fmt.Fprintf(&b, "stock_source_requests_total{source=%q,result=\"failure\"} %d\n", source.Name, circuit.Failures)
+		})
+	writeMetric("stock_source_requests_rejected_total", "counter", "Requests rejected by the open circuit breaker.",
+		func(source *model.IngestionSource, circuit *model.CircuitBreakerStatus) {
+			fmt.Fprintf(&b, "stock_source_requests_rejected_total{source=%q} %d\n", source.Name, circuit.Rejected)
+		})
+	writeMetric("stock_source_circuit_opens_total", "counter", "Times the circuit breaker opened.",
+		func(source *model.IngestionSource, circuit *model.CircuitBreakerStatus) {
+			fmt.Fprintf(&b, "stock_source_circuit_opens_total{source=%q} %d\n", source.Name, circuit.Opens)
+		})
+
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestStocksIngestionHandler_SourcesHealth(t *testing.T) {
+	tests := []struct {
+		name           string
+		sources        []*model.SourceHealth
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "All sources healthy",
+			sources: []*model.SourceHealth{
+				{Name: "primary", Healthy: true, Circuit: &model.CircuitBreakerStatus{State: model.CircuitClosed}},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"status":"healthy"`,
+		},
+		{
+			name: "Open circuit degrades health",
+			sources: []*model.SourceHealth{
+				{Name: "primary", Healthy: true},
+				{Name: "backup", Healthy: false, Error: "EXTERNAL_ERROR: circuit breaker open", Circuit: &model.CircuitBreakerStatus{State: model.CircuitOpen}},
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `"status":"degraded"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockIngestionService := &MockIngestionService{}
+			mockIngestionService.On("GetSourcesHealth", mock.Anything).Return(tt.sources)
+
+			handler := &StocksIngestionHandler{
+				ingestionService: mockIngestionService,
+				jobManager:       &MockJobManager{},
+				logger:           logrus.New(),
+			}
+
+			// Create request
+			req, _ := http.NewRequest("GET", "/api/v1/admin/health/sources", nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			// Execute
+			handler.SourcesHealth(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+
+			// Verify mocks
+			mockIngestionService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStocksIngestionHandler_Metrics(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockIngestionService := &MockIngestionService{}
+	mockIngestionService.On("GetSources").Return([]*model.IngestionSource{
+		{Name: "primary", Precedence: 1, Circuit: &model.CircuitBreakerStatus{
+			State:     model.CircuitOpen,
+			Successes: 12,
+			Failures:  5,
+			Rejected:  3,
+			Opens:     1,
+		}},
+		{Name: "unguarded", Precedence: 2},
+	}, nil)
+
+	handler := &StocksIngestionHandler{
+		ingestionService: mockIngestionService,
+		jobManager:       &MockJobManager{},
+		logger:           logrus.New(),
+	}
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/api/v1/admin/metrics", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.Metrics(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE stock_source_circuit_state gauge\n")
+	assert.Contains(t, body, `stock_source_circuit_state{source="primary"} 2`)
+	assert.Contains(t, body, `stock_source_requests_total{source="primary",result="success"} 12`)
+	assert.Contains(t, body, `stock_source_requests_total{source="primary",result="failure"} 5`)
+	assert.Contains(t, body, `stock_source_requests_rejected_total{source="primary"} 3`)
+	assert.Contains(t, body, `stock_source_circuit_opens_total{source="primary"} 1`)
+	assert.NotContains(t, body, "unguarded")
+
+	// Verify mocks
+	mockIngestionService.AssertExpectations(t)
+}
@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/importer"
+	"github.com/valeriapadilla/stock-insights/internal/job"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+const (
+	// maxImportSize bounds the body of an import request.
+	maxImportSize = 64 << 20
+	// maxAsyncImportSize bounds the body of an async import, which the queued
+	// job holds in memory and which is lost if the server restarts before the
+	// job runs. Larger files are imported synchronously.
+	maxAsyncImportSize = 8 << 20
+)
+
+type StockImportHandler struct {
+	importService interfaces.ImportServiceInterface
+	jobManager    job.JobManagerInterface
+	logger        *logrus.Logger
+}
+
+func NewStockImportHandler(importService interfaces.ImportServiceInterface, jobManager job.JobManagerInterface, logger *logrus.Logger) *StockImportHandler {
+	return &StockImportHandler{
+		importService: importService,
+		jobManager:    jobManager,
+		logger:        logger,
+	}
+}
+
+// ImportStocks imports the events of a multipart "file" upload or of the raw
+// request body. The format comes from the format query parameter, else from
+// the file name or Content-Type. With async=true the import runs as a
+// backfill job instead.
+func (h *StockImportHandler) ImportStocks(c *gin.Context) {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": "dry_run must be true or false",
+		})
+		return
+	}
+
+	async, err := strconv.ParseBool(c.DefaultQuery("async", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": "async must be true or false",
+		})
+		return
+	}
+
+	columns, err := importer.ParseColumns(c.Query("columns"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	format := model.ImportFormat(strings.ToLower(c.Query("format")))
+	var body io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			if bodyTooLarge(c, err) {
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad request",
+				"message": "multipart upload must include a file field",
+			})
+			return
+		}
+		opened, err := file.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad request",
+				"message": err.Error(),
+			})
+			return
+		}
+		defer opened.Close()
+
+		body = opened
+		if format == "" {
+			format = importer.DetectFormat(file.Filename)
+		}
+	}
+	if format == "" {
+		format = formatFromContentType(c.ContentType())
+	}
+
+	options := model.ImportOptions{
+		Source:  c.Query("source"),
+		Format:  format,
+		Columns: columns,
+		DryRun:  dryRun,
+	}
+	if async {
+		h.enqueueImport(c, body, options)
+		return
+	}
+
+	report, err := h.importService.ImportStocks(c.Request.Context(), body, options)
+	if err != nil {
+		if bodyTooLarge(c, err) {
+			return
+		}
+		handleError(c, err, "import stocks", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// enqueueImport queues the import as a backfill job. The body is read first,
+// since the request is over by the time the job runs, and is refused above
+// maxAsyncImportSize.
+func (h *StockImportHandler) enqueueImport(c *gin.Context, body io.Reader, options model.ImportOptions) {
+	priority, err := parseJobPriority(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxAsyncImportSize+1))
+	if err != nil {
+		if bodyTooLarge(c, err) {
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": err.Error(),
+		})
+		return
+	}
+	if len(data) > maxAsyncImportSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error":   "Request entity too large",
+			"message": fmt.Sprintf("async imports are limited to %d bytes, import larger files without async", maxAsyncImportSize),
+		})
+		return
+	}
+
+	request := job.JobRequest{
+		Type:      job.JobTypeBackfill,
+		Priority:  priority,
+		CreatedBy: requestUser(c),
+	}
+	enqueued, _, err := h.jobManager.Enqueue(request, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+		return h.importService.ImportStocks(ctx, bytes.NewReader(data), options)
+	})
+	if err != nil {
+		handleError(c, err, "enqueue import job", h.logger)
+		return
+	}
+
+	c.JSON(enqueuedJobResponse(enqueued, false, "Import job queued"))
+}
+
+// bodyTooLarge answers 413 if err comes from the maxImportSize limit on the
+// request body.
+func bodyTooLarge(c *gin.Context, err error) bool {
+	var tooLarge *http.MaxBytesError
+	if !stderrors.As(err, &tooLarge) {
+		return false
+	}
+
+	c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+		"error":   "Request entity too large",
+		"message": fmt.Sprintf("imports are limited to %d bytes", tooLarge.Limit),
+	})
+	return true
+}
+
+func formatFromContentType(contentType string) model.ImportFormat {
+	switch contentType {
+	case "text/csv":
+		return model.ImportFormatCSV
+	case "application/x-ndjson", "application/jsonl":
+		return model.ImportFormatNDJSON
+	case "application/json":
+		return model.ImportFormatJSON
+	}
+	return ""
+}
@@ -0,0 +1,252 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/job"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+// MockImportService records the body it was given as a string.
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) ImportStocks(ctx context.Context, r io.Reader, options model.ImportOptions) (*model.ImportReport, error) {
+	body, _ := io.ReadAll(r)
+	args := m.Called(string(body), options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ImportReport), args.Error(1)
+}
+
+func TestStockImportHandler_ImportStocks(t *testing.T) {
+	const csvBody = "Symbol,company,time\nAAPL,Apple Inc.,2025-03-12\n"
+
+	tests := []struct {
+		name           string
+		query          string
+		contentType    string
+		body           string
+		setupMocks     func(*MockImportService)
+		expectedStatus int
+	}{
+		{
+			name:        "csv body with column mapping and dry run",
+			query:       "?dry_run=true&source=archive&columns=ticker=Symbol",
+			contentType: "text/csv",
+			body:        csvBody,
+			setupMocks: func(m *MockImportService) {
+				m.On("ImportStocks", csvBody, model.ImportOptions{
+					Source:  "archive",
+					Format:  model.ImportFormatCSV,
+					Columns: map[string]string{"ticker": "Symbol"},
+					DryRun:  true,
+				}).Return(&model.ImportReport{Source: "archive", Format: model.ImportFormatCSV, DryRun: true, Rows: 1, Inserted: 1}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "format parameter wins over content type",
+			query:       "?format=ndjson",
+			contentType: "application/json",
+			body:        `{"ticker": "AAPL"}`,
+			setupMocks: func(m *MockImportService) {
+				m.On("ImportStocks", `{"ticker": "AAPL"}`, model.ImportOptions{
+					Format:  model.ImportFormatNDJSON,
+					Columns: map[string]string{},
+				}).Return(&model.ImportReport{Format: model.ImportFormatNDJSON}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "unreadable file",
+			contentType: "text/plain",
+			body:        "hello",
+			setupMocks: func(m *MockImportService) {
+				m.On("ImportStocks", "hello", model.ImportOptions{Columns: map[string]string{}}).
+					Return(nil, errors.NewValidationError(`unsupported import format ""`, nil))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "body over the size limit",
+			contentType: "text/csv",
+			body:        csvBody,
+			setupMocks: func(m *MockImportService) {
+				m.On("ImportStocks", csvBody, model.ImportOptions{Format: model.ImportFormatCSV, Columns: map[string]string{}}).
+					Return(nil, errors.NewValidationError("invalid import file", &http.MaxBytesError{Limit: maxImportSize}))
+			},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "invalid dry run",
+			query:          "?dry_run=maybe",
+			contentType:    "text/csv",
+			body:           csvBody,
+			setupMocks:     func(m *MockImportService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown mapped field",
+			query:          "?columns=symbol=Ticker",
+			contentType:    "text/csv",
+			body:           csvBody,
+			setupMocks:     func(m *MockImportService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockImportService := &MockImportService{}
+			tt.setupMocks(mockImportService)
+			handler := NewStockImportHandler(mockImportService, nil, logrus.New())
+
+			// Create request
+			req, _ := http.NewRequest("POST", "/api/v1/admin/import/stocks"+tt.query, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+
+			// Execute
+			handler.ImportStocks(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockImportService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStockImportHandler_ImportStocksMultipart(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	const ndjson = `{"ticker": "AAPL", "company": "Apple Inc.", "time": "2025-03-12T10:00:00Z"}` + "\n"
+	mockImportService := &MockImportService{}
+	mockImportService.On("ImportStocks", ndjson, model.ImportOptions{
+		Format:  model.ImportFormatNDJSON,
+		Columns: map[string]string{},
+	}).Return(&model.ImportReport{Format: model.ImportFormatNDJSON, Rows: 1, Inserted: 1}, nil)
+	handler := NewStockImportHandler(mockImportService, nil, logrus.New())
+
+	// Create request
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "backfill.ndjson")
+	require.NoError(t, err)
+	_, err = part.Write([]byte(ndjson))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/import/stocks", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.ImportStocks(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"inserted":1`)
+
+	// Verify mocks
+	mockImportService.AssertExpectations(t)
+}
+
+func TestStockImportHandler_ImportStocksAsync(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	const csvBody = "ticker,company,time\nAAPL,Apple Inc.,2025-03-12\n"
+	options := model.ImportOptions{Format: model.ImportFormatCSV, Columns: map[string]string{}}
+	mockImportService := &MockImportService{}
+	mockImportService.On("ImportStocks", csvBody, options).Return(&model.ImportReport{Format: model.ImportFormatCSV, Rows: 1, Inserted: 1}, nil)
+
+	var work job.WorkFunc
+	mockJobManager := &MockJobManager{}
+	mockJobManager.On("Enqueue", job.JobRequest{Type: job.JobTypeBackfill, Priority: 5, CreatedBy: "admin"}, mock.Anything).Run(func(args mock.Arguments) {
+		work = args.Get(1).(job.WorkFunc)
+	}).Return(&job.Job{ID: "test-job-id", Type: job.JobTypeBackfill, Status: job.JobStatusPending}, false, nil)
+	handler := NewStockImportHandler(mockImportService, mockJobManager, logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/api/v1/admin/import/stocks?async=true&priority=5", strings.NewReader(csvBody))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Set("user_subject", "admin")
+
+	// Execute
+	handler.ImportStocks(c)
+	require.NotNil(t, work)
+	result, err := work(context.Background(), nil)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"job_id":"test-job-id"`)
+	require.NoError(t, err)
+	report, ok := result.(*model.ImportReport)
+	require.True(t, ok)
+	assert.Equal(t, 1, report.Inserted)
+
+	// Verify mocks
+	mockImportService.AssertExpectations(t)
+	mockJobManager.AssertExpectations(t)
+}
+
+func TestStockImportHandler_ImportStocksAsyncTooLarge(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockImportService := &MockImportService{}
+	mockJobManager := &MockJobManager{}
+	handler := NewStockImportHandler(mockImportService, mockJobManager, logrus.New())
+
+	// Create request
+	body := strings.Repeat("x", maxAsyncImportSize+1)
+	req, _ := http.NewRequest("POST", "/api/v1/admin/import/stocks?async=true", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.ImportStocks(c)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "async imports are limited")
+
+	// Verify mocks
+	mockImportService.AssertNotCalled(t, "ImportStocks", mock.Anything, mock.Anything)
+	mockJobManager.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,102 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/dto/request"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+type StockRejectsHandler struct {
+	ingestionService interfaces.IngestionServiceInterface
+	logger           *logrus.Logger
+}
+
+func NewStockRejectsHandler(ingestionService interfaces.IngestionServiceInterface, logger *logrus.Logger) *StockRejectsHandler {
+	return &StockRejectsHandler{
+		ingestionService: ingestionService,
+		logger:           logger,
+	}
+}
+
+func (h *StockRejectsHandler) ListRejects(c *gin.Context) {
+	limit, offset, _, _ := parsePaginationParams(c)
+	filter := model.StockRejectFilter{
+		Status: model.StockRejectStatus(c.Query("status")),
+		Source: c.Query("source"),
+		RunID:  c.Query("run_id"),
+	}
+
+	rejects, total, err := h.ingestionService.GetRejects(filter, limit, offset)
+	if err != nil {
+		handleError(c, err, "retrieve stock rejects", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"rejects": rejects,
+		"total":   total,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+func (h *StockRejectsHandler) GetReject(c *gin.Context) {
+	reject, err := h.ingestionService.GetReject(c.Param("id"))
+	if err != nil {
+		handleError(c, err, "retrieve stock reject", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, reject)
+}
+
+func (h *StockRejectsHandler) ReplayReject(c *gin.Context) {
+	var req request.ReplayStockRejectRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Bad request",
+				"message": err.Error(),
+			})
+			return
+		}
+	}
+
+	reject, err := h.ingestionService.ReplayReject(c.Request.Context(), c.Param("id"), req.Payload)
+	if err != nil {
+		handleError(c, err, "replay stock reject", h.logger)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"reject_id": reject.ID,
+		"user_id":   requestUser(c),
+	}).Info("Stock reject replayed")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock reject replayed",
+		"reject":  reject,
+	})
+}
+
+func (h *StockRejectsHandler) DiscardReject(c *gin.Context) {
+	reject, err := h.ingestionService.DiscardReject(c.Param("id"))
+	if err != nil {
+		handleError(c, err, "discard stock reject", h.logger)
+		return
+	}
+
+	h.logger.WithFields(logrus.Fields{
+		"reject_id": reject.ID,
+		"user_id":   requestUser(c),
+	}).Info("Stock reject discarded")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Stock reject discarded",
+		"reject":  reject,
+	})
+}
@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+const testRejectID = "3b7e4c1a-2f6d-4e8b-9a05-6c1d2e3f4a5b"
+
+func TestStockRejectsHandler_ListRejects(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockIngestionService := &MockIngestionService{}
+	filter := model.StockRejectFilter{Status: model.StockRejectStatusPending, Source: "primary"}
+	mockIngestionService.On("GetRejects", filter, 10, 0).Return([]*model.StockReject{
+		{
+			ID:           testRejectID,
+			Source:       "primary",
+			Ticker:       "AAPL",
+			Payload:      json.RawMessage(`{"ticker":"AAPL","target_to":"abc"}`),
+			ErrorMessage: "invalid target price",
+			Status:       model.StockRejectStatusPending,
+		},
+	}, 1, nil)
+
+	handler := NewStockRejectsHandler(mockIngestionService, logrus.New())
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/api/v1/admin/rejects?status=pending&source=primary&limit=10", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.ListRejects(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"ticker":"AAPL"`)
+	assert.Contains(t, w.Body.String(), `"error_message":"invalid target price"`)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	// Verify mocks
+	mockIngestionService.AssertExpectations(t)
+}
+
+func TestStockRejectsHandler_ReplayReject(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMocks     func(*MockIngestionService)
+		expectedStatus int
+	}{
+		{
+			name: "replay stored payload",
+			body: "",
+			setupMocks: func(m *MockIngestionService) {
+				m.On("ReplayReject", mock.Anything, testRejectID, json.RawMessage(nil)).Return(&model.StockReject{
+					ID:     testRejectID,
+					Status: model.StockRejectStatusReplayed,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "replay fixed payload",
+			body: `{"payload": {"ticker": "AAPL", "target_to": "$200.00"}}`,
+			setupMocks: func(m *MockIngestionService) {
+				m.On("ReplayReject", mock.Anything, testRejectID, json.RawMessage(`{"ticker": "AAPL", "target_to": "$200.00"}`)).Return(&model.StockReject{
+					ID:     testRejectID,
+					Status: model.StockRejectStatusReplayed,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "still invalid",
+			body: "",
+			setupMocks: func(m *MockIngestionService) {
+				m.On("ReplayReject", mock.Anything, testRejectID, json.RawMessage(nil)).Return(nil, errors.NewValidationError("stock event is still rejected: invalid target price", nil))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed body",
+			body:           `{"payload":`,
+			setupMocks:     func(m *MockIngestionService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockIngestionService := &MockIngestionService{}
+			tt.setupMocks(mockIngestionService)
+
+			handler := &StockRejectsHandler{
+				ingestionService: mockIngestionService,
+				logger:           logrus.New(),
+			}
+
+			// Create request
+			req, _ := http.NewRequest("POST", "/api/v1/admin/rejects/"+testRejectID+"/replay", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: testRejectID}}
+
+			// Execute
+			handler.ReplayReject(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockIngestionService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStockRejectsHandler_DiscardReject(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMocks     func(*MockIngestionService)
+		expectedStatus int
+	}{
+		{
+			name: "pending reject",
+			setupMocks: func(m *MockIngestionService) {
+				m.On("DiscardReject", testRejectID).Return(&model.StockReject{
+					ID:     testRejectID,
+					Status: model.StockRejectStatusDiscarded,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "unknown reject",
+			setupMocks: func(m *MockIngestionService) {
+				m.On("DiscardReject", testRejectID).Return(nil, errors.NewNotFoundError("stock reject not found", nil))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockIngestionService := &MockIngestionService{}
+			tt.setupMocks(mockIngestionService)
+
+			handler := &StockRejectsHandler{
+				ingestionService: mockIngestionService,
+				logger:           logrus.New(),
+			}
+
+			// Create request
+			req, _ := http.NewRequest("POST", "/api/v1/admin/rejects/"+testRejectID+"/discard", nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: testRejectID}}
+
+			// Execute
+			handler.DiscardReject(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockIngestionService.AssertExpectations(t)
+		})
+	}
+}
@@ -61,6 +61,75 @@ func (h *StocksHandler) GetStock(c *gin.Context) {
 	})
 }
 
+func (h *StocksHandler) GetStockHistory(c *gin.Context) {
+	ticket := c.Param("ticket")
+	if ticket == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": "Ticket parameter is required",
+		})
+		return
+	}
+
+	limit, offset, _, _ := parsePaginationParams(c)
+	dateFrom := c.Query("date_from")
+	dateTo := c.Query("date_to")
+
+	history, total, err := h.stockService.GetStockHistory(interfaces.StockHistoryParams{
+		Ticket:   ticket,
+		DateFrom: dateFrom,
+		DateTo:   dateTo,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		handleError(c, err, "retrieve stock history", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticker":  ticket,
+		"history": history,
+		"pagination": gin.H{
+			"total":    total,
+			"limit":    limit,
+			"offset":   offset,
+			"has_next": offset+limit < total,
+		},
+		"filters_applied": gin.H{
+			"date_from": dateFrom,
+			"date_to":   dateTo,
+		},
+	})
+}
+
+// GetStockRevisions lists the field-level changes upstream corrections made
+// to the event of a ticker at the "time" query parameter.
+func (h *StocksHandler) GetStockRevisions(c *gin.Context) {
+	ticket := c.Param("ticket")
+	if ticket == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Bad request",
+			"message": "Ticket parameter is required",
+		})
+		return
+	}
+
+	eventTime := c.Query("time")
+	revisions, err := h.stockService.GetStockRevisions(ticket, eventTime)
+	if err != nil {
+		handleError(c, err, "retrieve stock revisions", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ticker":    ticket,
+		"time":      eventTime,
+		"revisions": revisions,
+		"total":     len(revisions),
+	})
+}
+
 func (h *StocksHandler) SearchStocks(c *gin.Context) {
 	limit, offset, _, _ := parsePaginationParams(c)
 	minPrice, maxPrice := parsePriceParams(c)
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 	"github.com/valeriapadilla/stock-insights/internal/job"
+	"github.com/valeriapadilla/stock-insights/internal/model"
 	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
 )
 
@@ -24,68 +25,162 @@ func NewStocksIngestionHandler(ingestionService interfaces.IngestionServiceInter
 	}
 }
 
+// TriggerIngestion queues an ingestion of every source. While one is
+// pending or running, it is returned instead of queueing another.
 func (h *StocksIngestionHandler) TriggerIngestion(c *gin.Context) {
-	userID := c.GetString("user_id")
+	userID := requestUser(c)
 	h.logger.WithFields(logrus.Fields{
 		"endpoint": "/api/v1/admin/ingest/stocks",
 		"method":   "POST",
 		"user_id":  userID,
 	}).Info("Manual stocks ingestion triggered")
 
-	job, err := h.jobManager.CreateJob()
+	priority, err := parseJobPriority(c)
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to create ingestion job")
-		c.JSON(http.StatusInternalServerError, gin.H{
+		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "error",
-			"message": "Failed to create job",
-			"error":   err.Error(),
+			"message": err.Error(),
 		})
 		return
 	}
 
-	if err := h.jobManager.RunJobAsync(job.ID, func(ctx context.Context) error {
-		return h.ingestionService.TriggerIngestionAsync(ctx)
-	}); err != nil {
-		h.logger.WithError(err).Error("Failed to start ingestion job")
+	request := job.JobRequest{
+		Type:      job.JobTypeIngest,
+		Priority:  priority,
+		Key:       "ingest",
+		CreatedBy: userID,
+	}
+	enqueued, existing, err := h.jobManager.Enqueue(request, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+		results, err := h.ingestionService.TriggerIngestionAsync(ctx, progress)
+		return model.NewIngestionSummary(results...), err
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to enqueue ingestion job")
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
-			"message": "Failed to start job",
+			"message": "Failed to create job",
 			"error":   err.Error(),
 		})
 		return
 	}
 
-	h.logger.WithField("job_id", job.ID).Info("Ingestion job started")
+	h.logger.WithFields(logrus.Fields{
+		"job_id":   enqueued.ID,
+		"existing": existing,
+	}).Info("Ingestion job queued")
+
+	c.JSON(enqueuedJobResponse(enqueued, existing, "Ingestion job queued"))
+}
+
+func (h *StocksIngestionHandler) ListSources(c *gin.Context) {
+	sources, err := h.ingestionService.GetSources()
+	if err != nil {
+		handleError(c, err, "retrieve ingestion sources", h.logger)
+		return
+	}
 
-	c.JSON(http.StatusAccepted, gin.H{
-		"status":  "accepted",
-		"message": "Ingestion job started",
-		"job_id":  job.ID,
-		"job":     job,
+	c.JSON(http.StatusOK, gin.H{
+		"sources": sources,
+		"total":   len(sources),
 	})
 }
 
-func (h *StocksIngestionHandler) GetJobStatus(c *gin.Context) {
-	jobID := c.Param("jobId")
-	if jobID == "" {
+// TriggerSourceIngestion queues an ingestion of one source, deduplicated
+// per source.
+func (h *StocksIngestionHandler) TriggerSourceIngestion(c *gin.Context) {
+	sourceName := c.Param("source")
+	h.logger.WithFields(logrus.Fields{
+		"endpoint": "/api/v1/admin/ingest/sources/:source",
+		"method":   "POST",
+		"source":   sourceName,
+		"user_id":  requestUser(c),
+	}).Info("Manual source ingestion triggered")
+
+	priority, err := parseJobPriority(c)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status":  "error",
-			"message": "Job ID is required",
+			"message": err.Error(),
 		})
 		return
 	}
 
-	job, exists := h.jobManager.GetJob(jobID)
-	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{
+	if _, err := h.ingestionService.GetSource(sourceName); err != nil {
+		handleError(c, err, "resolve ingestion source", h.logger)
+		return
+	}
+
+	request := job.JobRequest{
+		Type:      job.JobTypeIngest,
+		Priority:  priority,
+		Key:       "ingest:" + sourceName,
+		CreatedBy: requestUser(c),
+	}
+	enqueued, existing, err := h.jobManager.Enqueue(request, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+		result, err := h.ingestionService.TriggerSourceIngestionAsync(ctx, sourceName, progress)
+		return model.NewIngestionSummary(result), err
+	})
+	if err != nil {
+		h.logger.WithError(err).Error("Failed to enqueue ingestion job")
+		c.JSON(http.StatusInternalServerError, gin.H{
 			"status":  "error",
-			"message": "Job not found",
+			"message": "Failed to create job",
+			"error":   err.Error(),
 		})
 		return
 	}
 
+	h.logger.WithFields(logrus.Fields{
+		"job_id":   enqueued.ID,
+		"source":   sourceName,
+		"existing": existing,
+	}).Info("Source ingestion job queued")
+
+	status, body := enqueuedJobResponse(enqueued, existing, "Ingestion job queued")
+	body["source"] = sourceName
+	c.JSON(status, body)
+}
+
+func (h *StocksIngestionHandler) ListRuns(c *gin.Context) {
+	limit, offset, _, _ := parsePaginationParams(c)
+	source := c.Query("source")
+
+	runs, total, err := h.ingestionService.GetRuns(source, limit, offset)
+	if err != nil {
+		handleError(c, err, "retrieve ingestion runs", h.logger)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"status": "success",
-		"job":    job,
+		"runs":   runs,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
 	})
 }
+
+func (h *StocksIngestionHandler) GetRun(c *gin.Context) {
+	run, err := h.ingestionService.GetRun(c.Param("id"))
+	if err != nil {
+		handleError(c, err, "retrieve ingestion run", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+func (h *StocksIngestionHandler) GetRunQuality(c *gin.Context) {
+	limit, offset, _, _ := parsePaginationParams(c)
+	filter := model.DataQualityFindingFilter{
+		Severity: model.DataQualitySeverity(c.Query("severity")),
+		Rule:     c.Query("rule"),
+	}
+
+	report, err := h.ingestionService.GetQualityReport(c.Param("id"), filter, limit, offset)
+	if err != nil {
+		handleError(c, err, "retrieve data quality report", h.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
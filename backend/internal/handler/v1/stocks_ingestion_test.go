@@ -2,6 +2,7 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -11,7 +12,10 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
 	"github.com/valeriapadilla/stock-insights/internal/job"
+	"github.com/valeriapadilla/stock-insights/internal/model"
 	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
 )
 
@@ -20,98 +24,168 @@ type MockIngestionService struct {
 	mock.Mock
 }
 
-func (m *MockIngestionService) TriggerIngestionAsync(ctx context.Context) error {
+func (m *MockIngestionService) TriggerIngestionAsync(ctx context.Context, progress model.ProgressFunc) ([]*model.IngestionResult, error) {
 	args := m.Called(ctx)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.IngestionResult), args.Error(1)
 }
 
-type MockJobManager struct {
-	mock.Mock
+func (m *MockIngestionService) TriggerSourceIngestionAsync(ctx context.Context, source string, progress model.ProgressFunc) (*model.IngestionResult, error) {
+	args := m.Called(ctx, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.IngestionResult), args.Error(1)
 }
 
-func (m *MockJobManager) CreateJob() (*job.Job, error) {
+func (m *MockIngestionService) GetSources() ([]*model.IngestionSource, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*job.Job), args.Error(1)
+	return args.Get(0).([]*model.IngestionSource), args.Error(1)
+}
+
+func (m *MockIngestionService) GetSource(name string) (*model.IngestionSource, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.IngestionSource), args.Error(1)
+}
+
+func (m *MockIngestionService) GetSourcesHealth(ctx context.Context) []*model.SourceHealth {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]*model.SourceHealth)
+}
+
+func (m *MockIngestionService) GetRuns(source string, limit, offset int) ([]*model.IngestionRun, int, error) {
+	args := m.Called(source, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*model.IngestionRun), args.Int(1), args.Error(2)
+}
+
+func (m *MockIngestionService) GetRun(runID string) (*model.IngestionRun, error) {
+	args := m.Called(runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.IngestionRun), args.Error(1)
 }
 
-func (m *MockJobManager) GetJob(jobID string) (*job.Job, bool) {
-	args := m.Called(jobID)
+func (m *MockIngestionService) GetQualityReport(runID string, filter model.DataQualityFindingFilter, limit, offset int) (*model.DataQualityReport, error) {
+	args := m.Called(runID, filter, limit, offset)
 	if args.Get(0) == nil {
-		return nil, args.Bool(1)
+		return nil, args.Error(1)
 	}
-	return args.Get(0).(*job.Job), args.Bool(1)
+	return args.Get(0).(*model.DataQualityReport), args.Error(1)
 }
 
-func (m *MockJobManager) UpdateJob(jobID string, status job.JobStatus, progress int, message string) {
-	m.Called(jobID, status, progress, message)
+func (m *MockIngestionService) GetRejects(filter model.StockRejectFilter, limit, offset int) ([]*model.StockReject, int, error) {
+	args := m.Called(filter, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*model.StockReject), args.Int(1), args.Error(2)
 }
 
-func (m *MockJobManager) SetJobError(jobID string, err error) {
-	m.Called(jobID, err)
+func (m *MockIngestionService) GetReject(rejectID string) (*model.StockReject, error) {
+	args := m.Called(rejectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.StockReject), args.Error(1)
 }
 
-func (m *MockJobManager) RunJobAsync(jobID string, workFunc func(context.Context) error) error {
-	args := m.Called(jobID, workFunc)
-	return args.Error(0)
+func (m *MockIngestionService) ReplayReject(ctx context.Context, rejectID string, payload json.RawMessage) (*model.StockReject, error) {
+	args := m.Called(ctx, rejectID, payload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.StockReject), args.Error(1)
 }
 
-func (m *MockJobManager) CleanupOldJobs(maxAge time.Duration) {
-	m.Called(maxAge)
+func (m *MockIngestionService) DiscardReject(rejectID string) (*model.StockReject, error) {
+	args := m.Called(rejectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.StockReject), args.Error(1)
 }
 
 // Tests
 func TestStocksIngestionHandler_TriggerIngestion(t *testing.T) {
+	ingestRequest := job.JobRequest{Type: job.JobTypeIngest, Key: "ingest", CreatedBy: "admin"}
+
 	tests := []struct {
 		name           string
-		mockJob        *job.Job
+		query          string
 		expectedStatus int
+		expectedBody   string
 		setupMocks     func(interfaces.IngestionServiceInterface, *MockJobManager)
 	}{
 		{
-			name: "successful trigger",
-			mockJob: &job.Job{
-				ID:        "test-job-id",
-				Status:    job.JobStatusRunning,
-				CreatedAt: time.Now(),
-				StartedAt: &time.Time{},
-				Progress:  0,
-				Message:   "Starting job...",
-			},
+			name:           "successful trigger",
 			expectedStatus: http.StatusAccepted,
+			expectedBody:   `"status":"accepted"`,
 			setupMocks: func(ingestionService interfaces.IngestionServiceInterface, jobManager *MockJobManager) {
-				jobManager.On("CreateJob").Return(&job.Job{
+				jobManager.On("Enqueue", ingestRequest, mock.Anything).Return(&job.Job{
 					ID:        "test-job-id",
-					Status:    job.JobStatusRunning,
+					Type:      job.JobTypeIngest,
+					Status:    job.JobStatusPending,
+					Key:       "ingest",
 					CreatedAt: time.Now(),
-					StartedAt: &time.Time{},
-					Progress:  0,
-					Message:   "Starting job...",
-				}, nil)
-				jobManager.On("RunJobAsync", "test-job-id", mock.Anything).Return(nil)
+				}, false, nil)
 			},
 		},
 		{
-			name:           "job creation error",
-			mockJob:        nil,
-			expectedStatus: http.StatusInternalServerError,
+			name:           "with priority",
+			query:          "?priority=10",
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `"priority":10`,
+			setupMocks: func(ingestionService interfaces.IngestionServiceInterface, jobManager *MockJobManager) {
+				request := ingestRequest
+				request.Priority = 10
+				jobManager.On("Enqueue", request, mock.Anything).Return(&job.Job{
+					ID:       "test-job-id",
+					Type:     job.JobTypeIngest,
+					Status:   job.JobStatusPending,
+					Priority: 10,
+				}, false, nil)
+			},
+		},
+		{
+			name:           "ingestion already running",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "An equivalent ingest job is already running",
 			setupMocks: func(ingestionService interfaces.IngestionServiceInterface, jobManager *MockJobManager) {
-				jobManager.On("CreateJob").Return(nil, assert.AnError)
+				jobManager.On("Enqueue", ingestRequest, mock.Anything).Return(&job.Job{
+					ID:     "running-job-id",
+					Type:   job.JobTypeIngest,
+					Status: job.JobStatusRunning,
+				}, true, nil)
 			},
 		},
 		{
-			name:           "job start error",
-			mockJob:        nil,
+			name:           "invalid priority",
+			query:          "?priority=high",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid priority",
+			setupMocks:     func(ingestionService interfaces.IngestionServiceInterface, jobManager *MockJobManager) {},
+		},
+		{
+			name:           "job creation error",
 			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Failed to create job",
 			setupMocks: func(ingestionService interfaces.IngestionServiceInterface, jobManager *MockJobManager) {
-				jobManager.On("CreateJob").Return(&job.Job{
-					ID:        "test-job-id",
-					Status:    job.JobStatusPending,
-					CreatedAt: time.Now(),
-				}, nil)
-				jobManager.On("RunJobAsync", "test-job-id", mock.Anything).Return(assert.AnError)
+				jobManager.On("Enqueue", ingestRequest, mock.Anything).Return(nil, false, assert.AnError)
 			},
 		},
 	}
@@ -131,19 +205,21 @@ func TestStocksIngestionHandler_TriggerIngestion(t *testing.T) {
 			}
 
 			// Create request
-			req, _ := http.NewRequest("POST", "/api/v1/admin/ingest/stocks", nil)
+			req, _ := http.NewRequest("POST", "/api/v1/admin/ingest/stocks"+tt.query, nil)
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 
 			// Create Gin context
 			c, _ := gin.CreateTestContext(w)
 			c.Request = req
+			c.Set("user_subject", "admin")
 
 			// Execute
 			handler.TriggerIngestion(c)
 
 			// Assert
 			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
 
 			// Verify mocks
 			mockIngestionService.AssertExpectations(t)
@@ -152,72 +228,348 @@ func TestStocksIngestionHandler_TriggerIngestion(t *testing.T) {
 	}
 }
 
-func TestStocksIngestionHandler_GetJobStatus(t *testing.T) {
+func TestStocksIngestionHandler_TriggerIngestion_JobResult(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockIngestionService := &MockIngestionService{}
+	mockIngestionService.On("TriggerIngestionAsync", mock.Anything).Return([]*model.IngestionResult{
+		{Source: "primary", PagesFetched: 2, StocksSaved: 5, StocksInserted: 4, StocksUpdated: 1},
+		{Source: "backup", PagesFetched: 1, StocksSaved: 1, StocksInserted: 1},
+	}, assert.AnError)
+
+	var work job.WorkFunc
+	mockJobManager := &MockJobManager{}
+	mockJobManager.On("Enqueue", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		work = args.Get(1).(job.WorkFunc)
+	}).Return(&job.Job{ID: "test-job-id", Status: job.JobStatusPending}, false, nil)
+
+	handler := &StocksIngestionHandler{
+		ingestionService: mockIngestionService,
+		jobManager:       mockJobManager,
+		logger:           logrus.New(),
+	}
+
+	// Create request
+	req, _ := http.NewRequest("POST", "/api/v1/admin/ingest/stocks", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+	c.Set("user_subject", "admin")
+
+	// Execute
+	handler.TriggerIngestion(c)
+	require.NotNil(t, work)
+	result, err := work(context.Background(), nil)
+
+	// Assert
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.ErrorIs(t, err, assert.AnError)
+	summary, ok := result.(*model.IngestionSummary)
+	require.True(t, ok)
+	assert.Equal(t, 3, summary.PagesFetched)
+	assert.Equal(t, 6, summary.StocksSaved)
+	assert.Equal(t, 5, summary.StocksInserted)
+	assert.Len(t, summary.Sources, 2)
+
+	// Verify mocks
+	mockIngestionService.AssertExpectations(t)
+	mockJobManager.AssertExpectations(t)
+}
+
+func TestStocksIngestionHandler_ListSources(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockIngestionService := &MockIngestionService{}
+	mockIngestionService.On("GetSources").Return([]*model.IngestionSource{
+		{Name: "primary", Precedence: 1},
+		{Name: "backup", Precedence: 2},
+	}, nil)
+
+	handler := &StocksIngestionHandler{
+		ingestionService: mockIngestionService,
+		jobManager:       &MockJobManager{},
+		logger:           logrus.New(),
+	}
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/api/v1/admin/ingest/sources", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.ListSources(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"primary"`)
+	assert.Contains(t, w.Body.String(), `"total":2`)
+
+	// Verify mocks
+	mockIngestionService.AssertExpectations(t)
+}
+
+func TestStocksIngestionHandler_TriggerSourceIngestion(t *testing.T) {
 	tests := []struct {
 		name           string
-		jobID          string
-		mockJob        *job.Job
+		source         string
 		expectedStatus int
-		setupMocks     func(*MockJobManager)
+		setupMocks     func(*MockIngestionService, *MockJobManager)
 	}{
 		{
-			name:  "successful get status",
-			jobID: "test-job-id",
-			mockJob: &job.Job{
-				ID:        "test-job-id",
-				Status:    job.JobStatusCompleted,
-				CreatedAt: time.Now(),
-				StartedAt: &time.Time{},
-				Progress:  100,
-				Message:   "Job completed successfully",
-			},
-			expectedStatus: http.StatusOK,
-			setupMocks: func(jobManager *MockJobManager) {
-				jobManager.On("GetJob", "test-job-id").Return(&job.Job{
-					ID:        "test-job-id",
-					Status:    job.JobStatusCompleted,
-					CreatedAt: time.Now(),
-					StartedAt: &time.Time{},
-					Progress:  100,
-					Message:   "Job completed successfully",
-				}, true)
+			name:           "successful trigger",
+			source:         "backup",
+			expectedStatus: http.StatusAccepted,
+			setupMocks: func(ingestionService *MockIngestionService, jobManager *MockJobManager) {
+				ingestionService.On("GetSource", "backup").Return(&model.IngestionSource{Name: "backup", Precedence: 2}, nil)
+				request := job.JobRequest{Type: job.JobTypeIngest, Key: "ingest:backup"}
+				jobManager.On("Enqueue", request, mock.Anything).Return(&job.Job{ID: "test-job-id", Status: job.JobStatusPending, CreatedAt: time.Now()}, false, nil)
 			},
 		},
 		{
-			name:           "job not found",
-			jobID:          "invalid-job-id",
-			mockJob:        nil,
+			name:           "unknown source",
+			source:         "missing",
 			expectedStatus: http.StatusNotFound,
-			setupMocks: func(jobManager *MockJobManager) {
-				jobManager.On("GetJob", "invalid-job-id").Return(nil, false)
+			setupMocks: func(ingestionService *MockIngestionService, jobManager *MockJobManager) {
+				ingestionService.On("GetSource", "missing").Return(nil, errors.NewNotFoundError("Ingestion source missing not found", nil))
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// Setup
 			gin.SetMode(gin.TestMode)
+			mockIngestionService := &MockIngestionService{}
 			mockJobManager := &MockJobManager{}
-			tt.setupMocks(mockJobManager)
+			tt.setupMocks(mockIngestionService, mockJobManager)
 
 			handler := &StocksIngestionHandler{
-				ingestionService: &MockIngestionService{},
+				ingestionService: mockIngestionService,
 				jobManager:       mockJobManager,
 				logger:           logrus.New(),
 			}
 
-			req, _ := http.NewRequest("GET", "/api/v1/admin/jobs/"+tt.jobID, nil)
+			// Create request
+			req, _ := http.NewRequest("POST", "/api/v1/admin/ingest/sources/"+tt.source, nil)
 			w := httptest.NewRecorder()
 
+			// Create Gin context
 			c, _ := gin.CreateTestContext(w)
 			c.Request = req
-			c.Params = gin.Params{{Key: "jobId", Value: tt.jobID}}
+			c.Params = gin.Params{{Key: "source", Value: tt.source}}
 
-			handler.GetJobStatus(c)
+			// Execute
+			handler.TriggerSourceIngestion(c)
 
+			// Assert
 			assert.Equal(t, tt.expectedStatus, w.Code)
 
+			// Verify mocks
+			mockIngestionService.AssertExpectations(t)
 			mockJobManager.AssertExpectations(t)
 		})
 	}
 }
+
+func TestStocksIngestionHandler_ListRuns(t *testing.T) {
+	// Setup
+	gin.SetMode(gin.TestMode)
+	mockIngestionService := &MockIngestionService{}
+	mockIngestionService.On("GetRuns", "primary", 10, 0).Return([]*model.IngestionRun{
+		{
+			ID:     "run-1",
+			Status: model.IngestionStatusCompleted,
+			IngestionResult: model.IngestionResult{
+				Source:          "primary",
+				StocksInserted:  5,
+				StocksUpdated:   2,
+				StocksUnchanged: 1,
+				StocksRejected:  1,
+			},
+		},
+	}, 1, nil)
+
+	handler := &StocksIngestionHandler{
+		ingestionService: mockIngestionService,
+		jobManager:       &MockJobManager{},
+		logger:           logrus.New(),
+	}
+
+	// Create request
+	req, _ := http.NewRequest("GET", "/api/v1/admin/ingestions?source=primary&limit=10", nil)
+	w := httptest.NewRecorder()
+
+	// Create Gin context
+	c, _ := gin.CreateTestContext(w)
+	c.Request = req
+
+	// Execute
+	handler.ListRuns(c)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"run-1"`)
+	assert.Contains(t, w.Body.String(), `"source":"primary"`)
+	assert.Contains(t, w.Body.String(), `"stocks_inserted":5`)
+	assert.Contains(t, w.Body.String(), `"stocks_rejected":1`)
+	assert.Contains(t, w.Body.String(), `"total":1`)
+
+	// Verify mocks
+	mockIngestionService.AssertExpectations(t)
+}
+
+func TestStocksIngestionHandler_GetRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		runID          string
+		setupMocks     func(*MockIngestionService)
+		expectedStatus int
+	}{
+		{
+			name:  "existing run",
+			runID: "8f14e45f-ceea-467a-9b36-0d2d4c9e4f11",
+			setupMocks: func(m *MockIngestionService) {
+				m.On("GetRun", "8f14e45f-ceea-467a-9b36-0d2d4c9e4f11").Return(&model.IngestionRun{
+					ID:              "8f14e45f-ceea-467a-9b36-0d2d4c9e4f11",
+					Status:          model.IngestionStatusFailed,
+					ErrorMessage:    "upstream unavailable",
+					IngestionResult: model.IngestionResult{Source: "primary"},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "unknown run",
+			runID: "1c9f0e2a-8d4b-4c55-a3f6-5d2b7e6a9c01",
+			setupMocks: func(m *MockIngestionService) {
+				m.On("GetRun", "1c9f0e2a-8d4b-4c55-a3f6-5d2b7e6a9c01").Return(nil, errors.NewNotFoundError("ingestion run not found", nil))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:  "invalid id",
+			runID: "not-a-uuid",
+			setupMocks: func(m *MockIngestionService) {
+				m.On("GetRun", "not-a-uuid").Return(nil, errors.NewValidationError("run id must be a valid UUID", nil))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockIngestionService := &MockIngestionService{}
+			tt.setupMocks(mockIngestionService)
+
+			handler := &StocksIngestionHandler{
+				ingestionService: mockIngestionService,
+				jobManager:       &MockJobManager{},
+				logger:           logrus.New(),
+			}
+
+			// Create request
+			req, _ := http.NewRequest("GET", "/api/v1/admin/ingestions/"+tt.runID, nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: tt.runID}}
+
+			// Execute
+			handler.GetRun(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockIngestionService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStocksIngestionHandler_GetRunQuality(t *testing.T) {
+	runID := "8f14e45f-ceea-467a-9b36-0d2d4c9e4f11"
+
+	tests := []struct {
+		name           string
+		queryParams    string
+		setupMocks     func(*MockIngestionService)
+		expectedStatus int
+	}{
+		{
+			name:        "flagged run with filters",
+			queryParams: "?severity=error&rule=target_jump&limit=10",
+			setupMocks: func(m *MockIngestionService) {
+				filter := model.DataQualityFindingFilter{Severity: model.DataQualitySeverityError, Rule: "target_jump"}
+				m.On("GetQualityReport", runID, filter, 10, 0).Return(&model.DataQualityReport{
+					RunID:  runID,
+					Source: "primary",
+					Status: model.DataQualityStatusFlagged,
+					Errors: 1,
+					Findings: []*model.DataQualityFinding{
+						{RunID: runID, Rule: "target_jump", Severity: model.DataQualitySeverityError, Ticker: "AAPL"},
+					},
+					Total: 1,
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "invalid severity",
+			queryParams: "?severity=fatal",
+			setupMocks: func(m *MockIngestionService) {
+				filter := model.DataQualityFindingFilter{Severity: "fatal"}
+				m.On("GetQualityReport", runID, filter, 50, 0).Return(nil, errors.NewValidationError(`invalid severity "fatal"`, nil))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unknown run",
+			setupMocks: func(m *MockIngestionService) {
+				m.On("GetQualityReport", runID, model.DataQualityFindingFilter{}, 50, 0).Return(nil, errors.NewNotFoundError("ingestion run not found", nil))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockIngestionService := &MockIngestionService{}
+			tt.setupMocks(mockIngestionService)
+
+			handler := &StocksIngestionHandler{
+				ingestionService: mockIngestionService,
+				jobManager:       &MockJobManager{},
+				logger:           logrus.New(),
+			}
+
+			// Create request
+			req, _ := http.NewRequest("GET", "/api/v1/admin/ingestions/"+runID+"/quality"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "id", Value: runID}}
+
+			// Execute
+			handler.GetRunQuality(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockIngestionService.AssertExpectations(t)
+		})
+	}
+}
@@ -32,11 +32,27 @@ func (m *MockStockService) GetStock(ticket string) (*model.Stock, error) {
 	return args.Get(0).(*model.Stock), args.Error(1)
 }
 
+func (m *MockStockService) GetStockHistory(params serviceInterfaces.StockHistoryParams) ([]*model.Stock, int, error) {
+	args := m.Called(params)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*model.Stock), args.Int(1), args.Error(2)
+}
+
 func (m *MockStockService) SearchStocks(params serviceInterfaces.StockSearchParams) ([]*model.Stock, int, error) {
 	args := m.Called(params)
 	return args.Get(0).([]*model.Stock), args.Int(1), args.Error(2)
 }
 
+func (m *MockStockService) GetStockRevisions(ticket, eventTime string) ([]*model.StockRevision, error) {
+	args := m.Called(ticket, eventTime)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.StockRevision), args.Error(1)
+}
+
 func TestStocksHandler_ListStocks(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -297,3 +313,147 @@ func TestStocksHandler_SearchStocks(t *testing.T) {
 		})
 	}
 }
+
+func TestStocksHandler_GetStockHistory(t *testing.T) {
+	tests := []struct {
+		name           string
+		ticket         string
+		queryParams    string
+		expectedStatus int
+		setupMocks     func(*MockStockService)
+	}{
+		{
+			name:           "successful history",
+			ticket:         "AAPL",
+			queryParams:    "?limit=5&date_from=2025-01-01",
+			expectedStatus: http.StatusOK,
+			setupMocks: func(service *MockStockService) {
+				service.On("GetStockHistory", serviceInterfaces.StockHistoryParams{
+					Ticket:   "AAPL",
+					DateFrom: "2025-01-01",
+					Limit:    5,
+					Offset:   0,
+				}).Return([]*model.Stock{
+					{
+						Ticker:        "AAPL",
+						Company:       "Apple Inc",
+						Action:        "target raised by",
+						RatingTo:      "Buy",
+						TargetTo:      "$200.00",
+						TargetFrom:    "$150.00",
+						Time:          time.Now(),
+						ChangePercent: "+33.3%",
+					},
+				}, 1, nil)
+			},
+		},
+		{
+			name:           "stock not found",
+			ticket:         "INVALID",
+			expectedStatus: http.StatusNotFound,
+			setupMocks: func(service *MockStockService) {
+				service.On("GetStockHistory", mock.Anything).Return(nil, 0, errors.NewNotFoundError("stock not found", nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockService := &MockStockService{}
+			tt.setupMocks(mockService)
+
+			handler := &StocksHandler{
+				stockService: mockService,
+				logger:       logrus.New(),
+			}
+
+			// Create request
+			req, _ := http.NewRequest("GET", "/api/v1/public/stocks/"+tt.ticket+"/history"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "ticket", Value: tt.ticket}}
+
+			// Execute
+			handler.GetStockHistory(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// Verify mocks
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStocksHandler_GetStockRevisions(t *testing.T) {
+	tests := []struct {
+		name           string
+		queryParams    string
+		expectedStatus int
+		expectedBody   string
+		setupMocks     func(*MockStockService)
+	}{
+		{
+			name:           "successful revisions",
+			queryParams:    "?time=2025-03-12T10:00:00Z",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `"changes":[{"field":"target_to","before":"$200.00","after":"$210.00"}]`,
+			setupMocks: func(service *MockStockService) {
+				service.On("GetStockRevisions", "AAPL", "2025-03-12T10:00:00Z").Return([]*model.StockRevision{
+					{
+						Ticker:  "AAPL",
+						Source:  model.DefaultStockSource,
+						Changes: []model.StockFieldChange{{Field: "target_to", Before: "$200.00", After: "$210.00"}},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:           "missing time",
+			expectedStatus: http.StatusBadRequest,
+			setupMocks: func(service *MockStockService) {
+				service.On("GetStockRevisions", "AAPL", "").Return(nil, errors.NewValidationError("time is required", nil))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			gin.SetMode(gin.TestMode)
+			mockService := &MockStockService{}
+			tt.setupMocks(mockService)
+
+			handler := &StocksHandler{
+				stockService: mockService,
+				logger:       logrus.New(),
+			}
+
+			// Create request
+			req, _ := http.NewRequest("GET", "/api/v1/admin/stocks/AAPL/revisions"+tt.queryParams, nil)
+			w := httptest.NewRecorder()
+
+			// Create Gin context
+			c, _ := gin.CreateTestContext(w)
+			c.Request = req
+			c.Params = gin.Params{{Key: "ticket", Value: "AAPL"}}
+
+			// Execute
+			handler.GetStockRevisions(c)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+
+			// Verify mocks
+			mockService.AssertExpectations(t)
+		})
+	}
+}
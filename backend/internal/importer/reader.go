@@ -0,0 +1,283 @@
+// Package importer reads analyst events from CSV, NDJSON or JSON files for
+// bulk imports. It only parses; validation and storage go through the same
+// upsert path as ingestion.
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+// Fields lists the stock fields a CSV file can provide. By default each is
+// read from the column with the same name.
+var Fields = []string{"ticker", "company", "target_from", "target_to", "action", "brokerage", "rating_from", "rating_to", "time"}
+
+// requiredColumns must be present in a CSV header.
+var requiredColumns = []string{"ticker", "time"}
+
+// timeLayouts are tried in order for CSV time values.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"}
+
+// maxLineSize bounds a single NDJSON line.
+const maxLineSize = 1 << 20
+
+// Result holds the events read from a file. Records that could not be read
+// are listed in Errors instead of failing the whole file.
+type Result struct {
+	Stocks []model.Stock
+	Errors []model.ImportRowError
+	// Rows counts every record, read or not.
+	Rows int
+}
+
+// DetectFormat guesses the format from a file name; it returns "" for
+// unknown extensions.
+func DetectFormat(name string) model.ImportFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return model.ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return model.ImportFormatNDJSON
+	case ".json":
+		return model.ImportFormatJSON
+	}
+	return ""
+}
+
+// ParseColumns parses a CSV column mapping such as
+// "ticker=Symbol,time=Date" into field -> column.
+func ParseColumns(spec string) (map[string]string, error) {
+	columns := make(map[string]string)
+	if strings.TrimSpace(spec) == "" {
+		return columns, nil
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		field, column, ok := strings.Cut(pair, "=")
+		field, column = strings.TrimSpace(field), strings.TrimSpace(column)
+		if !ok || field == "" || column == "" {
+			return nil, fmt.Errorf("invalid column mapping %q, expected field=column", pair)
+		}
+		columns[field] = column
+	}
+
+	if err := validateColumns(columns); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// Read parses r in format. columns remaps CSV fields and is ignored for the
+// JSON formats.
+func Read(r io.Reader, format model.ImportFormat, columns map[string]string) (*Result, error) {
+	switch format {
+	case model.ImportFormatCSV:
+		if err := validateColumns(columns); err != nil {
+			return nil, err
+		}
+		return readCSV(r, columns)
+	case model.ImportFormatNDJSON:
+		return readNDJSON(r)
+	case model.ImportFormatJSON:
+		return readJSON(r)
+	}
+	return nil, fmt.Errorf("unsupported import format %q", format)
+}
+
+func validateColumns(columns map[string]string) error {
+	known := make(map[string]bool, len(Fields))
+	for _, field := range Fields {
+		known[field] = true
+	}
+
+	var unknown []string
+	for field := range columns {
+		if !known[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown fields in column mapping: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func readCSV(r io.Reader, columns map[string]string) (*Result, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return &Result{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		positions[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	index := make(map[string]int, len(Fields))
+	for _, field := range Fields {
+		column := field
+		if mapped, ok := columns[field]; ok {
+			column = mapped
+		}
+		if i, ok := positions[strings.ToLower(column)]; ok {
+			index[field] = i
+		}
+	}
+	for _, field := range requiredColumns {
+		if _, ok := index[field]; !ok {
+			column := field
+			if mapped, ok := columns[field]; ok {
+				column = mapped
+			}
+			return nil, fmt.Errorf("CSV header has no %q column for %s", column, field)
+		}
+	}
+
+	result := &Result{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			parseErr, ok := err.(*csv.ParseError)
+			if !ok {
+				return nil, fmt.Errorf("read CSV: %w", err)
+			}
+			result.Rows++
+			result.Errors = append(result.Errors, model.ImportRowError{Line: parseErr.Line, Reason: err.Error()})
+			continue
+		}
+		result.Rows++
+		line, _ := reader.FieldPos(0)
+
+		value := func(field string) string {
+			i, ok := index[field]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		stock := model.Stock{
+			Ticker:     value("ticker"),
+			Company:    value("company"),
+			TargetFrom: value("target_from"),
+			TargetTo:   value("target_to"),
+			Action:     value("action"),
+			Brokerage:  value("brokerage"),
+			RatingFrom: value("rating_from"),
+			RatingTo:   value("rating_to"),
+		}
+
+		eventTime, err := parseTime(value("time"))
+		if err != nil {
+			result.Errors = append(result.Errors, model.ImportRowError{Line: line, Ticker: stock.Ticker, Reason: err.Error()})
+			continue
+		}
+		stock.Time = eventTime
+
+		result.Stocks = append(result.Stocks, stock)
+	}
+
+	return result, nil
+}
+
+func readNDJSON(r io.Reader) (*Result, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	result := &Result{}
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		result.Rows++
+
+		var stock model.Stock
+		if err := json.Unmarshal(data, &stock); err != nil {
+			result.Errors = append(result.Errors, model.ImportRowError{Line: line, Reason: fmt.Sprintf("invalid JSON: %v", err)})
+			continue
+		}
+		result.Stocks = append(result.Stocks, stock)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read NDJSON: %w", err)
+	}
+
+	return result, nil
+}
+
+// readJSON accepts an upstream page ({"items": [...]}) or a plain array of
+// events, like the fake upstream fixtures.
+func readJSON(r io.Reader) (*Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read JSON: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return &Result{}, nil
+	}
+
+	var items []json.RawMessage
+	if bytes.HasPrefix(data, []byte("[")) {
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("parse JSON array: %w", err)
+		}
+	} else {
+		var page struct {
+			Items []json.RawMessage `json:"items"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("parse JSON page: %w", err)
+		}
+		items = page.Items
+	}
+
+	// Items are decoded one by one so a bad event does not hide the others
+	result := &Result{Rows: len(items)}
+	for _, item := range items {
+		var stock model.Stock
+		if err := json.Unmarshal(item, &stock); err != nil {
+			result.Errors = append(result.Errors, model.ImportRowError{Reason: fmt.Sprintf("invalid event: %v", err)})
+			continue
+		}
+		result.Stocks = append(result.Stocks, stock)
+	}
+
+	return result, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("time is required")
+	}
+	for _, layout := range timeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", value)
+}
@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestRead_CSV(t *testing.T) {
+	input := "ticker,company,target_from,target_to,action,brokerage,rating_from,rating_to,time\n" +
+		"AAPL,Apple Inc.,$200.00,$220.00,target raised by,Goldman Sachs,Buy,Buy,2025-03-12T10:00:00Z\n" +
+		"MSFT,Microsoft,,$450.00,initiated by,Barclays,,Overweight,2025-03-11\n" +
+		"NVDA,NVIDIA,,,,,,,yesterday\n"
+
+	result, err := Read(strings.NewReader(input), model.ImportFormatCSV, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Rows)
+	require.Len(t, result.Stocks, 2)
+
+	assert.Equal(t, model.Stock{
+		Ticker:     "AAPL",
+		Company:    "Apple Inc.",
+		TargetFrom: "$200.00",
+		TargetTo:   "$220.00",
+		Action:     "target raised by",
+		Brokerage:  "Goldman Sachs",
+		RatingFrom: "Buy",
+		RatingTo:   "Buy",
+		Time:       time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC),
+	}, result.Stocks[0])
+	assert.Equal(t, time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC), result.Stocks[1].Time)
+
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 4, result.Errors[0].Line)
+	assert.Equal(t, "NVDA", result.Errors[0].Ticker)
+	assert.Contains(t, result.Errors[0].Reason, "invalid time")
+}
+
+func TestRead_CSVReadError(t *testing.T) {
+	input := io.MultiReader(strings.NewReader("ticker,time\nAAPL,2025-03-12\n"), iotest.ErrReader(io.ErrClosedPipe))
+
+	_, err := Read(input, model.ImportFormatCSV, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, io.ErrClosedPipe)
+}
+
+func TestRead_CSVColumnMapping(t *testing.T) {
+	input := "Symbol,Name,Price Target,Date\nAAPL,Apple Inc.,$220.00,2025-03-12 10:00:00\n"
+	columns, err := ParseColumns("ticker=Symbol, company=Name,target_to=price target,time=Date")
+	require.NoError(t, err)
+
+	result, err := Read(strings.NewReader(input), model.ImportFormatCSV, columns)
+	require.NoError(t, err)
+	require.Len(t, result.Stocks, 1)
+	assert.Equal(t, "AAPL", result.Stocks[0].Ticker)
+	assert.Equal(t, "Apple Inc.", result.Stocks[0].Company)
+	assert.Equal(t, "$220.00", result.Stocks[0].TargetTo)
+	assert.Equal(t, time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC), result.Stocks[0].Time)
+
+	// Without the mapping the required columns are missing
+	_, err = Read(strings.NewReader(input), model.ImportFormatCSV, nil)
+	assert.Error(t, err)
+}
+
+func TestParseColumns(t *testing.T) {
+	columns, err := ParseColumns("")
+	require.NoError(t, err)
+	assert.Empty(t, columns)
+
+	_, err = ParseColumns("ticker")
+	assert.Error(t, err)
+
+	_, err = ParseColumns("symbol=Ticker")
+	assert.EqualError(t, err, "unknown fields in column mapping: symbol")
+}
+
+func TestRead_NDJSON(t *testing.T) {
+	input := `{"ticker": "AAPL", "company": "Apple Inc.", "time": "2025-03-12T10:00:00Z"}
+
+{"ticker": "MSFT", "time": "not a time"}
+{"ticker": "NVDA", "company": "NVIDIA", "time": "2025-03-10T10:00:00Z"}
+`
+
+	result, err := Read(strings.NewReader(input), model.ImportFormatNDJSON, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Rows)
+	require.Len(t, result.Stocks, 2)
+	assert.Equal(t, "AAPL", result.Stocks[0].Ticker)
+	assert.Equal(t, "NVDA", result.Stocks[1].Ticker)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 3, result.Errors[0].Line)
+}
+
+func TestRead_JSON(t *testing.T) {
+	page := `{"items": [{"ticker": "AAPL", "company": "Apple Inc.", "time": "2025-03-12T10:00:00Z"}, {"ticker": 5}], "next_page": "AAPL"}`
+	result, err := Read(strings.NewReader(page), model.ImportFormatJSON, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Rows)
+	require.Len(t, result.Stocks, 1)
+	assert.Equal(t, "AAPL", result.Stocks[0].Ticker)
+	assert.Len(t, result.Errors, 1)
+
+	array := `[{"ticker": "MSFT", "company": "Microsoft", "time": "2025-03-11T10:00:00Z"}]`
+	result, err = Read(strings.NewReader(array), model.ImportFormatJSON, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Stocks, 1)
+	assert.Equal(t, "MSFT", result.Stocks[0].Ticker)
+
+	_, err = Read(strings.NewReader(`{"items": [`), model.ImportFormatJSON, nil)
+	assert.Error(t, err)
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, model.ImportFormatCSV, DetectFormat("archive/2024.CSV"))
+	assert.Equal(t, model.ImportFormatNDJSON, DetectFormat("events.jsonl"))
+	assert.Equal(t, model.ImportFormatJSON, DetectFormat("page-0001.json"))
+	assert.Equal(t, model.ImportFormat(""), DetectFormat("events.txt"))
+}
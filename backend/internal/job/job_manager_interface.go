@@ -3,13 +3,23 @@ package job
 import (
 	"context"
 	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
 )
 
+// WorkFunc is the work of a job. It reports its progress through progress and
+// returns a result that is stored with the job as JSON, whether the work
+// failed or not.
+type WorkFunc func(ctx context.Context, progress model.ProgressFunc) (any, error)
+
 type JobManagerInterface interface {
-	CreateJob() (*Job, error)
+	CreateJob(jobType JobType, createdBy string) (*Job, error)
 	GetJob(jobID string) (*Job, bool)
+	ListJobs(filter JobFilter, limit, offset int) ([]*Job, int, error)
 	UpdateJob(jobID string, status JobStatus, progress int, message string)
 	SetJobError(jobID string, err error)
-	RunJobAsync(jobID string, workFunc func(context.Context) error) error
+	RunJobAsync(jobID string, work WorkFunc) error
+	Enqueue(request JobRequest, work WorkFunc) (job *Job, existing bool, err error)
+	CancelJob(jobID string) (*Job, error)
 	CleanupOldJobs(maxAge time.Duration)
 }
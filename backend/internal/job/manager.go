@@ -2,61 +2,274 @@ package job
 
 import (
 	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/model"
 )
 
+// cancelPollInterval is how often a running job checks the store for a
+// cancellation made through another replica.
+const cancelPollInterval = 5 * time.Second
+
+// progressInterval limits how often progress reports that only change the
+// message of a job are written to the store.
+const progressInterval = time.Second
+
+var (
+	ErrJobNotFound = stderrors.New("job not found")
+	// ErrJobFinished is returned when cancelling a job that already ended.
+	ErrJobFinished = stderrors.New("job already finished")
+	// ErrJobCancelled is the cause of the context of a cancelled job.
+	ErrJobCancelled = stderrors.New("job cancelled")
+	// ErrJobDeadlineExceeded is the cause of the context of a job that ran
+	// past its deadline.
+	ErrJobDeadlineExceeded = stderrors.New("job deadline exceeded")
+	// ErrDuplicateJobKey is returned by Store.CreateJob when a pending or
+	// running job already has the key of the new job.
+	ErrDuplicateJobKey = stderrors.New("an active job already has this key")
+)
+
+// staleJobTimeout is how long a pending or running job may go without an
+// update before it is presumed lost with its replica. Jobs are refreshed
+// every pollInterval while they wait or run.
+const staleJobTimeout = time.Minute
+
+// staleJobReason is the error of jobs failed for going stale.
+const staleJobReason = "job lost: it stopped being updated, probably because its replica stopped"
+
+// JobManager queues jobs and runs them on a bounded pool of goroutines,
+// recording their state in a Store. Jobs wait and run on the replica that
+// enqueued them; with a shared store any replica can report, list and
+// cancel them.
 type JobManager struct {
-	jobs    map[string]*Job
-	mutex   sync.RWMutex
-	logger  *logrus.Logger
-	workers chan struct{}
+	store      Store
+	logger     *logrus.Logger
+	maxWorkers int
+	deadlines  map[JobType]time.Duration
+	// limits bounds how many jobs of a type run at once on this replica
+	limits map[JobType]int
+	// pollInterval is how often queued and running jobs look for
+	// cancellations made through other replicas
+	pollInterval time.Duration
+	// enqueueMutex serializes Enqueue so that deduplication sees the jobs
+	// enqueued before
+	enqueueMutex sync.Mutex
+	// mutex serializes the read-modify-write updates of this replica's jobs
+	// and guards cancels, queue and the running counts
+	mutex sync.Mutex
+	// cancels holds the cancel funcs of the jobs queued or running on this
+	// replica
+	cancels map[string]context.CancelCauseFunc
+	// queue holds the jobs waiting for a worker, in order of enqueueing
+	queue         []*queuedJob
+	running       int
+	runningByType map[JobType]int
+}
+
+// queuedJob is a job waiting on this replica for a worker.
+type queuedJob struct {
+	job    *Job
+	work   WorkFunc
+	ctx    context.Context
+	cancel context.CancelCauseFunc
 }
 
+// NewJobManager keeps jobs in memory.
 func NewJobManager(maxWorkers int, logger *logrus.Logger) *JobManager {
+	return NewPersistentJobManager(newMemoryStore(), maxWorkers, logger)
+}
+
+// NewPersistentJobManager keeps jobs in store so they survive restarts and
+// are visible to every replica sharing it.
+func NewPersistentJobManager(store Store, maxWorkers int, logger *logrus.Logger) *JobManager {
 	return &JobManager{
-		jobs:    make(map[string]*Job),
-		logger:  logger,
-		workers: make(chan struct{}, maxWorkers),
+		store:         store,
+		logger:        logger,
+		maxWorkers:    maxWorkers,
+		deadlines:     make(map[JobType]time.Duration),
+		limits:        make(map[JobType]int),
+		pollInterval:  cancelPollInterval,
+		cancels:       make(map[string]context.CancelCauseFunc),
+		runningByType: make(map[JobType]int),
+	}
+}
+
+// SetDeadline limits how long jobs of jobType may run; they are cancelled
+// and failed once it passes. Zero removes the limit.
+func (jm *JobManager) SetDeadline(jobType JobType, timeout time.Duration) {
+	if timeout <= 0 {
+		delete(jm.deadlines, jobType)
+		return
+	}
+	jm.deadlines[jobType] = timeout
+}
+
+// SetConcurrency limits how many jobs of jobType run at once; the others
+// wait in the queue. Zero removes the limit, leaving only the number of
+// workers.
+func (jm *JobManager) SetConcurrency(jobType JobType, limit int) {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	if limit <= 0 {
+		delete(jm.limits, jobType)
+		return
 	}
+	jm.limits[jobType] = limit
 }
 
 var _ JobManagerInterface = (*JobManager)(nil)
 
-func (jm *JobManager) CreateJob() (*Job, error) {
+func (jm *JobManager) CreateJob(jobType JobType, createdBy string) (*Job, error) {
+	return jm.createJob(JobRequest{Type: jobType, CreatedBy: createdBy})
+}
+
+func (jm *JobManager) createJob(request JobRequest) (*Job, error) {
+	now := time.Now()
 	job := &Job{
 		ID:        uuid.New().String(),
+		Type:      request.Type,
 		Status:    JobStatusPending,
-		CreatedAt: time.Now(),
+		Priority:  request.Priority,
+		Key:       request.Key,
+		CreatedAt: now,
+		CreatedBy: request.CreatedBy,
+		UpdatedAt: now,
 		Progress:  0,
 	}
 
-	jm.mutex.Lock()
-	defer jm.mutex.Unlock()
-
-	jm.jobs[job.ID] = job
+	if err := jm.store.CreateJob(job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
 
-	jm.logger.WithField("job_id", job.ID).Info("Created new job")
+	jm.logger.WithFields(logrus.Fields{
+		"job_id":     job.ID,
+		"job_type":   job.Type,
+		"priority":   job.Priority,
+		"key":        job.Key,
+		"created_by": job.CreatedBy,
+	}).Info("Created new job")
 	return job, nil
 }
 
-func (jm *JobManager) GetJob(jobID string) (*Job, bool) {
-	jm.mutex.RLock()
-	defer jm.mutex.RUnlock()
+// Enqueue creates a job for request and queues work to run it. When
+// request has a key and a pending or running job with that key exists, that
+// job is returned with existing set and work is dropped. The store rejects a
+// second active job with the same key, so this holds across replicas. An
+// active job with a key above or below it, such as "ingest" for
+// "ingest:<source>", is returned the same way, though only the work
+// itself keeps two replicas from running both. Jobs that stopped being
+// updated are failed first, since their replica is presumably gone.
+func (jm *JobManager) Enqueue(request JobRequest, work WorkFunc) (job *Job, existing bool, err error) {
+	jm.enqueueMutex.Lock()
+	defer jm.enqueueMutex.Unlock()
+
+	if request.Key == "" {
+		job, err = jm.createJob(request)
+		if err != nil {
+			return nil, false, err
+		}
+		jm.enqueue(job, work)
+		return job, false, nil
+	}
+
+	if _, err := jm.store.FailStaleJobs(time.Now().Add(-staleJobTimeout), staleJobReason); err != nil {
+		return nil, false, fmt.Errorf("failed to fail stale jobs: %w", err)
+	}
 
-	job, exists := jm.jobs[jobID]
-	return job, exists
+	overlapping, err := jm.findOverlappingJob(request.Key)
+	if err != nil {
+		return nil, false, err
+	}
+	if overlapping != nil {
+		jm.logger.WithFields(logrus.Fields{
+			"job_id":      overlapping.ID,
+			"job_type":    overlapping.Type,
+			"key":         request.Key,
+			"overlapping": overlapping.Key,
+		}).Info("Overlapping job already pending or running")
+		return overlapping, true, nil
+	}
+
+	// The active job may finish between a rejected create and the lookup, so
+	// try once more before giving up.
+	for attempt := 0; attempt < 2; attempt++ {
+		job, err = jm.createJob(request)
+		if err == nil {
+			jm.enqueue(job, work)
+			return job, false, nil
+		}
+		if !stderrors.Is(err, ErrDuplicateJobKey) {
+			return nil, false, err
+		}
+
+		active, _, err := jm.store.ListJobs(JobFilter{Key: request.Key, Active: true}, 1, 0)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to look up active jobs: %w", err)
+		}
+		if len(active) > 0 {
+			jm.logger.WithFields(logrus.Fields{
+				"job_id":   active[0].ID,
+				"job_type": active[0].Type,
+				"key":      request.Key,
+			}).Info("Job already pending or running")
+			return active[0], true, nil
+		}
+	}
+
+	return nil, false, fmt.Errorf("failed to create job: %w", ErrDuplicateJobKey)
 }
 
-func (jm *JobManager) UpdateJob(jobID string, status JobStatus, progress int, message string) {
-	jm.mutex.Lock()
-	defer jm.mutex.Unlock()
+// findOverlappingJob returns a pending or running job whose key is above or
+// below key in the ":" hierarchy, or nil.
+func (jm *JobManager) findOverlappingJob(key string) (*Job, error) {
+	filters := []JobFilter{{KeyPrefix: key + ":", Active: true}}
+	for i := strings.LastIndex(key, ":"); i > 0; i = strings.LastIndex(key[:i], ":") {
+		filters = append(filters, JobFilter{Key: key[:i], Active: true})
+	}
+
+	for _, filter := range filters {
+		active, _, err := jm.store.ListJobs(filter, 1, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up overlapping jobs: %w", err)
+		}
+		if len(active) > 0 {
+			return active[0], nil
+		}
+	}
+	return nil, nil
+}
+
+// ListJobs returns a page of the jobs matching filter, newest first, with
+// the total number of matching jobs.
+func (jm *JobManager) ListJobs(filter JobFilter, limit, offset int) ([]*Job, int, error) {
+	jobs, total, err := jm.store.ListJobs(filter, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	return jobs, total, nil
+}
+
+// GetJob reports a store failure as a missing job after logging it.
+func (jm *JobManager) GetJob(jobID string) (*Job, bool) {
+	job, err := jm.store.GetJob(jobID)
+	if err != nil {
+		jm.logger.WithError(err).WithField("job_id", jobID).Error("Failed to get job")
+		return nil, false
+	}
+	return job, job != nil
+}
 
-	if job, exists := jm.jobs[jobID]; exists {
+func (jm *JobManager) UpdateJob(jobID string, status JobStatus, progress int, message string) {
+	jm.update(jobID, func(job *Job) {
 		job.Status = status
 		job.Progress = progress
 		job.Message = message
@@ -64,7 +277,7 @@ func (jm *JobManager) UpdateJob(jobID string, status JobStatus, progress int, me
 		if status == JobStatusRunning && job.StartedAt == nil {
 			now := time.Now()
 			job.StartedAt = &now
-		} else if (status == JobStatusCompleted || status == JobStatusFailed) && job.EndedAt == nil {
+		} else if status.IsFinished() && job.EndedAt == nil {
 			now := time.Now()
 			job.EndedAt = &now
 		}
@@ -75,14 +288,11 @@ func (jm *JobManager) UpdateJob(jobID string, status JobStatus, progress int, me
 			"progress": progress,
 			"message":  message,
 		}).Info("Job updated")
-	}
+	})
 }
 
 func (jm *JobManager) SetJobError(jobID string, err error) {
-	jm.mutex.Lock()
-	defer jm.mutex.Unlock()
-
-	if job, exists := jm.jobs[jobID]; exists {
+	jm.update(jobID, func(job *Job) {
 		job.Status = JobStatusFailed
 		job.Error = err.Error()
 		now := time.Now()
@@ -92,53 +302,319 @@ func (jm *JobManager) SetJobError(jobID string, err error) {
 			"job_id": jobID,
 			"error":  err.Error(),
 		}).Error("Job failed")
+	})
+}
+
+// update applies change to the stored job unless it already finished, so a
+// cancelled job stays cancelled even when another replica cancels it between
+// the read and the write. It returns false once the job finished. Store
+// failures are logged: the job keeps running even if its state can't be
+// recorded.
+func (jm *JobManager) update(jobID string, change func(job *Job)) bool {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+
+	job, err := jm.store.GetJob(jobID)
+	if err != nil {
+		jm.logger.WithError(err).WithField("job_id", jobID).Error("Failed to load job for update")
+		return true
+	}
+	if job == nil || job.Status.IsFinished() {
+		return false
 	}
+
+	change(job)
+	updated, err := jm.store.UpdateJob(job)
+	if err != nil {
+		jm.logger.WithError(err).WithField("job_id", jobID).Error("Failed to update job")
+		return true
+	}
+	return updated
 }
 
-func (jm *JobManager) RunJobAsync(jobID string, workFunc func(context.Context) error) error {
-	jm.mutex.RLock()
-	_, exists := jm.jobs[jobID]
-	jm.mutex.RUnlock()
+// setResult stores the JSON encoding of result with the job, even once it
+// finished, so cancelled jobs keep what they did.
+func (jm *JobManager) setResult(jobID string, result any) {
+	if result == nil {
+		return
+	}
 
+	payload, err := json.Marshal(result)
+	if err != nil {
+		jm.logger.WithError(err).WithField("job_id", jobID).Error("Failed to encode job result")
+		return
+	}
+
+	if err := jm.store.SetJobResult(jobID, payload); err != nil {
+		jm.logger.WithError(err).WithField("job_id", jobID).Error("Failed to store job result")
+	}
+}
+
+// progressReporter returns the ProgressFunc handed to the work of a job.
+// Progress never goes back and stays below 100 until the job completes.
+func (jm *JobManager) progressReporter(jobID string) model.ProgressFunc {
+	var mutex sync.Mutex
+	var last int
+	var lastAt time.Time
+
+	return func(progress int, message string) {
+		mutex.Lock()
+		progress = min(max(progress, last), 99)
+		if progress == last && time.Since(lastAt) < progressInterval {
+			mutex.Unlock()
+			return
+		}
+		last, lastAt = progress, time.Now()
+		mutex.Unlock()
+
+		jm.update(jobID, func(job *Job) {
+			job.Progress = progress
+			job.Message = message
+		})
+		jm.logger.WithFields(logrus.Fields{
+			"job_id":   jobID,
+			"progress": progress,
+			"message":  message,
+		}).Debug("Job progress")
+	}
+}
+
+// RunJobAsync queues work to run job jobID in the background, once a worker
+// is free and fewer jobs of its type than its concurrency limit run. Jobs of
+// higher priority start first. The work gets a context that is cancelled by
+// CancelJob or once the deadline of the job's type passes.
+func (jm *JobManager) RunJobAsync(jobID string, work WorkFunc) error {
+	job, exists := jm.GetJob(jobID)
 	if !exists {
 		return fmt.Errorf("job %s not found", jobID)
 	}
-
-	select {
-	case jm.workers <- struct{}{}:
-	default:
-		return fmt.Errorf("no available workers")
+	if job.Status.IsFinished() {
+		return fmt.Errorf("job %s is already %s", jobID, job.Status)
 	}
 
-	go func() {
-		defer func() {
-			<-jm.workers
-		}()
+	jm.enqueue(job, work)
+	return nil
+}
+
+func (jm *JobManager) enqueue(job *Job, work WorkFunc) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	jm.mutex.Lock()
+	jm.queue = append(jm.queue, &queuedJob{job: job, work: work, ctx: ctx, cancel: cancel})
+	jm.cancels[job.ID] = cancel
+	queued := len(jm.queue)
+	jm.mutex.Unlock()
+
+	jm.logger.WithFields(logrus.Fields{
+		"job_id":   job.ID,
+		"job_type": job.Type,
+		"priority": job.Priority,
+		"queued":   queued,
+	}).Debug("Job queued")
+
+	go jm.watchJob(ctx, job.ID, cancel)
+	jm.dispatch()
+}
+
+// dispatch starts queued jobs while workers are free.
+func (jm *JobManager) dispatch() {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
 
-		ctx := context.Background()
+	for jm.running < jm.maxWorkers {
+		next := jm.nextQueued()
+		if next == nil {
+			return
+		}
+		jm.running++
+		jm.runningByType[next.job.Type]++
+		go jm.run(next)
+	}
+}
 
-		jm.UpdateJob(jobID, JobStatusRunning, 0, "Starting job...")
+// nextQueued removes and returns the queued job to start next: the oldest
+// of the highest priority among the types under their concurrency limit.
+// Cancelled jobs are dropped on the way. The caller holds mutex.
+func (jm *JobManager) nextQueued() *queuedJob {
+	waiting := jm.queue[:0]
+	for _, queued := range jm.queue {
+		if queued.ctx.Err() != nil {
+			delete(jm.cancels, queued.job.ID)
+			continue
+		}
+		waiting = append(waiting, queued)
+	}
+	clear(jm.queue[len(waiting):])
+	jm.queue = waiting
 
-		if err := workFunc(ctx); err != nil {
-			jm.SetJobError(jobID, err)
-		} else {
-			jm.UpdateJob(jobID, JobStatusCompleted, 100, "Job completed successfully")
+	next := -1
+	for i, queued := range jm.queue {
+		if limit, limited := jm.limits[queued.job.Type]; limited && jm.runningByType[queued.job.Type] >= limit {
+			continue
 		}
+		if next < 0 || queued.job.Priority > jm.queue[next].job.Priority {
+			next = i
+		}
+	}
+	if next < 0 {
+		return nil
+	}
+
+	queued := jm.queue[next]
+	jm.queue = slices.Delete(jm.queue, next, next+1)
+	return queued
+}
+
+// run runs a job taken off the queue, then frees its worker.
+func (jm *JobManager) run(queued *queuedJob) {
+	jobID := queued.job.ID
+	ctx, cancel := queued.ctx, queued.cancel
+
+	defer func() {
+		cancel(nil)
+		jm.mutex.Lock()
+		delete(jm.cancels, jobID)
+		jm.running--
+		jm.runningByType[queued.job.Type]--
+		jm.mutex.Unlock()
+		jm.dispatch()
 	}()
 
-	return nil
+	if ctx.Err() != nil {
+		// cancelled after it was taken off the queue
+		return
+	}
+
+	deadline := jm.deadlines[queued.job.Type]
+	if deadline > 0 {
+		var stopDeadline context.CancelFunc
+		ctx, stopDeadline = context.WithTimeoutCause(ctx, deadline, ErrJobDeadlineExceeded)
+		defer stopDeadline()
+	}
+
+	jm.UpdateJob(jobID, JobStatusRunning, 0, "Starting job...")
+
+	result, err := queued.work(ctx, jm.progressReporter(jobID))
+	jm.setResult(jobID, result)
+	switch cause := context.Cause(ctx); {
+	case err == nil:
+		jm.UpdateJob(jobID, JobStatusCompleted, 100, "Job completed successfully")
+	case stderrors.Is(cause, ErrJobCancelled):
+		// CancelJob already recorded the job as cancelled
+		jm.logger.WithField("job_id", jobID).Info("Cancelled job stopped")
+	case stderrors.Is(cause, ErrJobDeadlineExceeded):
+		jm.SetJobError(jobID, fmt.Errorf("job exceeded its deadline of %s: %w", deadline, err))
+	default:
+		jm.SetJobError(jobID, err)
+	}
 }
 
-func (jm *JobManager) CleanupOldJobs(maxAge time.Duration) {
+// CancelJob marks a pending or running job as cancelled and cancels its
+// context, which also takes it off the queue. A job running on another
+// replica sharing the store stops once that replica sees the new status. The
+// work stops at its next cancellation check, which may be a little after the
+// job is reported cancelled.
+func (jm *JobManager) CancelJob(jobID string) (*Job, error) {
 	jm.mutex.Lock()
-	defer jm.mutex.Unlock()
+	job, err := jm.store.GetJob(jobID)
+	if err != nil {
+		jm.mutex.Unlock()
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	if job == nil {
+		jm.mutex.Unlock()
+		return nil, ErrJobNotFound
+	}
+	if job.Status.IsFinished() {
+		jm.mutex.Unlock()
+		return job, ErrJobFinished
+	}
 
-	cutoff := time.Now().Add(-maxAge)
+	now := time.Now()
+	job.Status = JobStatusCancelled
+	job.Message = "Job cancelled"
+	job.EndedAt = &now
+	updated, err := jm.store.UpdateJob(job)
+	if err != nil {
+		jm.mutex.Unlock()
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	if !updated {
+		// it finished, possibly on another replica, since it was read
+		jm.mutex.Unlock()
+		finished, err := jm.store.GetJob(jobID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job: %w", err)
+		}
+		if finished == nil {
+			return nil, ErrJobNotFound
+		}
+		return finished, ErrJobFinished
+	}
+	cancel := jm.cancels[jobID]
+	jm.mutex.Unlock()
+
+	if cancel != nil {
+		cancel(ErrJobCancelled)
+	}
+
+	jm.logger.WithFields(logrus.Fields{
+		"job_id":   jobID,
+		"job_type": job.Type,
+		"local":    cancel != nil,
+	}).Info("Job cancelled")
+	return job, nil
+}
+
+// watchJob cancels ctx when the stored job turns cancelled, which is how a
+// cancellation made on another replica reaches this one. Until then it
+// refreshes the job so it isn't taken for stale; once the job finished
+// elsewhere, for instance failed as stale, the work is stopped too.
+func (jm *JobManager) watchJob(ctx context.Context, jobID string, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(jm.pollInterval)
+	defer ticker.Stop()
 
-	for jobID, job := range jm.jobs {
-		if job.CreatedAt.Before(cutoff) {
-			delete(jm.jobs, jobID)
-			jm.logger.WithField("job_id", jobID).Debug("Cleaned up old job")
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job, err := jm.store.GetJob(jobID)
+			if err != nil {
+				jm.logger.WithError(err).WithField("job_id", jobID).Warn("Failed to check job for cancellation")
+				continue
+			}
+			if job != nil && job.Status == JobStatusCancelled {
+				cancel(ErrJobCancelled)
+				return
+			}
+			if !jm.update(jobID, func(*Job) {}) {
+				cancel(ErrJobCancelled)
+				return
+			}
 		}
 	}
 }
+
+// CleanupOldJobs fails the pending and running jobs that stopped being
+// updated, as their replica is gone, and deletes jobs created more than
+// maxAge ago, whatever their status.
+func (jm *JobManager) CleanupOldJobs(maxAge time.Duration) {
+	failed, err := jm.store.FailStaleJobs(time.Now().Add(-staleJobTimeout), staleJobReason)
+	if err != nil {
+		jm.logger.WithError(err).Error("Failed to fail stale jobs")
+	} else if failed > 0 {
+		jm.logger.WithField("failed", failed).Warn("Failed stale jobs")
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+
+	deleted, err := jm.store.DeleteJobsCreatedBefore(cutoff)
+	if err != nil {
+		jm.logger.WithError(err).Error("Failed to clean up old jobs")
+		return
+	}
+	if deleted > 0 {
+		jm.logger.WithField("deleted", deleted).Debug("Cleaned up old jobs")
+	}
+}
@@ -0,0 +1,566 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func newTestJobManager() *JobManager {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	return NewJobManager(2, logger)
+}
+
+func waitForJob(t *testing.T, jm *JobManager, jobID string, status JobStatus) *Job {
+	t.Helper()
+
+	var job *Job
+	require.Eventually(t, func() bool {
+		job, _ = jm.GetJob(jobID)
+		return job != nil && job.Status == status
+	}, time.Second, 5*time.Millisecond)
+	return job
+}
+
+// storeJob writes job to the store of jm, which must still hold it as
+// pending or running.
+func storeJob(t *testing.T, jm *JobManager, job *Job) {
+	t.Helper()
+
+	updated, err := jm.store.UpdateJob(job)
+	require.NoError(t, err)
+	require.True(t, updated)
+}
+
+func runningJobs(jm *JobManager) int {
+	jm.mutex.Lock()
+	defer jm.mutex.Unlock()
+	return jm.running
+}
+
+// blockingWork returns work that runs until release is closed, reporting
+// its start on started.
+func blockingWork(started chan<- string, name string, release <-chan struct{}) WorkFunc {
+	return func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+		started <- name
+		select {
+		case <-release:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func TestJobManager_RunJobAsync(t *testing.T) {
+	t.Run("completed job", func(t *testing.T) {
+		jm := newTestJobManager()
+
+		job, err := jm.CreateJob(JobTypeIngest, "admin")
+		require.NoError(t, err)
+		assert.Equal(t, JobStatusPending, job.Status)
+		assert.Equal(t, JobTypeIngest, job.Type)
+		assert.Equal(t, "admin", job.CreatedBy)
+
+		require.NoError(t, jm.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) { return nil, nil }))
+
+		done := waitForJob(t, jm, job.ID, JobStatusCompleted)
+		assert.Equal(t, 100, done.Progress)
+		assert.NotNil(t, done.StartedAt)
+		assert.NotNil(t, done.EndedAt)
+	})
+
+	t.Run("failed job", func(t *testing.T) {
+		jm := newTestJobManager()
+
+		job, err := jm.CreateJob(JobTypeIngest, "admin")
+		require.NoError(t, err)
+
+		require.NoError(t, jm.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+			return nil, errors.New("upstream unavailable")
+		}))
+
+		failed := waitForJob(t, jm, job.ID, JobStatusFailed)
+		assert.Equal(t, "upstream unavailable", failed.Error)
+		assert.NotNil(t, failed.EndedAt)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		jm := newTestJobManager()
+
+		err := jm.RunJobAsync("missing", func(ctx context.Context, progress model.ProgressFunc) (any, error) { return nil, nil })
+		assert.Error(t, err)
+	})
+}
+
+func TestJobManager_Queue(t *testing.T) {
+	t.Run("jobs wait for a free worker", func(t *testing.T) {
+		jm := newTestJobManager()
+		started := make(chan string, 3)
+		release := make(chan struct{})
+
+		var jobs []*Job
+		for _, name := range []string{"first", "second", "third"} {
+			job, _, err := jm.Enqueue(JobRequest{Type: JobTypeBackfill}, blockingWork(started, name, release))
+			require.NoError(t, err)
+			jobs = append(jobs, job)
+		}
+
+		assert.ElementsMatch(t, []string{"first", "second"}, []string{<-started, <-started})
+		waitForJob(t, jm, jobs[1].ID, JobStatusRunning)
+		queued, _ := jm.GetJob(jobs[2].ID)
+		assert.Equal(t, JobStatusPending, queued.Status)
+
+		close(release)
+		assert.Equal(t, "third", <-started)
+		waitForJob(t, jm, jobs[2].ID, JobStatusCompleted)
+	})
+
+	t.Run("concurrency limit per type", func(t *testing.T) {
+		jm := newTestJobManager()
+		jm.SetConcurrency(JobTypeIngest, 1)
+		started := make(chan string, 3)
+		release := make(chan struct{})
+
+		first, _, err := jm.Enqueue(JobRequest{Type: JobTypeIngest}, blockingWork(started, "ingest 1", release))
+		require.NoError(t, err)
+		second, _, err := jm.Enqueue(JobRequest{Type: JobTypeIngest}, blockingWork(started, "ingest 2", release))
+		require.NoError(t, err)
+		_, _, err = jm.Enqueue(JobRequest{Type: JobTypeRecommendations}, blockingWork(started, "recommendations", release))
+		require.NoError(t, err)
+
+		// The second ingestion waits although a worker is free for another type
+		assert.ElementsMatch(t, []string{"ingest 1", "recommendations"}, []string{<-started, <-started})
+		waitForJob(t, jm, first.ID, JobStatusRunning)
+		queued, _ := jm.GetJob(second.ID)
+		assert.Equal(t, JobStatusPending, queued.Status)
+
+		close(release)
+		assert.Equal(t, "ingest 2", <-started)
+		waitForJob(t, jm, second.ID, JobStatusCompleted)
+	})
+
+	t.Run("higher priority starts first", func(t *testing.T) {
+		logger := logrus.New()
+		logger.SetLevel(logrus.PanicLevel)
+		jm := NewJobManager(1, logger)
+		started := make(chan string, 4)
+		release := make(chan struct{})
+
+		_, _, err := jm.Enqueue(JobRequest{Type: JobTypeBackfill}, blockingWork(started, "blocker", release))
+		require.NoError(t, err)
+		assert.Equal(t, "blocker", <-started)
+
+		for _, request := range []struct {
+			name     string
+			priority int
+		}{{"low", 0}, {"high", 10}, {"low again", 0}} {
+			_, _, err := jm.Enqueue(JobRequest{Type: JobTypeBackfill, Priority: request.priority}, blockingWork(started, request.name, release))
+			require.NoError(t, err)
+		}
+
+		close(release)
+		assert.Equal(t, []string{"high", "low", "low again"}, []string{<-started, <-started, <-started})
+	})
+
+	t.Run("cancelled queued job never runs", func(t *testing.T) {
+		logger := logrus.New()
+		logger.SetLevel(logrus.PanicLevel)
+		jm := NewJobManager(1, logger)
+		started := make(chan string, 3)
+		release := make(chan struct{})
+
+		_, _, err := jm.Enqueue(JobRequest{Type: JobTypeBackfill}, blockingWork(started, "blocker", release))
+		require.NoError(t, err)
+		assert.Equal(t, "blocker", <-started)
+		queued, _, err := jm.Enqueue(JobRequest{Type: JobTypeBackfill}, blockingWork(started, "cancelled", release))
+		require.NoError(t, err)
+		next, _, err := jm.Enqueue(JobRequest{Type: JobTypeBackfill}, blockingWork(started, "next", release))
+		require.NoError(t, err)
+
+		_, err = jm.CancelJob(queued.ID)
+		require.NoError(t, err)
+
+		close(release)
+		assert.Equal(t, "next", <-started)
+		waitForJob(t, jm, next.ID, JobStatusCompleted)
+		cancelled, _ := jm.GetJob(queued.ID)
+		assert.Equal(t, JobStatusCancelled, cancelled.Status)
+		assert.Nil(t, cancelled.StartedAt)
+	})
+}
+
+func TestJobManager_EnqueueDeduplicates(t *testing.T) {
+	jm := newTestJobManager()
+	started := make(chan string, 2)
+	release := make(chan struct{})
+
+	request := JobRequest{Type: JobTypeIngest, Key: "ingest:primary", CreatedBy: "admin"}
+	first, existing, err := jm.Enqueue(request, blockingWork(started, "first", release))
+	require.NoError(t, err)
+	assert.False(t, existing)
+	assert.Equal(t, "ingest:primary", first.Key)
+	<-started
+
+	duplicate, existing, err := jm.Enqueue(request, blockingWork(started, "duplicate", release))
+	require.NoError(t, err)
+	assert.True(t, existing)
+	assert.Equal(t, first.ID, duplicate.ID)
+
+	other, existing, err := jm.Enqueue(JobRequest{Type: JobTypeIngest, Key: "ingest:secondary"}, blockingWork(started, "other", release))
+	require.NoError(t, err)
+	assert.False(t, existing)
+	assert.NotEqual(t, first.ID, other.ID)
+	<-started
+
+	close(release)
+	waitForJob(t, jm, first.ID, JobStatusCompleted)
+
+	// Once the job finished the key is free again
+	again, existing, err := jm.Enqueue(request, func(ctx context.Context, progress model.ProgressFunc) (any, error) { return nil, nil })
+	require.NoError(t, err)
+	assert.False(t, existing)
+	assert.NotEqual(t, first.ID, again.ID)
+}
+
+func TestJobManager_EnqueueDeduplicatesOverlappingKeys(t *testing.T) {
+	jm := newTestJobManager()
+	started := make(chan string, 1)
+	release := make(chan struct{})
+
+	source, _, err := jm.Enqueue(JobRequest{Type: JobTypeIngest, Key: "ingest:primary"}, blockingWork(started, "source", release))
+	require.NoError(t, err)
+	<-started
+
+	// Ingesting every source overlaps the running source
+	all, existing, err := jm.Enqueue(JobRequest{Type: JobTypeIngest, Key: "ingest"}, blockingWork(started, "all", release))
+	require.NoError(t, err)
+	assert.True(t, existing)
+	assert.Equal(t, source.ID, all.ID)
+
+	close(release)
+	waitForJob(t, jm, source.ID, JobStatusCompleted)
+
+	release = make(chan struct{})
+	all, existing, err = jm.Enqueue(JobRequest{Type: JobTypeIngest, Key: "ingest"}, blockingWork(started, "all", release))
+	require.NoError(t, err)
+	assert.False(t, existing)
+	<-started
+
+	// And a source is covered by the running ingestion of every source
+	covered, existing, err := jm.Enqueue(JobRequest{Type: JobTypeIngest, Key: "ingest:secondary"}, blockingWork(started, "source", release))
+	require.NoError(t, err)
+	assert.True(t, existing)
+	assert.Equal(t, all.ID, covered.ID)
+
+	close(release)
+	waitForJob(t, jm, all.ID, JobStatusCompleted)
+}
+
+func TestJobManager_EnqueueDeduplicatesAcrossReplicas(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	store := newMemoryStore()
+	replicaA := NewPersistentJobManager(store, 2, logger)
+	replicaB := NewPersistentJobManager(store, 2, logger)
+	started := make(chan string, 2)
+	release := make(chan struct{})
+	defer close(release)
+
+	request := JobRequest{Type: JobTypeIngest, Key: "ingest"}
+	first, existing, err := replicaA.Enqueue(request, blockingWork(started, "a", release))
+	require.NoError(t, err)
+	assert.False(t, existing)
+
+	duplicate, existing, err := replicaB.Enqueue(request, blockingWork(started, "b", release))
+	require.NoError(t, err)
+	assert.True(t, existing)
+	assert.Equal(t, first.ID, duplicate.ID)
+	assert.Equal(t, "a", <-started)
+}
+
+func TestJobManager_StaleJobs(t *testing.T) {
+	jm := newTestJobManager()
+
+	// A job left running by a replica that stopped
+	lost, err := jm.createJob(JobRequest{Type: JobTypeIngest, Key: "ingest"})
+	require.NoError(t, err)
+	lost.Status = JobStatusRunning
+	storeJob(t, jm, lost)
+	jm.store.(*memoryStore).jobs[lost.ID].UpdatedAt = time.Now().Add(-2 * staleJobTimeout)
+
+	job, existing, err := jm.Enqueue(JobRequest{Type: JobTypeIngest, Key: "ingest"}, func(ctx context.Context, progress model.ProgressFunc) (any, error) { return nil, nil })
+	require.NoError(t, err)
+	assert.False(t, existing)
+	assert.NotEqual(t, lost.ID, job.ID)
+
+	jm.CleanupOldJobs(24 * time.Hour)
+
+	failed, _ := jm.GetJob(lost.ID)
+	assert.Equal(t, JobStatusFailed, failed.Status)
+	assert.Contains(t, failed.Error, "stopped being updated")
+	assert.NotNil(t, failed.EndedAt)
+}
+
+func TestJobManager_ListJobs(t *testing.T) {
+	jm := newTestJobManager()
+
+	ingest, err := jm.CreateJob(JobTypeIngest, "admin")
+	require.NoError(t, err)
+	ingest.CreatedAt = time.Now().Add(-2 * time.Hour)
+	storeJob(t, jm, ingest)
+	recommendations, err := jm.CreateJob(JobTypeRecommendations, "admin")
+	require.NoError(t, err)
+	backfill, err := jm.CreateJob(JobTypeBackfill, "admin")
+	require.NoError(t, err)
+	_, err = jm.CancelJob(backfill.ID)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		filter    JobFilter
+		limit     int
+		offset    int
+		wantIDs   []string
+		wantTotal int
+	}{
+		{"all, newest first", JobFilter{}, 10, 0, []string{backfill.ID, recommendations.ID, ingest.ID}, 3},
+		{"page", JobFilter{}, 1, 1, []string{recommendations.ID}, 3},
+		{"by type", JobFilter{Type: JobTypeIngest}, 10, 0, []string{ingest.ID}, 1},
+		{"by status", JobFilter{Status: JobStatusCancelled}, 10, 0, []string{backfill.ID}, 1},
+		{"active", JobFilter{Active: true}, 10, 0, []string{recommendations.ID, ingest.ID}, 2},
+		{"created from", JobFilter{CreatedFrom: time.Now().Add(-time.Hour)}, 10, 0, []string{backfill.ID, recommendations.ID}, 2},
+		{"created to", JobFilter{CreatedTo: time.Now().Add(-time.Hour)}, 10, 0, []string{ingest.ID}, 1},
+		{"offset past the end", JobFilter{}, 10, 5, nil, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs, total, err := jm.ListJobs(tt.filter, tt.limit, tt.offset)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, total)
+
+			var ids []string
+			for _, job := range jobs {
+				ids = append(ids, job.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestJobManager_ProgressAndResult(t *testing.T) {
+	jm := newTestJobManager()
+
+	job, err := jm.CreateJob(JobTypeIngest, "admin")
+	require.NoError(t, err)
+
+	reported := make(chan struct{})
+	finish := make(chan struct{})
+	require.NoError(t, jm.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+		progress(40, "Page 2")
+		// Progress never goes back and stays below 100 until completion
+		progress(30, "Page 3")
+		progress(120, "Page 4")
+		close(reported)
+		<-finish
+		return map[string]int{"stocks_saved": 12}, nil
+	}))
+
+	<-reported
+	running, _ := jm.GetJob(job.ID)
+	assert.Equal(t, JobStatusRunning, running.Status)
+	assert.Equal(t, 99, running.Progress)
+	assert.Equal(t, "Page 4", running.Message)
+	assert.Nil(t, running.Result)
+	close(finish)
+
+	done := waitForJob(t, jm, job.ID, JobStatusCompleted)
+	assert.Equal(t, 100, done.Progress)
+	assert.JSONEq(t, `{"stocks_saved": 12}`, string(done.Result))
+}
+
+func TestJobManager_ResultOfFailedJob(t *testing.T) {
+	jm := newTestJobManager()
+
+	job, err := jm.CreateJob(JobTypeIngest, "admin")
+	require.NoError(t, err)
+
+	require.NoError(t, jm.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+		return map[string]int{"stocks_saved": 3}, errors.New("upstream unavailable")
+	}))
+
+	failed := waitForJob(t, jm, job.ID, JobStatusFailed)
+	assert.JSONEq(t, `{"stocks_saved": 3}`, string(failed.Result))
+}
+
+func TestJobManager_GetJobReturnsCopy(t *testing.T) {
+	jm := newTestJobManager()
+
+	job, err := jm.CreateJob(JobTypeIngest, "admin")
+	require.NoError(t, err)
+
+	stored, exists := jm.GetJob(job.ID)
+	require.True(t, exists)
+	stored.Status = JobStatusFailed
+
+	stored, _ = jm.GetJob(job.ID)
+	assert.Equal(t, JobStatusPending, stored.Status)
+}
+
+func TestJobManager_UpdateKeepsCancellation(t *testing.T) {
+	jm := newTestJobManager()
+	job, err := jm.CreateJob(JobTypeIngest, "admin")
+	require.NoError(t, err)
+
+	// A replica read the job just before another one cancelled it
+	read, err := jm.store.GetJob(job.ID)
+	require.NoError(t, err)
+	_, err = jm.CancelJob(job.ID)
+	require.NoError(t, err)
+
+	read.Status = JobStatusRunning
+	updated, err := jm.store.UpdateJob(read)
+	require.NoError(t, err)
+	assert.False(t, updated)
+
+	stored, _ := jm.GetJob(job.ID)
+	assert.Equal(t, JobStatusCancelled, stored.Status)
+}
+
+func TestJobManager_CleanupOldJobs(t *testing.T) {
+	jm := newTestJobManager()
+
+	old, err := jm.CreateJob(JobTypeIngest, "admin")
+	require.NoError(t, err)
+	old.CreatedAt = time.Now().Add(-48 * time.Hour)
+	storeJob(t, jm, old)
+
+	recent, err := jm.CreateJob(JobTypeIngest, "admin")
+	require.NoError(t, err)
+
+	jm.CleanupOldJobs(24 * time.Hour)
+
+	_, exists := jm.GetJob(old.ID)
+	assert.False(t, exists)
+	_, exists = jm.GetJob(recent.ID)
+	assert.True(t, exists)
+}
+
+func TestJobManager_CancelJob(t *testing.T) {
+	t.Run("running job", func(t *testing.T) {
+		jm := newTestJobManager()
+
+		job, err := jm.CreateJob(JobTypeIngest, "admin")
+		require.NoError(t, err)
+
+		started := make(chan struct{})
+		stopped := make(chan error, 1)
+		require.NoError(t, jm.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+			close(started)
+			<-ctx.Done()
+			stopped <- context.Cause(ctx)
+			return nil, ctx.Err()
+		}))
+		<-started
+		waitForJob(t, jm, job.ID, JobStatusRunning)
+
+		cancelled, err := jm.CancelJob(job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, JobStatusCancelled, cancelled.Status)
+		assert.ErrorIs(t, <-stopped, ErrJobCancelled)
+
+		// The work returning an error does not turn the job into a failure
+		require.Eventually(t, func() bool { return runningJobs(jm) == 0 }, time.Second, 5*time.Millisecond)
+		stored, _ := jm.GetJob(job.ID)
+		assert.Equal(t, JobStatusCancelled, stored.Status)
+		assert.Empty(t, stored.Error)
+		assert.NotNil(t, stored.EndedAt)
+	})
+
+	t.Run("pending job is not run", func(t *testing.T) {
+		jm := newTestJobManager()
+
+		job, err := jm.CreateJob(JobTypeIngest, "admin")
+		require.NoError(t, err)
+
+		_, err = jm.CancelJob(job.ID)
+		require.NoError(t, err)
+
+		err = jm.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) { return nil, nil })
+		assert.Error(t, err)
+	})
+
+	t.Run("finished job", func(t *testing.T) {
+		jm := newTestJobManager()
+
+		job, err := jm.CreateJob(JobTypeIngest, "admin")
+		require.NoError(t, err)
+		require.NoError(t, jm.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) { return nil, nil }))
+		waitForJob(t, jm, job.ID, JobStatusCompleted)
+
+		finished, err := jm.CancelJob(job.ID)
+		assert.ErrorIs(t, err, ErrJobFinished)
+		assert.Equal(t, JobStatusCompleted, finished.Status)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		jm := newTestJobManager()
+
+		_, err := jm.CancelJob("missing")
+		assert.ErrorIs(t, err, ErrJobNotFound)
+	})
+
+	t.Run("job running on another replica", func(t *testing.T) {
+		logger := logrus.New()
+		logger.SetLevel(logrus.PanicLevel)
+		store := newMemoryStore()
+		runner := NewPersistentJobManager(store, 1, logger)
+		runner.pollInterval = 5 * time.Millisecond
+		other := NewPersistentJobManager(store, 1, logger)
+
+		job, err := runner.CreateJob(JobTypeIngest, "admin")
+		require.NoError(t, err)
+
+		stopped := make(chan error, 1)
+		require.NoError(t, runner.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+			<-ctx.Done()
+			stopped <- context.Cause(ctx)
+			return nil, ctx.Err()
+		}))
+		waitForJob(t, runner, job.ID, JobStatusRunning)
+
+		_, err = other.CancelJob(job.ID)
+		require.NoError(t, err)
+
+		select {
+		case cause := <-stopped:
+			assert.ErrorIs(t, cause, ErrJobCancelled)
+		case <-time.After(time.Second):
+			t.Fatal("job was not cancelled through the shared store")
+		}
+	})
+}
+
+func TestJobManager_Deadline(t *testing.T) {
+	jm := newTestJobManager()
+	jm.SetDeadline(JobTypeIngest, 20*time.Millisecond)
+
+	job, err := jm.CreateJob(JobTypeIngest, "admin")
+	require.NoError(t, err)
+
+	require.NoError(t, jm.RunJobAsync(job.ID, func(ctx context.Context, progress model.ProgressFunc) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+
+	failed := waitForJob(t, jm, job.ID, JobStatusFailed)
+	assert.Contains(t, failed.Error, "job exceeded its deadline of 20ms")
+}
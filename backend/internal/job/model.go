@@ -1,6 +1,8 @@
 package job
 
 import (
+	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -11,15 +13,106 @@ const (
 	JobStatusRunning   JobStatus = "running"
 	JobStatusCompleted JobStatus = "completed"
 	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
 )
 
+// IsValid reports whether s is one of the known job statuses.
+func (s JobStatus) IsValid() bool {
+	return s == JobStatusPending || s == JobStatusRunning || s.IsFinished()
+}
+
+// IsFinished reports whether the status is final.
+func (s JobStatus) IsFinished() bool {
+	return s == JobStatusCompleted || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// JobType names the kind of work a job does; deadlines and concurrency
+// limits are set per type.
+type JobType string
+
+const (
+	JobTypeIngest          JobType = "ingest"
+	JobTypeRecommendations JobType = "recommendations"
+	JobTypeBackfill        JobType = "backfill"
+)
+
+// IsValid reports whether t is one of the known job types.
+func (t JobType) IsValid() bool {
+	return t == JobTypeIngest || t == JobTypeRecommendations || t == JobTypeBackfill
+}
+
 type Job struct {
-	ID        string     `json:"id"`
-	Status    JobStatus  `json:"status"`
-	CreatedAt time.Time  `json:"created_at"`
+	ID     string    `json:"id"`
+	Type   JobType   `json:"type"`
+	Status JobStatus `json:"status"`
+	// Priority orders the queue of pending jobs: higher runs first.
+	Priority int `json:"priority"`
+	// Key identifies the work of the job for deduplication, such as
+	// "ingest:<source>". Keys are hierarchical: "ingest" covers
+	// "ingest:<source>".
+	Key       string    `json:"key,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// CreatedBy is the admin user that triggered the job.
+	CreatedBy string     `json:"created_by,omitempty"`
 	StartedAt *time.Time `json:"started_at,omitempty"`
 	EndedAt   *time.Time `json:"ended_at,omitempty"`
-	Error     string     `json:"error,omitempty"`
-	Progress  int        `json:"progress"` // 0-100
-	Message   string     `json:"message,omitempty"`
+	// UpdatedAt is refreshed while the job is pending or running, so jobs
+	// whose replica stopped can be told apart.
+	UpdatedAt time.Time `json:"updated_at"`
+	Error     string    `json:"error,omitempty"`
+	Progress  int       `json:"progress"` // 0-100
+	Message   string    `json:"message,omitempty"`
+	// Result is the JSON summary returned by the work of the job, such as
+	// row counts.
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// JobRequest describes a job to enqueue.
+type JobRequest struct {
+	Type JobType
+	// Priority orders the queue: higher runs first, equal priorities in
+	// order of creation.
+	Priority int
+	// Key deduplicates jobs: while a job with the same key, or a key above or
+	// below it in the ":" hierarchy, is pending or running, Enqueue returns it
+	// instead of creating another one. Empty disables deduplication.
+	Key       string
+	CreatedBy string
+}
+
+// JobFilter selects jobs in ListJobs. Zero fields match every job.
+type JobFilter struct {
+	Type   JobType
+	Status JobStatus
+	Key    string
+	// KeyPrefix restricts the jobs to keys starting with it.
+	KeyPrefix string
+	// Active restricts the jobs to pending and running ones.
+	Active       bool
+	CreatedFrom  time.Time
+	CreatedTo    time.Time
+	UpdatedAfter time.Time
+}
+
+// Matches reports whether job is selected by f.
+func (f JobFilter) Matches(job *Job) bool {
+	switch {
+	case f.Type != "" && job.Type != f.Type:
+		return false
+	case f.Status != "" && job.Status != f.Status:
+		return false
+	case f.Key != "" && job.Key != f.Key:
+		return false
+	case f.KeyPrefix != "" && !strings.HasPrefix(job.Key, f.KeyPrefix):
+		return false
+	case f.Active && job.Status.IsFinished():
+		return false
+	case !f.CreatedFrom.IsZero() && job.CreatedAt.Before(f.CreatedFrom):
+		return false
+	case !f.CreatedTo.IsZero() && !job.CreatedAt.Before(f.CreatedTo):
+		return false
+	case !f.UpdatedAfter.IsZero() && job.UpdatedAt.Before(f.UpdatedAfter):
+		return false
+	}
+	return true
 }
@@ -0,0 +1,147 @@
+package job
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Store persists jobs for a JobManager. CreateJob returns ErrDuplicateJobKey
+// when a pending or running job has the same non-empty key. GetJob returns
+// nil, nil for unknown jobs.
+type Store interface {
+	CreateJob(job *Job) error
+	GetJob(jobID string) (*Job, error)
+	// ListJobs returns a page of the jobs matching filter, newest first,
+	// with the total number of matching jobs.
+	ListJobs(filter JobFilter, limit, offset int) ([]*Job, int, error)
+	// UpdateJob stores job and sets its UpdatedAt, but only while the stored
+	// job is pending or running; updated reports whether it was.
+	UpdateJob(job *Job) (updated bool, err error)
+	// SetJobResult stores the result of a job whatever its status.
+	SetJobResult(jobID string, result []byte) error
+	// FailStaleJobs fails the pending and running jobs not updated since
+	// cutoff with reason as their error.
+	FailStaleJobs(cutoff time.Time, reason string) (int64, error)
+	DeleteJobsCreatedBefore(cutoff time.Time) (int64, error)
+}
+
+// memoryStore keeps jobs in the process; they are lost on restart and
+// invisible to other replicas.
+type memoryStore struct {
+	mutex sync.RWMutex
+	jobs  map[string]*Job
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{jobs: make(map[string]*Job)}
+}
+
+func (s *memoryStore) CreateJob(job *Job) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if job.Key != "" {
+		for _, other := range s.jobs {
+			if other.Key == job.Key && !other.Status.IsFinished() {
+				return ErrDuplicateJobKey
+			}
+		}
+	}
+
+	stored := *job
+	s.jobs[job.ID] = &stored
+	return nil
+}
+
+// GetJob returns a copy so callers can read it while the job runs.
+func (s *memoryStore) GetJob(jobID string) (*Job, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stored, exists := s.jobs[jobID]
+	if !exists {
+		return nil, nil
+	}
+	job := *stored
+	return &job, nil
+}
+
+func (s *memoryStore) ListJobs(filter JobFilter, limit, offset int) ([]*Job, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var matched []*Job
+	for _, stored := range s.jobs {
+		if filter.Matches(stored) {
+			job := *stored
+			matched = append(matched, &job)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *Job) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	total := len(matched)
+	start := min(offset, total)
+	end := min(start+limit, total)
+	return matched[start:end], total, nil
+}
+
+func (s *memoryStore) UpdateJob(job *Job) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, exists := s.jobs[job.ID]
+	if !exists || current.Status.IsFinished() {
+		return false, nil
+	}
+	stored := *job
+	stored.Result = current.Result
+	stored.UpdatedAt = time.Now()
+	s.jobs[job.ID] = &stored
+	return true, nil
+}
+
+func (s *memoryStore) SetJobResult(jobID string, result []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if job, exists := s.jobs[jobID]; exists {
+		job.Result = result
+		job.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (s *memoryStore) FailStaleJobs(cutoff time.Time, reason string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var failed int64
+	now := time.Now()
+	for _, job := range s.jobs {
+		if !job.Status.IsFinished() && job.UpdatedAt.Before(cutoff) {
+			job.Status = JobStatusFailed
+			job.Error = reason
+			job.EndedAt = &now
+			job.UpdatedAt = now
+			failed++
+		}
+	}
+	return failed, nil
+}
+
+func (s *memoryStore) DeleteJobsCreatedBefore(cutoff time.Time) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var deleted int64
+	for jobID, job := range s.jobs {
+		if job.CreatedAt.Before(cutoff) {
+			delete(s.jobs, jobID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
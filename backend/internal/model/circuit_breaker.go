@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// CircuitState is the state of the circuit breaker guarding a stock source.
+type CircuitState string
+
+const (
+	// CircuitClosed lets requests through and counts consecutive failures.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects requests without calling the upstream.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a few trial requests through after the open
+	// timeout; one success closes the breaker and one failure opens it again.
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerStatus is a snapshot of a circuit breaker. The totals count
+// since the process started.
+type CircuitBreakerStatus struct {
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	FailureThreshold    int          `json:"failure_threshold"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+	// RetryAt is when an open breaker lets a trial request through.
+	RetryAt       *time.Time `json:"retry_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+	Successes     int64      `json:"successes_total"`
+	Failures      int64      `json:"failures_total"`
+	Rejected      int64      `json:"rejected_total"`
+	Opens         int64      `json:"opens_total"`
+}
+
+// SourceHealth is the result of checking one stock source.
+type SourceHealth struct {
+	Name      string                `json:"name"`
+	Healthy   bool                  `json:"healthy"`
+	Error     string                `json:"error,omitempty"`
+	Circuit   *CircuitBreakerStatus `json:"circuit,omitempty"`
+	CheckedAt time.Time             `json:"checked_at"`
+}
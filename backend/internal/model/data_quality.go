@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+type DataQualitySeverity string
+
+const (
+	DataQualitySeverityWarning DataQualitySeverity = "warning"
+	DataQualitySeverityError   DataQualitySeverity = "error"
+)
+
+// IsValid reports whether s is a known finding severity.
+func (s DataQualitySeverity) IsValid() bool {
+	return s == DataQualitySeverityWarning || s == DataQualitySeverityError
+}
+
+// DataQualityStatus is the verdict of the checks run after an ingestion.
+type DataQualityStatus string
+
+const (
+	// DataQualityStatusPassed means no error findings; warnings may exist.
+	DataQualityStatusPassed DataQualityStatus = "passed"
+	// DataQualityStatusFlagged means error findings were kept for review
+	// without failing the run.
+	DataQualityStatusFlagged DataQualityStatus = "flagged"
+	// DataQualityStatusFailed means error findings failed the run. The
+	// events stay stored.
+	DataQualityStatusFailed DataQualityStatus = "failed"
+)
+
+// DataQualityPolicy decides what error findings do to an ingestion run.
+type DataQualityPolicy string
+
+const (
+	DataQualityPolicyOff  DataQualityPolicy = "off"
+	DataQualityPolicyFlag DataQualityPolicy = "flag"
+	DataQualityPolicyFail DataQualityPolicy = "fail"
+)
+
+// IsValid reports whether p is a known policy.
+func (p DataQualityPolicy) IsValid() bool {
+	switch p {
+	case DataQualityPolicyOff, DataQualityPolicyFlag, DataQualityPolicyFail:
+		return true
+	}
+	return false
+}
+
+// DataQualityFinding is one rule violation by a stored event.
+type DataQualityFinding struct {
+	ID        string              `json:"id" db:"id"`
+	RunID     string              `json:"run_id,omitempty" db:"run_id"`
+	Source    string              `json:"source" db:"source"`
+	Rule      string              `json:"rule" db:"rule"`
+	Severity  DataQualitySeverity `json:"severity" db:"severity"`
+	Ticker    string              `json:"ticker" db:"ticker"`
+	EventTime time.Time           `json:"event_time" db:"event_time"`
+	Field     string              `json:"field,omitempty" db:"field"`
+	Value     string              `json:"value,omitempty" db:"value"`
+	Message   string              `json:"message" db:"message"`
+	CreatedAt time.Time           `json:"created_at" db:"created_at"`
+}
+
+// DataQualityFindingFilter narrows a finding listing; empty fields match
+// everything.
+type DataQualityFindingFilter struct {
+	Severity DataQualitySeverity
+	Rule     string
+}
+
+// DataQualityReport is the quality verdict of an ingestion run with a page of
+// its findings.
+type DataQualityReport struct {
+	RunID    string                `json:"run_id"`
+	Source   string                `json:"source"`
+	Status   DataQualityStatus     `json:"status,omitempty"`
+	Errors   int                   `json:"errors"`
+	Warnings int                   `json:"warnings"`
+	Findings []*DataQualityFinding `json:"findings"`
+	Total    int                   `json:"total"`
+}
@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// DefaultImportSource is the source of imported events unless another is
+// given. It is not a configured source, so it ranks below all of them.
+const DefaultImportSource = "import"
+
+// ImportFormat is the file format of a bulk import.
+type ImportFormat string
+
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+	// ImportFormatJSON is the upstream ExternalAPIResponse page or a plain
+	// array of events.
+	ImportFormatJSON ImportFormat = "json"
+)
+
+func (f ImportFormat) IsValid() bool {
+	switch f {
+	case ImportFormatCSV, ImportFormatNDJSON, ImportFormatJSON:
+		return true
+	}
+	return false
+}
+
+// ImportOptions controls a bulk import of stock events.
+type ImportOptions struct {
+	Source string
+	Format ImportFormat
+	// Columns maps stock fields to CSV header names that differ from the
+	// field name, e.g. "ticker" to "Symbol".
+	Columns map[string]string
+	// DryRun validates and classifies the events without storing them.
+	DryRun bool
+}
+
+// ImportRowError describes a record that could not be read or was rejected by
+// validation. Line is the line number in the file when known.
+type ImportRowError struct {
+	Line   int        `json:"line,omitempty"`
+	Ticker string     `json:"ticker,omitempty"`
+	Time   *time.Time `json:"time,omitempty"`
+	Reason string     `json:"reason"`
+}
+
+// ImportReport summarizes a bulk import. Errors lists at most the first
+// MaxImportErrors problems; Unreadable and Rejected count all of them.
+type ImportReport struct {
+	Source     string           `json:"source"`
+	Format     ImportFormat     `json:"format"`
+	DryRun     bool             `json:"dry_run"`
+	Rows       int              `json:"rows"`
+	Unreadable int              `json:"unreadable"`
+	Inserted   int              `json:"inserted"`
+	Updated    int              `json:"updated"`
+	Unchanged  int              `json:"unchanged"`
+	Rejected   int              `json:"rejected"`
+	Errors     []ImportRowError `json:"errors"`
+	Duration   string           `json:"duration"`
+}
+
+// MaxImportErrors caps the errors listed in an ImportReport.
+const MaxImportErrors = 100
+
+// AddError lists err unless the report already lists MaxImportErrors.
+func (r *ImportReport) AddError(err ImportRowError) {
+	if len(r.Errors) < MaxImportErrors {
+		r.Errors = append(r.Errors, err)
+	}
+}
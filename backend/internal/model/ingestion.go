@@ -0,0 +1,162 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type IngestionStatus string
+
+const (
+	IngestionStatusRunning   IngestionStatus = "running"
+	IngestionStatusCompleted IngestionStatus = "completed"
+	IngestionStatusFailed    IngestionStatus = "failed"
+)
+
+// IngestionCheckpoint tracks how far an ingestion source has been read.
+// NextPage is the cursor after the last committed page of the current run and
+// HighWaterMark the newest event time stored by the last completed run.
+type IngestionCheckpoint struct {
+	Source           string          `json:"source" db:"source"`
+	Status           IngestionStatus `json:"status" db:"status"`
+	NextPage         string          `json:"next_page,omitempty" db:"next_page"`
+	HighWaterMark    *time.Time      `json:"high_water_mark,omitempty" db:"high_water_mark"`
+	RunHighWaterMark *time.Time      `json:"run_high_water_mark,omitempty" db:"run_high_water_mark"`
+	PagesCommitted   int             `json:"pages_committed" db:"pages_committed"`
+	StartedAt        time.Time       `json:"started_at" db:"started_at"`
+	UpdatedAt        time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CanResume reports whether an unfinished run left a cursor to continue from.
+func (c *IngestionCheckpoint) CanResume() bool {
+	return c != nil && c.Status != IngestionStatusCompleted && c.NextPage != ""
+}
+
+// IsSeen reports whether an event at eventTime was already stored by a
+// completed run and is older than lookback before the high-water mark, so
+// it is not fetched again for corrections. Events at the mark itself are
+// never seen: a new event may share its timestamp, and upserting the stored
+// ones again is harmless.
+func (c *IngestionCheckpoint) IsSeen(eventTime time.Time, lookback time.Duration) bool {
+	return c != nil && c.HighWaterMark != nil && eventTime.Before(c.HighWaterMark.Add(-lookback))
+}
+
+// IngestionResult summarises one ingestion run.
+type IngestionResult struct {
+	Source string `json:"source"`
+	// PagesFetched counts pages downloaded by this run.
+	PagesFetched int `json:"pages_fetched"`
+	// PagesSkipped counts fetched pages holding only already-seen events.
+	PagesSkipped int `json:"pages_skipped"`
+	// PagesResumed counts pages committed by an interrupted run that were
+	// not downloaded again.
+	PagesResumed  int  `json:"pages_resumed"`
+	Resumed       bool `json:"resumed"`
+	StocksFetched int  `json:"stocks_fetched"`
+	// StocksSaved counts events inserted or updated by this run.
+	StocksSaved          int        `json:"stocks_saved"`
+	StocksInserted       int        `json:"stocks_inserted"`
+	StocksUpdated        int        `json:"stocks_updated"`
+	StocksUnchanged      int        `json:"stocks_unchanged"`
+	StocksRejected       int        `json:"stocks_rejected"`
+	ReachedHighWaterMark bool       `json:"reached_high_water_mark"`
+	HighWaterMark        *time.Time `json:"high_water_mark,omitempty"`
+	// QualityStatus is empty when data quality checks are off.
+	QualityStatus   DataQualityStatus `json:"quality_status,omitempty"`
+	QualityErrors   int               `json:"quality_errors"`
+	QualityWarnings int               `json:"quality_warnings"`
+}
+
+// AddUpsert counts the outcome of one BulkUpsert call.
+func (r *IngestionResult) AddUpsert(upsert *BulkUpsertResult) {
+	if upsert == nil {
+		return
+	}
+	r.StocksInserted += upsert.Inserted
+	r.StocksUpdated += upsert.Updated
+	r.StocksUnchanged += upsert.Unchanged
+	r.StocksRejected += upsert.Rejected
+	r.StocksSaved += upsert.Inserted + upsert.Updated
+}
+
+// IngestionSummary totals the results of the sources of one ingestion job.
+type IngestionSummary struct {
+	PagesFetched    int                `json:"pages_fetched"`
+	StocksFetched   int                `json:"stocks_fetched"`
+	StocksSaved     int                `json:"stocks_saved"`
+	StocksInserted  int                `json:"stocks_inserted"`
+	StocksUpdated   int                `json:"stocks_updated"`
+	StocksUnchanged int                `json:"stocks_unchanged"`
+	StocksRejected  int                `json:"stocks_rejected"`
+	Sources         []*IngestionResult `json:"sources"`
+}
+
+// NewIngestionSummary adds up results, skipping nil ones.
+func NewIngestionSummary(results ...*IngestionResult) *IngestionSummary {
+	summary := &IngestionSummary{Sources: []*IngestionResult{}}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		summary.PagesFetched += result.PagesFetched
+		summary.StocksFetched += result.StocksFetched
+		summary.StocksSaved += result.StocksSaved
+		summary.StocksInserted += result.StocksInserted
+		summary.StocksUpdated += result.StocksUpdated
+		summary.StocksUnchanged += result.StocksUnchanged
+		summary.StocksRejected += result.StocksRejected
+		summary.Sources = append(summary.Sources, result)
+	}
+	return summary
+}
+
+// IngestionRun is the recorded history of one ingestion of a source.
+type IngestionRun struct {
+	ID           string          `json:"id" db:"id"`
+	Status       IngestionStatus `json:"status" db:"status"`
+	StartedAt    time.Time       `json:"started_at" db:"started_at"`
+	FinishedAt   *time.Time      `json:"finished_at,omitempty" db:"finished_at"`
+	ErrorMessage string          `json:"error_message,omitempty" db:"error_message"`
+	IngestionResult
+}
+
+// StockRejection is an event BulkUpsert refused to store, with the reason
+// and the event as it was received.
+type StockRejection struct {
+	Ticker  string          `json:"ticker"`
+	Time    time.Time       `json:"time"`
+	Reason  string          `json:"reason"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// BulkUpsertResult classifies the events of one BulkUpsert call. Unchanged
+// events matched the stored row exactly or lost to a higher-precedence source.
+type BulkUpsertResult struct {
+	Inserted   int              `json:"inserted"`
+	Updated    int              `json:"updated"`
+	Unchanged  int              `json:"unchanged"`
+	Rejected   int              `json:"rejected"`
+	Rejections []StockRejection `json:"rejections,omitempty"`
+}
+
+// IngestionSource describes a configured source. Precedence 1 is the highest:
+// its version of an event reported by several sources is the one kept.
+type IngestionSource struct {
+	Name       string               `json:"name"`
+	Precedence int                  `json:"precedence"`
+	Checkpoint *IngestionCheckpoint `json:"checkpoint,omitempty"`
+	// Circuit is the state of the source's circuit breaker, when it has one.
+	Circuit *CircuitBreakerStatus `json:"circuit,omitempty"`
+}
+
+// Add accumulates the counts and rejections of other.
+func (r *BulkUpsertResult) Add(other *BulkUpsertResult) {
+	if other == nil {
+		return
+	}
+	r.Inserted += other.Inserted
+	r.Updated += other.Updated
+	r.Unchanged += other.Unchanged
+	r.Rejected += other.Rejected
+	r.Rejections = append(r.Rejections, other.Rejections...)
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/utils"
+)
+
+// PriceBar is one trading day of OHLCV data for a ticker.
+type PriceBar struct {
+	Ticker    string    `json:"ticker" db:"ticker"`
+	Date      time.Time `json:"date" db:"date"`
+	Open      float64   `json:"open" db:"open"`
+	High      float64   `json:"high" db:"high"`
+	Low       float64   `json:"low" db:"low"`
+	Close     float64   `json:"close" db:"close"`
+	Volume    int64     `json:"volume" db:"volume"`
+	Source    string    `json:"source" db:"source"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// UpsidePercent is how far target sits above lastClose, in percent. It returns
+// nil when either price is missing.
+func UpsidePercent(target *float64, lastClose float64) *float64 {
+	if target == nil || *target <= 0 || lastClose <= 0 {
+		return nil
+	}
+	upside := utils.CalculateChangePercentage(lastClose, *target)
+	return &upside
+}
@@ -0,0 +1,25 @@
+package model
+
+// ProgressFunc receives the progress of long-running work, from 0 to 100,
+// with a short description of the current step. A nil ProgressFunc drops
+// every report, so callers that don't track progress pass nil.
+type ProgressFunc func(progress int, message string)
+
+// Report calls f unless it is nil.
+func (f ProgressFunc) Report(progress int, message string) {
+	if f != nil {
+		f(progress, message)
+	}
+}
+
+// Scale returns a ProgressFunc that maps 0-100 onto from-to of f, for work
+// that is one step of a larger task.
+func (f ProgressFunc) Scale(from, to int) ProgressFunc {
+	if f == nil {
+		return nil
+	}
+	return func(progress int, message string) {
+		progress = min(max(progress, 0), 100)
+		f(from+(to-from)*progress/100, message)
+	}
+}
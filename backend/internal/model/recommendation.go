@@ -4,9 +4,25 @@ import "time"
 
 type Recommendation struct {
     ID          string    `json:"id" db:"id"`
+    RunID       string    `json:"run_id,omitempty" db:"run_id"`
     Ticker      string    `json:"ticker" db:"ticker"`
     Score       float64   `json:"score" db:"score"`
     Explanation string    `json:"explanation" db:"explanation"`
     RunAt       time.Time `json:"run_at" db:"run_at"`
     Rank        int       `json:"rank" db:"rank"`
+    ScoringConfigVersion string `json:"scoring_config_version,omitempty" db:"scoring_config_version"`
+    TargetPrice *float64  `json:"target_price,omitempty" db:"target_price"`
+    LastClose   *float64  `json:"last_close,omitempty"`
+    UpsidePercent *float64 `json:"upside_percent,omitempty"`
+}
+
+// ApplyLastClose sets the latest close and the upside of the recommendation's
+// target price against it.
+func (r *Recommendation) ApplyLastClose(bar *PriceBar) {
+    if bar == nil {
+        return
+    }
+
+    r.LastClose = &bar.Close
+    r.UpsidePercent = UpsidePercent(r.TargetPrice, bar.Close)
 }
\ No newline at end of file
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type RecommendationRunStatus string
+
+const (
+	RecommendationRunStatusRunning   RecommendationRunStatus = "running"
+	RecommendationRunStatusCompleted RecommendationRunStatus = "completed"
+	RecommendationRunStatusFailed    RecommendationRunStatus = "failed"
+)
+
+// RecommendationRun is one execution of the recommendation engine. Every
+// recommendation it produced is keyed to it by RunID. The single active run is
+// the one served as the latest recommendations.
+type RecommendationRun struct {
+	ID                   string                  `json:"id" db:"id"`
+	Status               RecommendationRunStatus `json:"status" db:"status"`
+	Strategy             string                  `json:"strategy" db:"strategy"`
+	ScoringConfigVersion string                  `json:"scoring_config_version,omitempty" db:"scoring_config_version"`
+	Params               json.RawMessage         `json:"params" db:"params"`
+	StartedAt            time.Time               `json:"started_at" db:"started_at"`
+	FinishedAt           *time.Time              `json:"finished_at,omitempty" db:"finished_at"`
+	RecommendationCount  int                     `json:"recommendation_count" db:"recommendation_count"`
+	ErrorMessage         string                  `json:"error_message,omitempty" db:"error_message"`
+	IsActive             bool                    `json:"is_active" db:"is_active"`
+	Recommendations      []*Recommendation       `json:"recommendations,omitempty" db:"-"`
+}
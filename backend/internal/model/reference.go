@@ -0,0 +1,90 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+type ReferenceKind string
+
+const (
+	ReferenceKindBrokerage ReferenceKind = "brokerage"
+	ReferenceKindRating    ReferenceKind = "rating"
+	ReferenceKindAction    ReferenceKind = "action"
+)
+
+type Brokerage struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type Rating struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	Rank      int       `json:"rank" db:"rank"` // 1 (most bearish) - 5 (most bullish)
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// Bullishness ranks of ratings that scoring awards points to. Ratings
+// ranked RatingRankOutperform or higher count as bullish.
+const (
+	RatingRankBuy        = 5
+	RatingRankOutperform = 4
+	RatingRankHold       = 3
+)
+
+// DefaultRatingRanks is the rank of each rating seeded in the ratings table,
+// keyed by normalized name. It ranks ratings when no reference data is
+// loaded.
+var DefaultRatingRanks = map[string]int{
+	"strong-buy":          5,
+	"buy":                 5,
+	"speculative buy":     4,
+	"outperform":          4,
+	"market outperform":   4,
+	"sector outperform":   4,
+	"overweight":          4,
+	"positive":            4,
+	"hold":                3,
+	"neutral":             3,
+	"equal weight":        3,
+	"market perform":      3,
+	"sector perform":      3,
+	"sector weight":       3,
+	"in-line":             3,
+	"peer perform":        3,
+	"underweight":         2,
+	"underperform":        2,
+	"sector underperform": 2,
+	"reduce":              2,
+	"negative":            2,
+	"sell":                1,
+	"strong sell":         1,
+}
+
+type Action struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+type ReferenceAlias struct {
+	Kind          ReferenceKind `json:"kind" db:"kind"`
+	Alias         string        `json:"alias" db:"alias"`
+	CanonicalName string        `json:"canonical_name" db:"canonical_name"`
+}
+
+type UnmappedReferenceValue struct {
+	Kind        ReferenceKind `json:"kind" db:"kind"`
+	RawValue    string        `json:"raw_value" db:"raw_value"`
+	Occurrences int           `json:"occurrences" db:"occurrences"`
+	FirstSeenAt time.Time     `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt  time.Time     `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// NormalizeReferenceValue lowercases and collapses whitespace so raw upstream
+// strings can be compared against canonical names and aliases.
+func NormalizeReferenceValue(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DefaultScoringConfigVersion identifies the built-in weights used when no
+// file or database version is configured.
+const DefaultScoringConfigVersion = "default"
+
+type ScoringConfig struct {
+	// Action Scores (0-40 points)
+	TargetRaisedScore     int `json:"target_raised_score" yaml:"target_raised_score"`
+	UpgradedScore         int `json:"upgraded_score" yaml:"upgraded_score"`
+	InitiatedScore        int `json:"initiated_score" yaml:"initiated_score"`
+	TargetMaintainedScore int `json:"target_maintained_score" yaml:"target_maintained_score"`
+
+	// Rating Scores (0-25 points), by the rank of the target rating in the
+	// ratings table: BuyScore for rank 5, OverweightScore for rank 4 and
+	// NeutralScore for rank 3. Sector Perform and Equal Weight, both rank 3,
+	// score SectorPerformScore and EqualWeightScore instead.
+	BuyScore           int `json:"buy_score" yaml:"buy_score"`
+	OverweightScore    int `json:"overweight_score" yaml:"overweight_score"`
+	SectorPerformScore int `json:"sector_perform_score" yaml:"sector_perform_score"`
+	EqualWeightScore   int `json:"equal_weight_score" yaml:"equal_weight_score"`
+	NeutralScore       int `json:"neutral_score" yaml:"neutral_score"`
+
+	// Target Change Scores (0-20 points)
+	HighTargetChangeScore   int `json:"high_target_change_score" yaml:"high_target_change_score"`
+	MediumTargetChangeScore int `json:"medium_target_change_score" yaml:"medium_target_change_score"`
+	LowTargetChangeScore    int `json:"low_target_change_score" yaml:"low_target_change_score"`
+	MinTargetChangeScore    int `json:"min_target_change_score" yaml:"min_target_change_score"`
+
+	// Freshness Scores (0-15 points)
+	TodayScore     int `json:"today_score" yaml:"today_score"`
+	YesterdayScore int `json:"yesterday_score" yaml:"yesterday_score"`
+	ThreeDaysScore int `json:"three_days_score" yaml:"three_days_score"`
+	WeekScore      int `json:"week_score" yaml:"week_score"`
+
+	// Thresholds
+	HighTargetChangePercent   float64 `json:"high_target_change_percent" yaml:"high_target_change_percent"`
+	MediumTargetChangePercent float64 `json:"medium_target_change_percent" yaml:"medium_target_change_percent"`
+	LowTargetChangePercent    float64 `json:"low_target_change_percent" yaml:"low_target_change_percent"`
+	MinTargetChangePercent    float64 `json:"min_target_change_percent" yaml:"min_target_change_percent"`
+}
+
+// ScoredActions are the canonical actions the scorers award points to. Any
+// other action scores nothing. Ratings score by their rank in the ratings
+// table instead.
+var ScoredActions = []string{"target raised by", "upgraded by", "initiated by", "target maintained by"}
+
+func DefaultScoringConfig() *ScoringConfig {
+	return &ScoringConfig{
+		TargetRaisedScore:     40,
+		UpgradedScore:         35,
+		InitiatedScore:        30,
+		TargetMaintainedScore: 20,
+
+		BuyScore:           25,
+		OverweightScore:    20,
+		SectorPerformScore: 15,
+		EqualWeightScore:   10,
+		NeutralScore:       5,
+
+		HighTargetChangeScore:   20,
+		MediumTargetChangeScore: 15,
+		LowTargetChangeScore:    10,
+		MinTargetChangeScore:    5,
+
+		TodayScore:     15,
+		YesterdayScore: 12,
+		ThreeDaysScore: 10,
+		WeekScore:      5,
+
+		HighTargetChangePercent:   50.0,
+		MediumTargetChangePercent: 25.0,
+		LowTargetChangePercent:    10.0,
+		MinTargetChangePercent:    5.0,
+	}
+}
+
+// ScoringConfigVersion is a named, immutable set of scoring weights. At most
+// one version is active at a time.
+type ScoringConfigVersion struct {
+	ID          int64           `json:"id" db:"id"`
+	Version     string          `json:"version" db:"version" yaml:"version"`
+	Description string          `json:"description" db:"description" yaml:"description"`
+	Config      json.RawMessage `json:"config" db:"config" yaml:"-"`
+	IsActive    bool            `json:"is_active" db:"is_active"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	ActivatedAt *time.Time      `json:"activated_at,omitempty" db:"activated_at"`
+}
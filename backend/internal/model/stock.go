@@ -7,19 +7,33 @@ import (
 	"github.com/valeriapadilla/stock-insights/internal/utils"
 )
 
+// DefaultStockSource names the original analyst-ratings API. Events without an
+// explicit source are attributed to it.
+const DefaultStockSource = "external_api"
+
 type Stock struct {
-	Ticker        string    `json:"ticker" db:"ticker"`
-	Company       string    `json:"company" db:"company"`
-	TargetFrom    string    `json:"target_from" db:"target_from"`
-	TargetTo      string    `json:"target_to" db:"target_to"`
-	RatingFrom    string    `json:"rating_from" db:"rating_from"`
-	RatingTo      string    `json:"rating_to" db:"rating_to"`
-	Action        string    `json:"action" db:"action"`
-	Brokerage     string    `json:"brokerage" db:"brokerage"`
-	Time          time.Time `json:"time" db:"time"`
-	CreatedAt     time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
-	ChangePercent string    `json:"change_percent,omitempty"` // Calculado dinámicamente
+	Ticker          string     `json:"ticker" db:"ticker"`
+	Company         string     `json:"company" db:"company"`
+	TargetFrom      string     `json:"target_from" db:"target_from"`
+	TargetTo        string     `json:"target_to" db:"target_to"`
+	RatingFrom      string     `json:"rating_from" db:"rating_from"`
+	RatingTo        string     `json:"rating_to" db:"rating_to"`
+	Action          string     `json:"action" db:"action"`
+	Brokerage       string     `json:"brokerage" db:"brokerage"`
+	Time            time.Time  `json:"time" db:"time"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	TargetFromPrice *float64   `json:"target_from_price,omitempty" db:"target_from_price"`
+	TargetToPrice   *float64   `json:"target_to_price,omitempty" db:"target_to_price"`
+	BrokerageID     *int64     `json:"brokerage_id,omitempty" db:"brokerage_id"`
+	RatingFromID    *int64     `json:"rating_from_id,omitempty" db:"rating_from_id"`
+	RatingToID      *int64     `json:"rating_to_id,omitempty" db:"rating_to_id"`
+	ActionID        *int64     `json:"action_id,omitempty" db:"action_id"`
+	Source          string     `json:"source,omitempty" db:"source"`
+	ChangePercent   string     `json:"change_percent,omitempty"` // Calculado dinámicamente
+	LastClose       *float64   `json:"last_close,omitempty"`
+	LastCloseDate   *time.Time `json:"last_close_date,omitempty"`
+	UpsidePercent   *float64   `json:"upside_percent,omitempty"`
 }
 
 func (s *Stock) GetRating() string {
@@ -37,6 +51,38 @@ func (s *Stock) GetTargetToPrice() float64 {
 	return utils.ParsePrice(s.TargetTo)
 }
 
+// SourceName returns the stock's source, falling back to DefaultStockSource.
+func (s *Stock) SourceName() string {
+	if s.Source == "" {
+		return DefaultStockSource
+	}
+	return s.Source
+}
+
+// PopulateTargetPrices fills the numeric target columns from the raw
+// "$123.45" strings; unparseable values are left nil.
+func (s *Stock) PopulateTargetPrices() {
+	s.TargetFromPrice = utils.PriceOrNil(s.TargetFrom)
+	s.TargetToPrice = utils.PriceOrNil(s.TargetTo)
+}
+
+// ApplyLastClose sets the latest close and the upside of the target price
+// against it.
+func (s *Stock) ApplyLastClose(bar *PriceBar) {
+	if bar == nil {
+		return
+	}
+
+	target := s.TargetToPrice
+	if target == nil {
+		target = utils.PriceOrNil(s.TargetTo)
+	}
+
+	s.LastClose = &bar.Close
+	s.LastCloseDate = &bar.Date
+	s.UpsidePercent = UpsidePercent(target, bar.Close)
+}
+
 func (s *Stock) GetChangePercentage() float64 {
 	fromPrice := s.GetTargetFromPrice()
 	toPrice := s.GetTargetToPrice()
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type StockRejectStatus string
+
+const (
+	StockRejectStatusPending   StockRejectStatus = "pending"
+	StockRejectStatusReplayed  StockRejectStatus = "replayed"
+	StockRejectStatusDiscarded StockRejectStatus = "discarded"
+)
+
+// IsValid reports whether s is a known reject status.
+func (s StockRejectStatus) IsValid() bool {
+	switch s {
+	case StockRejectStatusPending, StockRejectStatusReplayed, StockRejectStatusDiscarded:
+		return true
+	}
+	return false
+}
+
+// StockReject is an upstream event quarantined because it failed validation.
+// Pending rejects can be fixed and replayed into stocks, or discarded.
+type StockReject struct {
+	ID           string            `json:"id" db:"id"`
+	RunID        string            `json:"run_id,omitempty" db:"run_id"`
+	Source       string            `json:"source" db:"source"`
+	Ticker       string            `json:"ticker" db:"ticker"`
+	EventTime    *time.Time        `json:"event_time,omitempty" db:"event_time"`
+	Payload      json.RawMessage   `json:"payload" db:"payload"`
+	ErrorMessage string            `json:"error_message" db:"error_message"`
+	Status       StockRejectStatus `json:"status" db:"status"`
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	ResolvedAt   *time.Time        `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// StockRejectFilter narrows a reject listing; empty fields match everything.
+type StockRejectFilter struct {
+	Status StockRejectStatus
+	Source string
+	RunID  string
+}
+
+// StockEvent holds the fields of a stock event as a source delivers them,
+// without the ids, prices and timestamps derived during ingestion. It is the
+// payload kept for rejected events and decodes back into a Stock.
+type StockEvent struct {
+	Ticker     string    `json:"ticker"`
+	Company    string    `json:"company"`
+	TargetFrom string    `json:"target_from"`
+	TargetTo   string    `json:"target_to"`
+	RatingFrom string    `json:"rating_from"`
+	RatingTo   string    `json:"rating_to"`
+	Action     string    `json:"action"`
+	Brokerage  string    `json:"brokerage"`
+	Time       time.Time `json:"time"`
+}
+
+func NewStockEvent(stock *Stock) StockEvent {
+	return StockEvent{
+		Ticker:     stock.Ticker,
+		Company:    stock.Company,
+		TargetFrom: stock.TargetFrom,
+		TargetTo:   stock.TargetTo,
+		RatingFrom: stock.RatingFrom,
+		RatingTo:   stock.RatingTo,
+		Action:     stock.Action,
+		Brokerage:  stock.Brokerage,
+		Time:       stock.Time,
+	}
+}
@@ -0,0 +1,59 @@
+package model
+
+import "time"
+
+// StockRevision records how an upsert changed an analyst event that was
+// already stored, one entry per changed field.
+type StockRevision struct {
+	ID        string             `json:"id" db:"id"`
+	Ticker    string             `json:"ticker" db:"ticker"`
+	EventTime time.Time          `json:"event_time" db:"event_time"`
+	Source    string             `json:"source" db:"source"`
+	Changes   []StockFieldChange `json:"changes" db:"changes"`
+	RevisedAt time.Time          `json:"revised_at" db:"revised_at"`
+}
+
+// StockFieldChange is the before and after value of one stocks column.
+// Prices are numbers and a missing price is null.
+type StockFieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// DiffStocks lists the upstream and derived fields that differ between two
+// versions of the same event. Reference ids are left out because they follow
+// the text fields they are mapped from.
+func DiffStocks(before, after *Stock) []StockFieldChange {
+	var changes []StockFieldChange
+	addText := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, StockFieldChange{Field: field, Before: from, After: to})
+		}
+	}
+	addPrice := func(field string, from, to *float64) {
+		if priceValue(from) != priceValue(to) {
+			changes = append(changes, StockFieldChange{Field: field, Before: priceValue(from), After: priceValue(to)})
+		}
+	}
+
+	addText("company", before.Company, after.Company)
+	addText("target_from", before.TargetFrom, after.TargetFrom)
+	addText("target_to", before.TargetTo, after.TargetTo)
+	addText("rating_from", before.RatingFrom, after.RatingFrom)
+	addText("rating_to", before.RatingTo, after.RatingTo)
+	addText("action", before.Action, after.Action)
+	addText("brokerage", before.Brokerage, after.Brokerage)
+	addPrice("target_from_price", before.TargetFromPrice, after.TargetFromPrice)
+	addPrice("target_to_price", before.TargetToPrice, after.TargetToPrice)
+	addText("source", before.SourceName(), after.SourceName())
+
+	return changes
+}
+
+func priceValue(price *float64) any {
+	if price == nil {
+		return nil
+	}
+	return *price
+}
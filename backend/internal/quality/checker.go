@@ -0,0 +1,175 @@
+// Package quality runs rule-based data quality checks over ingested analyst
+// events. The ingestion run records the findings and, depending on the
+// policy, is flagged or failed; under the fail policy events with error
+// findings are also kept out of storage.
+package quality
+
+import (
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+const (
+	DefaultMaxTargetChangePercent = 1000.0
+	DefaultFutureTolerance        = time.Hour
+)
+
+// Config tunes the built-in rules and the policy for error findings. Zero
+// values select the defaults.
+type Config struct {
+	Policy model.DataQualityPolicy
+	// MaxTargetChangePercent is the largest accepted move between target_from
+	// and target_to, in either direction.
+	MaxTargetChangePercent float64
+	// FutureTolerance allows for clock skew before an event counts as being
+	// in the future.
+	FutureTolerance time.Duration
+}
+
+func (c Config) withDefaults() Config {
+	if c.Policy == "" {
+		c.Policy = model.DataQualityPolicyFlag
+	}
+	if c.MaxTargetChangePercent <= 0 {
+		c.MaxTargetChangePercent = DefaultMaxTargetChangePercent
+	}
+	if c.FutureTolerance <= 0 {
+		c.FutureTolerance = DefaultFutureTolerance
+	}
+	return c
+}
+
+// Normalizer maps raw upstream spellings onto canonical names and ranks
+// ratings, as the reference service does for scoring.
+type Normalizer interface {
+	CanonicalAction(action string) string
+	CanonicalRating(rating string) string
+	RatingRank(rating string) (int, bool)
+}
+
+// Rule checks one event. Findings only need Rule, Severity, Field, Value and
+// Message; the Checker fills in the event and run details.
+type Rule interface {
+	Name() string
+	Check(stock *model.Stock, now time.Time) []model.DataQualityFinding
+}
+
+// Checker applies a set of rules to events.
+type Checker struct {
+	config Config
+	rules  []Rule
+	now    func() time.Time
+}
+
+// NewChecker returns a Checker with the built-in rules. A nil normalizer
+// compares actions and ratings after lowercasing only.
+func NewChecker(config Config, normalizer Normalizer) *Checker {
+	config = config.withDefaults()
+	if normalizer == nil {
+		normalizer = plainNormalizer{}
+	}
+
+	return &Checker{
+		config: config,
+		rules: []Rule{
+			unparseableTargetRule{},
+			targetJumpRule{maxChangePercent: config.MaxTargetChangePercent},
+			futureTimestampRule{tolerance: config.FutureTolerance},
+			unknownActionRule{normalizer: normalizer, known: toSet(model.ScoredActions)},
+			unknownRatingRule{normalizer: normalizer},
+		},
+		now: time.Now,
+	}
+}
+
+// Enabled reports whether checks should run at all.
+func (c *Checker) Enabled() bool {
+	return c != nil && c.config.Policy != model.DataQualityPolicyOff
+}
+
+// Blocking reports whether events with error findings are kept out of
+// storage, as the fail policy does.
+func (c *Checker) Blocking() bool {
+	return c != nil && c.config.Policy == model.DataQualityPolicyFail
+}
+
+// Rules lists the names of the rules the Checker applies.
+func (c *Checker) Rules() []string {
+	names := make([]string, 0, len(c.rules))
+	for _, rule := range c.rules {
+		names = append(names, rule.Name())
+	}
+	return names
+}
+
+// Check runs every rule over stocks and returns the findings attributed to
+// source.
+func (c *Checker) Check(source string, stocks []*model.Stock) []*model.DataQualityFinding {
+	now := c.now()
+
+	var findings []*model.DataQualityFinding
+	for _, stock := range stocks {
+		if stock == nil {
+			continue
+		}
+		for _, rule := range c.rules {
+			for _, finding := range rule.Check(stock, now) {
+				finding.Source = source
+				finding.Ticker = stock.Ticker
+				finding.EventTime = stock.Time
+				finding.CreatedAt = now
+				findings = append(findings, &finding)
+			}
+		}
+	}
+	return findings
+}
+
+// Status applies the policy to the number of error findings of a run.
+func (c *Checker) Status(errors int) model.DataQualityStatus {
+	switch {
+	case errors == 0:
+		return model.DataQualityStatusPassed
+	case c.config.Policy == model.DataQualityPolicyFail:
+		return model.DataQualityStatusFailed
+	default:
+		return model.DataQualityStatusFlagged
+	}
+}
+
+// CountSeverities returns the number of error and warning findings.
+func CountSeverities(findings []*model.DataQualityFinding) (errors, warnings int) {
+	for _, finding := range findings {
+		switch finding.Severity {
+		case model.DataQualitySeverityError:
+			errors++
+		case model.DataQualitySeverityWarning:
+			warnings++
+		}
+	}
+	return errors, warnings
+}
+
+type plainNormalizer struct{}
+
+func (plainNormalizer) CanonicalAction(action string) string {
+	return model.NormalizeReferenceValue(action)
+}
+
+func (plainNormalizer) CanonicalRating(rating string) string {
+	return model.NormalizeReferenceValue(rating)
+}
+
+func (plainNormalizer) RatingRank(rating string) (int, bool) {
+	rank, ok := model.DefaultRatingRanks[model.NormalizeReferenceValue(rating)]
+	return rank, ok
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
@@ -0,0 +1,118 @@
+package quality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func newTestChecker(config Config) *Checker {
+	checker := NewChecker(config, nil)
+	checker.now = func() time.Time { return time.Date(2025, time.March, 12, 12, 0, 0, 0, time.UTC) }
+	return checker
+}
+
+func TestChecker_Check(t *testing.T) {
+	eventTime := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+	valid := model.Stock{
+		Ticker: "AAPL", Company: "Apple Inc.", TargetFrom: "$180.00", TargetTo: "$200.00",
+		Action: "Target Raised By", RatingTo: "Buy", Time: eventTime,
+	}
+
+	tests := []struct {
+		name      string
+		change    func(stock *model.Stock)
+		wantRules []string
+		wantError bool
+	}{
+		{name: "valid event", change: func(stock *model.Stock) {}},
+		{name: "unparseable target_to", change: func(stock *model.Stock) { stock.TargetTo = "N/A" }, wantRules: []string{RuleUnparseableTarget}, wantError: true},
+		{name: "zero target_to", change: func(stock *model.Stock) { stock.TargetTo = "$0.00" }, wantRules: []string{RuleUnparseableTarget}, wantError: true},
+		{name: "unparseable target_from", change: func(stock *model.Stock) { stock.TargetFrom = "abc" }, wantRules: []string{RuleUnparseableTarget}},
+		{name: "target jump", change: func(stock *model.Stock) { stock.TargetTo = "$20,000.00" }, wantRules: []string{RuleTargetJump}, wantError: true},
+		{name: "target collapse", change: func(stock *model.Stock) { stock.TargetTo = "$1.50" }, wantRules: []string{RuleTargetJump}, wantError: true},
+		{name: "future timestamp", change: func(stock *model.Stock) { stock.Time = eventTime.Add(48 * time.Hour) }, wantRules: []string{RuleFutureTimestamp}, wantError: true},
+		{name: "within clock skew", change: func(stock *model.Stock) { stock.Time = eventTime.Add(2*time.Hour + 30*time.Minute) }},
+		{name: "unknown action", change: func(stock *model.Stock) { stock.Action = "reiterated by" }, wantRules: []string{RuleUnknownAction}},
+		{name: "unknown rating", change: func(stock *model.Stock) { stock.RatingTo = "Accumulate" }, wantRules: []string{RuleUnknownRating}},
+	}
+
+	checker := newTestChecker(Config{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stock := valid
+			tt.change(&stock)
+
+			findings := checker.Check("primary", []*model.Stock{&stock})
+
+			var rules []string
+			for _, finding := range findings {
+				rules = append(rules, finding.Rule)
+				assert.Equal(t, "primary", finding.Source)
+				assert.Equal(t, "AAPL", finding.Ticker)
+				assert.Equal(t, stock.Time, finding.EventTime)
+				assert.NotEmpty(t, finding.Message)
+			}
+			assert.Equal(t, tt.wantRules, rules)
+
+			errors, _ := CountSeverities(findings)
+			assert.Equal(t, tt.wantError, errors > 0)
+		})
+	}
+}
+
+func TestChecker_UsesNormalizer(t *testing.T) {
+	checker := NewChecker(Config{}, aliasNormalizer{"accumulate": "buy"})
+
+	findings := checker.Check("primary", []*model.Stock{{Ticker: "AAPL", Action: "upgraded by", RatingTo: "Accumulate", Time: time.Now()}})
+
+	assert.Empty(t, findings)
+}
+
+func TestChecker_Status(t *testing.T) {
+	assert.Equal(t, model.DataQualityStatusPassed, newTestChecker(Config{}).Status(0))
+	assert.Equal(t, model.DataQualityStatusFlagged, newTestChecker(Config{}).Status(2))
+	assert.Equal(t, model.DataQualityStatusFailed, newTestChecker(Config{Policy: model.DataQualityPolicyFail}).Status(1))
+
+	assert.True(t, newTestChecker(Config{}).Enabled())
+	assert.False(t, newTestChecker(Config{Policy: model.DataQualityPolicyOff}).Enabled())
+	var disabled *Checker
+	assert.False(t, disabled.Enabled())
+
+	assert.True(t, newTestChecker(Config{Policy: model.DataQualityPolicyFail}).Blocking())
+	assert.False(t, newTestChecker(Config{}).Blocking())
+	assert.False(t, disabled.Blocking())
+}
+
+func TestChecker_MaxTargetChangePercent(t *testing.T) {
+	checker := newTestChecker(Config{MaxTargetChangePercent: 50})
+	stock := &model.Stock{Ticker: "AAPL", TargetFrom: "$100.00", TargetTo: "$160.00", Time: checker.now()}
+
+	findings := checker.Check("primary", []*model.Stock{stock})
+
+	require.Len(t, findings, 1)
+	assert.Equal(t, RuleTargetJump, findings[0].Rule)
+	assert.Equal(t, "$100.00 -> $160.00", findings[0].Value)
+}
+
+type aliasNormalizer map[string]string
+
+func (n aliasNormalizer) CanonicalAction(action string) string {
+	return model.NormalizeReferenceValue(action)
+}
+
+func (n aliasNormalizer) CanonicalRating(rating string) string {
+	normalized := model.NormalizeReferenceValue(rating)
+	if canonical, ok := n[normalized]; ok {
+		return canonical
+	}
+	return normalized
+}
+
+func (n aliasNormalizer) RatingRank(rating string) (int, bool) {
+	rank, ok := model.DefaultRatingRanks[n.CanonicalRating(rating)]
+	return rank, ok
+}
@@ -0,0 +1,150 @@
+package quality
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/utils"
+)
+
+const (
+	RuleUnparseableTarget = "unparseable_target"
+	RuleTargetJump        = "target_jump"
+	RuleFutureTimestamp   = "future_timestamp"
+	RuleUnknownAction     = "unknown_action"
+	RuleUnknownRating     = "unknown_rating"
+)
+
+// unparseableTargetRule flags targets that utils.ParsePrice would silently
+// turn into 0. target_to drives scoring and upside, so it is an error;
+// target_from only a warning.
+type unparseableTargetRule struct{}
+
+func (unparseableTargetRule) Name() string { return RuleUnparseableTarget }
+
+func (r unparseableTargetRule) Check(stock *model.Stock, now time.Time) []model.DataQualityFinding {
+	var findings []model.DataQualityFinding
+	check := func(field, value string, severity model.DataQualitySeverity) {
+		if strings.TrimSpace(value) == "" {
+			return
+		}
+		if price, ok := utils.TryParsePrice(value); !ok || price <= 0 {
+			findings = append(findings, model.DataQualityFinding{
+				Rule:     r.Name(),
+				Severity: severity,
+				Field:    field,
+				Value:    value,
+				Message:  fmt.Sprintf("%s %q is not a positive price", field, value),
+			})
+		}
+	}
+
+	check("target_to", stock.TargetTo, model.DataQualitySeverityError)
+	check("target_from", stock.TargetFrom, model.DataQualitySeverityWarning)
+	return findings
+}
+
+// targetJumpRule flags target moves beyond maxChangePercent, which are more
+// likely unit or typing errors than analyst calls.
+type targetJumpRule struct {
+	maxChangePercent float64
+}
+
+func (targetJumpRule) Name() string { return RuleTargetJump }
+
+func (r targetJumpRule) Check(stock *model.Stock, now time.Time) []model.DataQualityFinding {
+	from, ok1 := utils.TryParsePrice(stock.TargetFrom)
+	to, ok2 := utils.TryParsePrice(stock.TargetTo)
+	if !ok1 || !ok2 || from <= 0 || to <= 0 {
+		return nil
+	}
+
+	// Measure drops against the new target so a fall to a tenth is as
+	// suspicious as a rise to ten times.
+	change := (to - from) / from * 100
+	if to < from {
+		change = (from - to) / to * 100
+	}
+	if change <= r.maxChangePercent {
+		return nil
+	}
+
+	return []model.DataQualityFinding{{
+		Rule:     r.Name(),
+		Severity: model.DataQualitySeverityError,
+		Field:    "target_to",
+		Value:    fmt.Sprintf("%s -> %s", stock.TargetFrom, stock.TargetTo),
+		Message:  fmt.Sprintf("target moved by more than %.0f%%", r.maxChangePercent),
+	}}
+}
+
+// futureTimestampRule flags events dated after now plus tolerance.
+type futureTimestampRule struct {
+	tolerance time.Duration
+}
+
+func (futureTimestampRule) Name() string { return RuleFutureTimestamp }
+
+func (r futureTimestampRule) Check(stock *model.Stock, now time.Time) []model.DataQualityFinding {
+	if !stock.Time.After(now.Add(r.tolerance)) {
+		return nil
+	}
+
+	return []model.DataQualityFinding{{
+		Rule:     r.Name(),
+		Severity: model.DataQualitySeverityError,
+		Field:    "time",
+		Value:    stock.Time.UTC().Format(time.RFC3339),
+		Message:  "event time is in the future",
+	}}
+}
+
+// unknownActionRule flags actions the scorers do not recognize, after
+// reference alias mapping.
+type unknownActionRule struct {
+	normalizer Normalizer
+	known      map[string]bool
+}
+
+func (unknownActionRule) Name() string { return RuleUnknownAction }
+
+func (r unknownActionRule) Check(stock *model.Stock, now time.Time) []model.DataQualityFinding {
+	if strings.TrimSpace(stock.Action) == "" || r.known[r.normalizer.CanonicalAction(stock.Action)] {
+		return nil
+	}
+
+	return []model.DataQualityFinding{{
+		Rule:     r.Name(),
+		Severity: model.DataQualitySeverityWarning,
+		Field:    "action",
+		Value:    stock.Action,
+		Message:  "action is not recognized by scoring",
+	}}
+}
+
+// unknownRatingRule flags target ratings without a rank in the ratings table,
+// after reference alias mapping; the scorers cannot score them.
+type unknownRatingRule struct {
+	normalizer Normalizer
+}
+
+func (unknownRatingRule) Name() string { return RuleUnknownRating }
+
+func (r unknownRatingRule) Check(stock *model.Stock, now time.Time) []model.DataQualityFinding {
+	if strings.TrimSpace(stock.RatingTo) == "" {
+		return nil
+	}
+	if _, ok := r.normalizer.RatingRank(stock.RatingTo); ok {
+		return nil
+	}
+
+	return []model.DataQualityFinding{{
+		Rule:     r.Name(),
+		Severity: model.DataQualitySeverityWarning,
+		Field:    "rating_to",
+		Value:    stock.RatingTo,
+		Message:  "rating is not recognized by scoring",
+	}}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+// dataQualityFindingFilterClause matches $1 run id, $2 severity and $3 rule;
+// severity and rule are ignored when empty.
+const dataQualityFindingFilterClause = `
+		WHERE run_id = $1
+			AND ($2::TEXT = '' OR severity = $2)
+			AND ($3::TEXT = '' OR rule = $3)
+	`
+
+type DataQualityRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.DataQualityRepository = (*DataQualityRepository)(nil)
+
+func NewDataQualityRepository(db *sql.DB) *DataQualityRepository {
+	return &DataQualityRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *DataQualityRepository) CreateFindings(findings []*model.DataQualityFinding) error {
+	if len(findings) == 0 {
+		return nil
+	}
+
+	tx, err := r.GetDB().Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO data_quality_findings (id, run_id, source, rule, severity, ticker, event_time, field, value, message, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, finding := range findings {
+		_, err := stmt.Exec(
+			finding.ID,
+			finding.RunID,
+			finding.Source,
+			finding.Rule,
+			finding.Severity,
+			finding.Ticker,
+			finding.EventTime,
+			finding.Field,
+			finding.Value,
+			finding.Message,
+			finding.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create data quality finding: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetFindings lists the findings of a run matching filter, errors first.
+func (r *DataQualityRepository) GetFindings(runID string, filter model.DataQualityFindingFilter, limit, offset int) ([]*model.DataQualityFinding, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT id::TEXT, run_id::TEXT, source, rule, severity, ticker, event_time, field, value, message, created_at
+		FROM data_quality_findings` + dataQualityFindingFilterClause + `
+		ORDER BY severity = 'error' DESC, event_time DESC, ticker
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.GetDB().Query(query, runID, filter.Severity, filter.Rule, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data quality findings: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []*model.DataQualityFinding
+	for rows.Next() {
+		var finding model.DataQualityFinding
+		err := rows.Scan(
+			&finding.ID,
+			&finding.RunID,
+			&finding.Source,
+			&finding.Rule,
+			&finding.Severity,
+			&finding.Ticker,
+			&finding.EventTime,
+			&finding.Field,
+			&finding.Value,
+			&finding.Message,
+			&finding.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan data quality finding: %w", err)
+		}
+		findings = append(findings, &finding)
+	}
+
+	return findings, nil
+}
+
+func (r *DataQualityRepository) GetFindingsCount(runID string, filter model.DataQualityFindingFilter) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM data_quality_findings` + dataQualityFindingFilterClause
+	if err := r.GetDB().QueryRow(query, runID, filter.Severity, filter.Rule).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count data quality findings: %w", err)
+	}
+	return count, nil
+}
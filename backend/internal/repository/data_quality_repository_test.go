@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestDataQualityRepository(t *testing.T) {
+	testCfg := config.LoadTestConfig()
+	if !testCfg.HasTestDatabase() {
+		t.Skip("DATABASE_URL_TEST not set, skipping integration test")
+	}
+
+	err := connectToTestDatabase()
+	require.NoError(t, err)
+	defer database.Close()
+
+	repo := NewDataQualityRepository(database.DB)
+	runRepo := NewIngestionRunRepository(database.DB)
+
+	run := &model.IngestionRun{
+		ID:              uuid.New().String(),
+		Status:          model.IngestionStatusRunning,
+		StartedAt:       time.Now().UTC(),
+		IngestionResult: model.IngestionResult{Source: "primary"},
+	}
+	require.NoError(t, runRepo.CreateRun(run))
+
+	eventTime := time.Now().UTC().Truncate(time.Second)
+	newFinding := func(rule string, severity model.DataQualitySeverity, ticker string) *model.DataQualityFinding {
+		return &model.DataQualityFinding{
+			ID:        uuid.New().String(),
+			RunID:     run.ID,
+			Source:    "primary",
+			Rule:      rule,
+			Severity:  severity,
+			Ticker:    ticker,
+			EventTime: eventTime,
+			Field:     "target_to",
+			Value:     "N/A",
+			Message:   "target_to \"N/A\" is not a positive price",
+			CreatedAt: eventTime,
+		}
+	}
+
+	require.NoError(t, repo.CreateFindings([]*model.DataQualityFinding{
+		newFinding("unknown_rating", model.DataQualitySeverityWarning, "AAPL"),
+		newFinding("unparseable_target", model.DataQualitySeverityError, "MSFT"),
+		newFinding("unknown_action", model.DataQualitySeverityWarning, "NVDA"),
+	}))
+
+	t.Run("GetFindings lists errors first", func(t *testing.T) {
+		findings, err := repo.GetFindings(run.ID, model.DataQualityFindingFilter{}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, findings, 3)
+		assert.Equal(t, "MSFT", findings[0].Ticker)
+		assert.Equal(t, run.ID, findings[0].RunID)
+		assert.Equal(t, "N/A", findings[0].Value)
+
+		count, err := repo.GetFindingsCount(run.ID, model.DataQualityFindingFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("GetFindings filters by severity and rule", func(t *testing.T) {
+		filter := model.DataQualityFindingFilter{Severity: model.DataQualitySeverityWarning, Rule: "unknown_action"}
+		findings, err := repo.GetFindings(run.ID, filter, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, findings, 1)
+		assert.Equal(t, "NVDA", findings[0].Ticker)
+
+		count, err := repo.GetFindingsCount(uuid.New().String(), model.DataQualityFindingFilter{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
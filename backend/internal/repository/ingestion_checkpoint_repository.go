@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+type IngestionCheckpointRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.IngestionCheckpointRepository = (*IngestionCheckpointRepository)(nil)
+
+func NewIngestionCheckpointRepository(db *sql.DB) *IngestionCheckpointRepository {
+	return &IngestionCheckpointRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *IngestionCheckpointRepository) GetCheckpoint(source string) (*model.IngestionCheckpoint, error) {
+	query := `
+		SELECT source, status, next_page, high_water_mark, run_high_water_mark, pages_committed, started_at, updated_at
+		FROM ingestion_checkpoints
+		WHERE source = $1
+	`
+
+	var checkpoint model.IngestionCheckpoint
+	var highWaterMark, runHighWaterMark sql.NullTime
+	err := r.GetDB().QueryRow(query, source).Scan(
+		&checkpoint.Source,
+		&checkpoint.Status,
+		&checkpoint.NextPage,
+		&highWaterMark,
+		&runHighWaterMark,
+		&checkpoint.PagesCommitted,
+		&checkpoint.StartedAt,
+		&checkpoint.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingestion checkpoint: %w", err)
+	}
+
+	if highWaterMark.Valid {
+		checkpoint.HighWaterMark = &highWaterMark.Time
+	}
+	if runHighWaterMark.Valid {
+		checkpoint.RunHighWaterMark = &runHighWaterMark.Time
+	}
+
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint inserts or replaces the checkpoint of checkpoint.Source.
+func (r *IngestionCheckpointRepository) SaveCheckpoint(checkpoint *model.IngestionCheckpoint) error {
+	query := `
+		INSERT INTO ingestion_checkpoints (source, status, next_page, high_water_mark, run_high_water_mark, pages_committed, started_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, now())
+		ON CONFLICT (source) DO UPDATE SET
+			status = EXCLUDED.status,
+			next_page = EXCLUDED.next_page,
+			high_water_mark = EXCLUDED.high_water_mark,
+			run_high_water_mark = EXCLUDED.run_high_water_mark,
+			pages_committed = EXCLUDED.pages_committed,
+			started_at = EXCLUDED.started_at,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.GetDB().Exec(query,
+		checkpoint.Source,
+		checkpoint.Status,
+		checkpoint.NextPage,
+		checkpoint.HighWaterMark,
+		checkpoint.RunHighWaterMark,
+		checkpoint.PagesCommitted,
+		checkpoint.StartedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save ingestion checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// LockSource takes a session-level advisory lock on a dedicated connection,
+// so the lock is released if the process dies mid-ingestion.
+func (r *IngestionCheckpointRepository) LockSource(ctx context.Context, source string) (func(), bool, error) {
+	conn, err := r.GetDB().Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get connection for ingestion lock: %w", err)
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext('ingestion:' || $1))`, source).Scan(&locked)
+	if err != nil || !locked {
+		conn.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to take ingestion lock: %w", err)
+		}
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext('ingestion:' || $1))`, source)
+		conn.Close()
+	}
+	return unlock, true, nil
+}
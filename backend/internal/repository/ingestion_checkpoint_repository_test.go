@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestIngestionCheckpointRepository(t *testing.T) {
+	testCfg := config.LoadTestConfig()
+	if !testCfg.HasTestDatabase() {
+		t.Skip("DATABASE_URL_TEST not set, skipping integration test")
+	}
+
+	err := connectToTestDatabase()
+	require.NoError(t, err)
+	defer database.Close()
+
+	repo := NewIngestionCheckpointRepository(database.DB)
+
+	_, err = database.DB.Exec("DELETE FROM ingestion_checkpoints")
+	require.NoError(t, err)
+
+	t.Run("Missing checkpoint", func(t *testing.T) {
+		checkpoint, err := repo.GetCheckpoint("external_api")
+		require.NoError(t, err)
+		assert.Nil(t, checkpoint)
+	})
+
+	t.Run("Save and update", func(t *testing.T) {
+		startedAt := time.Date(2025, time.March, 10, 8, 0, 0, 0, time.UTC)
+		runMark := time.Date(2025, time.March, 9, 18, 0, 0, 0, time.UTC)
+
+		err := repo.SaveCheckpoint(&model.IngestionCheckpoint{
+			Source:           "external_api",
+			Status:           model.IngestionStatusRunning,
+			NextPage:         "MSFT",
+			RunHighWaterMark: &runMark,
+			PagesCommitted:   2,
+			StartedAt:        startedAt,
+		})
+		require.NoError(t, err)
+
+		checkpoint, err := repo.GetCheckpoint("external_api")
+		require.NoError(t, err)
+		require.NotNil(t, checkpoint)
+		assert.Equal(t, model.IngestionStatusRunning, checkpoint.Status)
+		assert.Equal(t, "MSFT", checkpoint.NextPage)
+		assert.Equal(t, 2, checkpoint.PagesCommitted)
+		assert.Nil(t, checkpoint.HighWaterMark)
+		require.NotNil(t, checkpoint.RunHighWaterMark)
+		assert.True(t, runMark.Equal(*checkpoint.RunHighWaterMark))
+		assert.True(t, checkpoint.CanResume())
+
+		checkpoint.Status = model.IngestionStatusCompleted
+		checkpoint.NextPage = ""
+		checkpoint.HighWaterMark = checkpoint.RunHighWaterMark
+		checkpoint.RunHighWaterMark = nil
+		require.NoError(t, repo.SaveCheckpoint(checkpoint))
+
+		checkpoint, err = repo.GetCheckpoint("external_api")
+		require.NoError(t, err)
+		assert.Equal(t, model.IngestionStatusCompleted, checkpoint.Status)
+		assert.False(t, checkpoint.CanResume())
+		require.NotNil(t, checkpoint.HighWaterMark)
+		assert.True(t, runMark.Equal(*checkpoint.HighWaterMark))
+		assert.Nil(t, checkpoint.RunHighWaterMark)
+	})
+}
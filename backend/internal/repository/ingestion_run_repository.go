@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+const ingestionRunSelectColumns = `id::TEXT, source, status, resumed, pages_fetched, pages_skipped, pages_resumed,
+	stocks_fetched, stocks_inserted, stocks_updated, stocks_unchanged, stocks_rejected,
+	reached_high_water_mark, high_water_mark, quality_status, quality_errors, quality_warnings,
+	COALESCE(error_message, ''), started_at, finished_at`
+
+type IngestionRunRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.IngestionRunRepository = (*IngestionRunRepository)(nil)
+
+func NewIngestionRunRepository(db *sql.DB) *IngestionRunRepository {
+	return &IngestionRunRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *IngestionRunRepository) CreateRun(run *model.IngestionRun) error {
+	query := `
+		INSERT INTO ingestion_runs (id, source, status, resumed, pages_resumed, started_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.GetDB().Exec(query,
+		run.ID,
+		run.Source,
+		run.Status,
+		run.Resumed,
+		run.PagesResumed,
+		run.StartedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create ingestion run: %w", err)
+	}
+
+	return nil
+}
+
+// FinishRun stores the final status, counts and quality verdict of run.
+func (r *IngestionRunRepository) FinishRun(run *model.IngestionRun) error {
+	query := `
+		UPDATE ingestion_runs
+		SET status = $2, pages_fetched = $3, pages_skipped = $4, stocks_fetched = $5,
+			stocks_inserted = $6, stocks_updated = $7, stocks_unchanged = $8, stocks_rejected = $9,
+			reached_high_water_mark = $10, high_water_mark = $11, error_message = NULLIF($12, ''),
+			quality_status = $13, quality_errors = $14, quality_warnings = $15, finished_at = now()
+		WHERE id = $1
+	`
+
+	_, err := r.GetDB().Exec(query,
+		run.ID,
+		run.Status,
+		run.PagesFetched,
+		run.PagesSkipped,
+		run.StocksFetched,
+		run.StocksInserted,
+		run.StocksUpdated,
+		run.StocksUnchanged,
+		run.StocksRejected,
+		run.ReachedHighWaterMark,
+		run.HighWaterMark,
+		run.ErrorMessage,
+		run.QualityStatus,
+		run.QualityErrors,
+		run.QualityWarnings,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to finish ingestion run: %w", err)
+	}
+
+	return nil
+}
+
+// GetRuns lists runs newest first, restricted to source unless it is empty.
+func (r *IngestionRunRepository) GetRuns(source string, limit, offset int) ([]*model.IngestionRun, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT ` + ingestionRunSelectColumns + `
+		FROM ingestion_runs
+		WHERE ($1::TEXT = '' OR source = $1)
+		ORDER BY started_at DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.GetDB().Query(query, source, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingestion runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*model.IngestionRun
+	for rows.Next() {
+		run, err := scanIngestionRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ingestion run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
+
+func (r *IngestionRunRepository) GetRunsCount(source string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM ingestion_runs WHERE ($1::TEXT = '' OR source = $1)`
+	if err := r.GetDB().QueryRow(query, source).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count ingestion runs: %w", err)
+	}
+	return count, nil
+}
+
+func (r *IngestionRunRepository) GetRunByID(runID string) (*model.IngestionRun, error) {
+	query := `SELECT ` + ingestionRunSelectColumns + ` FROM ingestion_runs WHERE id = $1`
+
+	run, err := scanIngestionRun(r.GetDB().QueryRow(query, runID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get ingestion run: %w", err)
+	}
+
+	return run, nil
+}
+
+// GetLastCompletedRunTime returns when the latest completed run of source
+// started, or nil when no run of it has completed.
+func (r *IngestionRunRepository) GetLastCompletedRunTime(source string) (*time.Time, error) {
+	query := `SELECT MAX(started_at) FROM ingestion_runs WHERE source = $1 AND status = $2`
+
+	var startedAt sql.NullTime
+	if err := r.GetDB().QueryRow(query, source, model.IngestionStatusCompleted).Scan(&startedAt); err != nil {
+		return nil, fmt.Errorf("failed to get last completed ingestion run: %w", err)
+	}
+	if !startedAt.Valid {
+		return nil, nil
+	}
+
+	return &startedAt.Time, nil
+}
+
+func scanIngestionRun(row rowScanner) (*model.IngestionRun, error) {
+	var run model.IngestionRun
+	var highWaterMark, finishedAt sql.NullTime
+
+	err := row.Scan(
+		&run.ID,
+		&run.Source,
+		&run.Status,
+		&run.Resumed,
+		&run.PagesFetched,
+		&run.PagesSkipped,
+		&run.PagesResumed,
+		&run.StocksFetched,
+		&run.StocksInserted,
+		&run.StocksUpdated,
+		&run.StocksUnchanged,
+		&run.StocksRejected,
+		&run.ReachedHighWaterMark,
+		&highWaterMark,
+		&run.QualityStatus,
+		&run.QualityErrors,
+		&run.QualityWarnings,
+		&run.ErrorMessage,
+		&run.StartedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	run.StocksSaved = run.StocksInserted + run.StocksUpdated
+	if highWaterMark.Valid {
+		run.HighWaterMark = &highWaterMark.Time
+	}
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+
+	return &run, nil
+}
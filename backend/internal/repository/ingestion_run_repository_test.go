@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestIngestionRunRepository(t *testing.T) {
+	testCfg := config.LoadTestConfig()
+	if !testCfg.HasTestDatabase() {
+		t.Skip("DATABASE_URL_TEST not set, skipping integration test")
+	}
+
+	err := connectToTestDatabase()
+	require.NoError(t, err)
+	defer database.Close()
+
+	repo := NewIngestionRunRepository(database.DB)
+
+	_, err = database.DB.Exec("DELETE FROM ingestion_runs")
+	require.NoError(t, err)
+
+	newRun := func(source string, startedAt time.Time) *model.IngestionRun {
+		run := &model.IngestionRun{
+			ID:              uuid.New().String(),
+			Status:          model.IngestionStatusRunning,
+			StartedAt:       startedAt,
+			IngestionResult: model.IngestionResult{Source: source},
+		}
+		require.NoError(t, repo.CreateRun(run))
+		return run
+	}
+
+	t.Run("Create, Finish and Get", func(t *testing.T) {
+		run := newRun("primary", time.Now().UTC())
+
+		run.Status = model.IngestionStatusCompleted
+		run.PagesFetched = 2
+		run.StocksFetched = 20
+		run.AddUpsert(&model.BulkUpsertResult{Inserted: 12, Updated: 3, Unchanged: 4, Rejected: 1})
+		run.QualityStatus = model.DataQualityStatusFlagged
+		run.QualityErrors = 2
+		run.QualityWarnings = 5
+		require.NoError(t, repo.FinishRun(run))
+
+		stored, err := repo.GetRunByID(run.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, model.IngestionStatusCompleted, stored.Status)
+		assert.Equal(t, "primary", stored.Source)
+		assert.Equal(t, 12, stored.StocksInserted)
+		assert.Equal(t, 3, stored.StocksUpdated)
+		assert.Equal(t, 4, stored.StocksUnchanged)
+		assert.Equal(t, 1, stored.StocksRejected)
+		assert.Equal(t, 15, stored.StocksSaved)
+		assert.Equal(t, model.DataQualityStatusFlagged, stored.QualityStatus)
+		assert.Equal(t, 2, stored.QualityErrors)
+		assert.Equal(t, 5, stored.QualityWarnings)
+		assert.NotNil(t, stored.FinishedAt)
+
+		missing, err := repo.GetRunByID(uuid.New().String())
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("GetRuns filters by source newest first", func(t *testing.T) {
+		now := time.Now().UTC()
+		older := newRun("backup", now.Add(-time.Hour))
+		newer := newRun("backup", now)
+
+		runs, err := repo.GetRuns("backup", 10, 0)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.Equal(t, newer.ID, runs[0].ID)
+		assert.Equal(t, older.ID, runs[1].ID)
+
+		count, err := repo.GetRunsCount("backup")
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		count, err = repo.GetRunsCount("")
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("GetLastCompletedRunTime ignores unfinished runs and other sources", func(t *testing.T) {
+		_, err := database.DB.Exec("DELETE FROM ingestion_runs")
+		require.NoError(t, err)
+
+		lastRun, err := repo.GetLastCompletedRunTime("primary")
+		require.NoError(t, err)
+		assert.Nil(t, lastRun)
+
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		completed := newRun("primary", now.Add(-2*time.Hour))
+		completed.Status = model.IngestionStatusCompleted
+		require.NoError(t, repo.FinishRun(completed))
+		newRun("primary", now)
+		other := newRun("secondary", now.Add(-time.Hour))
+		other.Status = model.IngestionStatusCompleted
+		require.NoError(t, repo.FinishRun(other))
+
+		lastRun, err = repo.GetLastCompletedRunTime("primary")
+		require.NoError(t, err)
+		require.NotNil(t, lastRun)
+		assert.True(t, now.Add(-2*time.Hour).Equal(*lastRun))
+	})
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"database/sql"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type DataQualityRepository interface {
+	CreateFindings(findings []*model.DataQualityFinding) error
+	GetFindings(runID string, filter model.DataQualityFindingFilter, limit, offset int) ([]*model.DataQualityFinding, error)
+	GetFindingsCount(runID string, filter model.DataQualityFindingFilter) (int, error)
+	GetDB() *sql.DB
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type IngestionCheckpointRepository interface {
+	GetCheckpoint(source string) (*model.IngestionCheckpoint, error)
+	SaveCheckpoint(checkpoint *model.IngestionCheckpoint) error
+	// LockSource takes the ingestion lock of source, shared by every process
+	// using the database. ok is false when another ingestion holds it. unlock
+	// releases the lock.
+	LockSource(ctx context.Context, source string) (unlock func(), ok bool, err error)
+	GetDB() *sql.DB
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type IngestionRunRepository interface {
+	CreateRun(run *model.IngestionRun) error
+	FinishRun(run *model.IngestionRun) error
+	GetRuns(source string, limit, offset int) ([]*model.IngestionRun, error)
+	GetRunsCount(source string) (int, error)
+	GetRunByID(runID string) (*model.IngestionRun, error)
+	GetLastCompletedRunTime(source string) (*time.Time, error)
+	GetDB() *sql.DB
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type PriceRepository interface {
+	UpsertPrices(bars []*model.PriceBar) (int, error)
+	GetLatestCloses(tickers []string) (map[string]*model.PriceBar, error)
+	GetLatestPriceDate(ticker string) (*time.Time, error)
+	GetPrices(ticker string, from, to time.Time) ([]*model.PriceBar, error)
+	GetTrackedTickers() ([]string, error)
+	GetDB() *sql.DB
+}
@@ -6,5 +6,6 @@ import (
 
 type RecommendationCommand interface {
 	BulkCreate(recommendations []*model.Recommendation) error
+	PublishRun(run *model.RecommendationRun) error
 	DeleteAllRecommendations() error
 }
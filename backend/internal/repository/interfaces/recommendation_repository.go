@@ -11,5 +11,7 @@ type RecommendationRepository interface {
 	CreateRecommendation(recommendation *model.Recommendation) error
 	GetLatest(limit int) ([]*model.Recommendation, error)
 	GetLatestRunAt() (*time.Time, error)
+	GetRecommendationsByRun(runID string) ([]*model.Recommendation, error)
+	DeleteOldRecommendations(maxAge time.Duration) (int64, error)
 	GetDB() *sql.DB
 }
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type RecommendationRunRepository interface {
+	CreateRun(run *model.RecommendationRun) error
+	FinishRun(runID string, status model.RecommendationRunStatus, recommendationCount int, errorMessage string) error
+	GetRuns(limit, offset int) ([]*model.RecommendationRun, error)
+	GetRunsCount() (int, error)
+	GetRunByID(runID string) (*model.RecommendationRun, error)
+	GetLastCompletedRunTime() (*time.Time, error)
+	GetDB() *sql.DB
+}
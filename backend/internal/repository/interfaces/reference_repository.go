@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"database/sql"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type ReferenceRepository interface {
+	GetBrokerages() ([]*model.Brokerage, error)
+	GetRatings() ([]*model.Rating, error)
+	GetActions() ([]*model.Action, error)
+	GetAliases() ([]*model.ReferenceAlias, error)
+	FindBrokerage(key string) (*model.Brokerage, error)
+	CreateBrokerage(name string) (*model.Brokerage, error)
+	RecordUnmapped(kind model.ReferenceKind, rawValues map[string]int) error
+	GetUnmapped(kind model.ReferenceKind, limit, offset int) ([]*model.UnmappedReferenceValue, error)
+	GetDB() *sql.DB
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"database/sql"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type ScoringConfigRepository interface {
+	ListConfigs(limit, offset int) ([]*model.ScoringConfigVersion, error)
+	CountConfigs() (int, error)
+	GetByVersion(version string) (*model.ScoringConfigVersion, error)
+	GetActive() (*model.ScoringConfigVersion, error)
+	CreateConfig(config *model.ScoringConfigVersion) error
+	ActivateConfig(version string) (bool, error)
+	GetDB() *sql.DB
+}
@@ -8,5 +8,6 @@ type StockCommand interface {
 	Create(stock *model.Stock) error
 	BulkCreate(stocks []*model.Stock) error
 	Upsert(stock *model.Stock) error
-	BulkUpsert(stocks []*model.Stock) error
+	BulkUpsert(stocks []*model.Stock) (*model.BulkUpsertResult, error)
+	PreviewBulkUpsert(stocks []*model.Stock) (*model.BulkUpsertResult, error)
 }
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"database/sql"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type StockRejectRepository interface {
+	CreateRejects(rejects []*model.StockReject) error
+	GetRejects(filter model.StockRejectFilter, limit, offset int) ([]*model.StockReject, error)
+	GetRejectsCount(filter model.StockRejectFilter) (int, error)
+	GetRejectByID(rejectID string) (*model.StockReject, error)
+	UpdateReject(reject *model.StockReject) error
+	GetDB() *sql.DB
+}
@@ -17,6 +17,13 @@ type StockSearchFilters struct {
 	Offset   int        `json:"offset"`
 }
 
+type StockHistoryFilters struct {
+	DateFrom *time.Time `json:"date_from"`
+	DateTo   *time.Time `json:"date_to"`
+	Limit    int        `json:"limit"`
+	Offset   int        `json:"offset"`
+}
+
 type GetStocksParams struct {
 	Limit   int                 `json:"limit"`
 	Offset  int                 `json:"offset"`
@@ -32,6 +39,8 @@ type StockRepository interface {
 	GetLastUpdateTime() (*time.Time, error)
 	ExistsByTicker(ticker string) (bool, error)
 	GetStockByTicket(ticket string) (*model.Stock, error)
+	GetStockHistory(ticket string, filters StockHistoryFilters) ([]*model.Stock, error)
+	GetStockHistoryCount(ticket string, filters StockHistoryFilters) (int, error)
 	SearchStocks(filters StockSearchFilters) ([]*model.Stock, error)
 	GetDB() *sql.DB
 }
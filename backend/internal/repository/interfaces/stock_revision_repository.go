@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type StockRevisionRepository interface {
+	GetRevisions(ticker string, eventTime time.Time) ([]*model.StockRevision, error)
+	GetDB() *sql.DB
+}
@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/valeriapadilla/stock-insights/internal/job"
+)
+
+const jobSelectColumns = `id::TEXT, type, status, priority, key, progress, message, error, created_by, created_at, started_at, ended_at, updated_at, result`
+
+// Postgres error codes of a unique constraint violation and of a value that
+// does not parse, such as a malformed UUID.
+const (
+	uniqueViolation           = "23505"
+	invalidTextRepresentation = "22P02"
+)
+
+// JobRepository stores admin jobs in the jobs table so that every API
+// replica sees the same jobs and they survive restarts.
+type JobRepository struct {
+	*BaseRepository
+}
+
+var _ job.Store = (*JobRepository)(nil)
+
+func NewJobRepository(db *sql.DB) *JobRepository {
+	return &JobRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *JobRepository) CreateJob(j *job.Job) error {
+	query := `
+		INSERT INTO jobs (id, type, status, priority, key, progress, message, error, created_by, created_at, started_at, ended_at, result)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, '')::JSONB)
+	`
+
+	_, err := r.GetDB().Exec(query,
+		j.ID,
+		j.Type,
+		j.Status,
+		j.Priority,
+		j.Key,
+		j.Progress,
+		j.Message,
+		j.Error,
+		j.CreatedBy,
+		j.CreatedAt,
+		j.StartedAt,
+		j.EndedAt,
+		string(j.Result),
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation && pqErr.Constraint == "idx_jobs_active_key" {
+		return job.ErrDuplicateJobKey
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob returns nil for an unknown job, including an ID that is not a UUID.
+func (r *JobRepository) GetJob(jobID string) (*job.Job, error) {
+	query := `SELECT ` + jobSelectColumns + ` FROM jobs WHERE id = $1::uuid`
+
+	j, err := scanJob(r.GetDB().QueryRow(query, jobID))
+	if err != nil {
+		var pqErr *pq.Error
+		if err == sql.ErrNoRows || (errors.As(err, &pqErr) && pqErr.Code == invalidTextRepresentation) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return j, nil
+}
+
+// ListJobs returns a page of the jobs matching filter, newest first, with
+// the total number of matching jobs.
+func (r *JobRepository) ListJobs(filter job.JobFilter, limit, offset int) ([]*job.Job, int, error) {
+	qb := NewQueryBuilder().
+		Select(jobSelectColumns).
+		From("jobs").
+		Where("type = ?", string(filter.Type)).
+		Where("status = ?", string(filter.Status)).
+		Where("key = ?", filter.Key).
+		Where("starts_with(key, ?)", filter.KeyPrefix)
+
+	if filter.Active {
+		qb.Where("status = ANY(?)", pq.Array([]string{string(job.JobStatusPending), string(job.JobStatusRunning)}))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		qb.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		qb.Where("created_at < ?", filter.CreatedTo)
+	}
+	if !filter.UpdatedAfter.IsZero() {
+		qb.Where("updated_at >= ?", filter.UpdatedAfter)
+	}
+
+	countQuery, countArgs := qb.CountQuery()
+	var total int
+	if err := r.GetDB().QueryRow(countQuery, countArgs...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	query, args := qb.OrderBy("created_at", "DESC").Limit(limit).Offset(offset).Build()
+	rows, err := r.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*job.Job
+	for rows.Next() {
+		j, err := scanJob(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to iterate jobs: %w", err)
+	}
+
+	return jobs, total, nil
+}
+
+// UpdateJob stores the status, progress, message, error and timestamps of j
+// while it is still pending or running, so a job finished elsewhere, such as
+// cancelled through another replica, stays finished. updated is false when
+// the job was already finished or is unknown.
+func (r *JobRepository) UpdateJob(j *job.Job) (bool, error) {
+	query := `
+		UPDATE jobs
+		SET status = $2, progress = $3, message = $4, error = $5, started_at = $6, ended_at = $7, updated_at = now()
+		WHERE id = $1 AND status IN ($8, $9)
+	`
+
+	result, err := r.GetDB().Exec(query,
+		j.ID,
+		j.Status,
+		j.Progress,
+		j.Message,
+		j.Error,
+		j.StartedAt,
+		j.EndedAt,
+		job.JobStatusPending,
+		job.JobStatusRunning,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update job: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to update job: %w", err)
+	}
+	return updated > 0, nil
+}
+
+// SetJobResult stores the result of a job whatever its status.
+func (r *JobRepository) SetJobResult(jobID string, result []byte) error {
+	query := `UPDATE jobs SET result = NULLIF($2, '')::JSONB, updated_at = now() WHERE id = $1`
+
+	if _, err := r.GetDB().Exec(query, jobID, string(result)); err != nil {
+		return fmt.Errorf("failed to set job result: %w", err)
+	}
+	return nil
+}
+
+func (r *JobRepository) FailStaleJobs(cutoff time.Time, reason string) (int64, error) {
+	query := `
+		UPDATE jobs
+		SET status = $1, error = $2, ended_at = now(), updated_at = now()
+		WHERE status IN ($3, $4) AND updated_at < $5
+	`
+
+	result, err := r.GetDB().Exec(query, job.JobStatusFailed, reason, job.JobStatusPending, job.JobStatusRunning, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale jobs: %w", err)
+	}
+
+	failed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count stale jobs: %w", err)
+	}
+
+	return failed, nil
+}
+
+func (r *JobRepository) DeleteJobsCreatedBefore(cutoff time.Time) (int64, error) {
+	result, err := r.GetDB().Exec(`DELETE FROM jobs WHERE created_at < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old jobs: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted jobs: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func scanJob(row rowScanner) (*job.Job, error) {
+	var j job.Job
+	var startedAt, endedAt sql.NullTime
+	var result []byte
+
+	err := row.Scan(
+		&j.ID,
+		&j.Type,
+		&j.Status,
+		&j.Priority,
+		&j.Key,
+		&j.Progress,
+		&j.Message,
+		&j.Error,
+		&j.CreatedBy,
+		&j.CreatedAt,
+		&startedAt,
+		&endedAt,
+		&j.UpdatedAt,
+		&result,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if startedAt.Valid {
+		j.StartedAt = &startedAt.Time
+	}
+	if endedAt.Valid {
+		j.EndedAt = &endedAt.Time
+	}
+	if len(result) > 0 {
+		j.Result = result
+	}
+
+	return &j, nil
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/job"
+)
+
+func TestJobRepository(t *testing.T) {
+	testCfg := config.LoadTestConfig()
+	if !testCfg.HasTestDatabase() {
+		t.Skip("DATABASE_URL_TEST not set, skipping integration test")
+	}
+
+	err := connectToTestDatabase()
+	require.NoError(t, err)
+	defer database.Close()
+
+	repo := NewJobRepository(database.DB)
+
+	_, err = database.DB.Exec("DELETE FROM jobs")
+	require.NoError(t, err)
+
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	newJob := func(createdAt time.Time) *job.Job {
+		return &job.Job{
+			ID:        uuid.New().String(),
+			Type:      job.JobTypeIngest,
+			Status:    job.JobStatusPending,
+			CreatedAt: createdAt,
+			CreatedBy: "admin",
+			Message:   "Job created",
+		}
+	}
+
+	current := newJob(createdAt)
+	old := newJob(createdAt.Add(-48 * time.Hour))
+	require.NoError(t, repo.CreateJob(current))
+	require.NoError(t, repo.CreateJob(old))
+
+	t.Run("GetJob returns stored job", func(t *testing.T) {
+		stored, err := repo.GetJob(current.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, job.JobTypeIngest, stored.Type)
+		assert.Equal(t, job.JobStatusPending, stored.Status)
+		assert.Equal(t, "admin", stored.CreatedBy)
+		assert.Nil(t, stored.StartedAt)
+		assert.Nil(t, stored.Result)
+		assert.True(t, createdAt.Equal(stored.CreatedAt))
+	})
+
+	t.Run("GetJob returns nil for unknown job", func(t *testing.T) {
+		stored, err := repo.GetJob(uuid.New().String())
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		stored, err = repo.GetJob("not-a-uuid")
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("UpdateJob stores progress and timestamps", func(t *testing.T) {
+		startedAt := time.Now().UTC()
+		endedAt := startedAt.Add(time.Second)
+		current.Status = job.JobStatusCancelled
+		current.Progress = 50
+		current.Error = "upstream unavailable"
+		current.StartedAt = &startedAt
+		current.EndedAt = &endedAt
+		updated, err := repo.UpdateJob(current)
+		require.NoError(t, err)
+		assert.True(t, updated)
+		require.NoError(t, repo.SetJobResult(current.ID, []byte(`{"stocks_saved": 12}`)))
+
+		stored, err := repo.GetJob(current.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, job.JobStatusCancelled, stored.Status)
+		assert.Equal(t, 50, stored.Progress)
+		assert.Equal(t, "upstream unavailable", stored.Error)
+		require.NotNil(t, stored.StartedAt)
+		require.NotNil(t, stored.EndedAt)
+		assert.JSONEq(t, `{"stocks_saved": 12}`, string(stored.Result))
+	})
+
+	t.Run("UpdateJob leaves finished jobs alone", func(t *testing.T) {
+		running := *current
+		running.Status = job.JobStatusRunning
+		updated, err := repo.UpdateJob(&running)
+		require.NoError(t, err)
+		assert.False(t, updated)
+
+		stored, err := repo.GetJob(current.ID)
+		require.NoError(t, err)
+		assert.Equal(t, job.JobStatusCancelled, stored.Status)
+	})
+
+	queued := newJob(createdAt.Add(-time.Hour))
+	queued.Type = job.JobTypeBackfill
+	queued.Priority = 5
+	queued.Key = "backfill:events.csv"
+	require.NoError(t, repo.CreateJob(queued))
+
+	t.Run("ListJobs filters jobs", func(t *testing.T) {
+		jobs, total, err := repo.ListJobs(job.JobFilter{}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, jobs, 3)
+		assert.Equal(t, []string{current.ID, queued.ID, old.ID}, []string{jobs[0].ID, jobs[1].ID, jobs[2].ID})
+
+		jobs, total, err = repo.ListJobs(job.JobFilter{}, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, queued.ID, jobs[0].ID)
+		assert.Equal(t, 5, jobs[0].Priority)
+		assert.Equal(t, "backfill:events.csv", jobs[0].Key)
+
+		jobs, total, err = repo.ListJobs(job.JobFilter{Type: job.JobTypeBackfill, Status: job.JobStatusPending}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, queued.ID, jobs[0].ID)
+
+		jobs, total, err = repo.ListJobs(job.JobFilter{Key: queued.Key, Active: true}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, jobs, 1)
+
+		_, total, err = repo.ListJobs(job.JobFilter{Active: true}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+
+		_, total, err = repo.ListJobs(job.JobFilter{CreatedFrom: createdAt.Add(-2 * time.Hour), CreatedTo: createdAt}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("FailStaleJobs fails jobs that stopped being updated", func(t *testing.T) {
+		_, err := database.DB.Exec("UPDATE jobs SET updated_at = now() - INTERVAL '1 hour' WHERE id = $1", queued.ID)
+		require.NoError(t, err)
+
+		failed, err := repo.FailStaleJobs(time.Now().Add(-time.Minute), "job lost")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), failed)
+
+		stored, err := repo.GetJob(queued.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, job.JobStatusFailed, stored.Status)
+		assert.Equal(t, "job lost", stored.Error)
+		assert.NotNil(t, stored.EndedAt)
+
+		// The old job is pending but was updated recently
+		stored, err = repo.GetJob(old.ID)
+		require.NoError(t, err)
+		assert.Equal(t, job.JobStatusPending, stored.Status)
+	})
+
+	t.Run("DeleteJobsCreatedBefore removes old jobs", func(t *testing.T) {
+		deleted, err := repo.DeleteJobsCreatedBefore(createdAt.Add(-24 * time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		stored, err := repo.GetJob(old.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored)
+
+		stored, err = repo.GetJob(current.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored)
+	})
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+const priceSelectColumns = `ticker, date, open, high, low, close, volume, source, created_at, updated_at`
+
+type PriceRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.PriceRepository = (*PriceRepository)(nil)
+
+func NewPriceRepository(db *sql.DB) *PriceRepository {
+	return &PriceRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// UpsertPrices writes the bars in one transaction, replacing any bar already
+// stored for the same ticker and date. It returns the number of bars written.
+func (r *PriceRepository) UpsertPrices(bars []*model.PriceBar) (int, error) {
+	if len(bars) == 0 {
+		return 0, nil
+	}
+
+	query := `
+		INSERT INTO prices (ticker, date, open, high, low, close, volume, source, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, now(), now())
+		ON CONFLICT (ticker, date) DO UPDATE SET
+			open = EXCLUDED.open,
+			high = EXCLUDED.high,
+			low = EXCLUDED.low,
+			close = EXCLUDED.close,
+			volume = EXCLUDED.volume,
+			source = EXCLUDED.source,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	written := 0
+	err := r.ExecuteTransaction(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(query)
+		if err != nil {
+			return fmt.Errorf("failed to prepare statement: %w", err)
+		}
+		defer stmt.Close()
+
+		for _, bar := range bars {
+			if bar == nil || bar.Ticker == "" || bar.Date.IsZero() {
+				continue
+			}
+
+			_, err := stmt.Exec(
+				bar.Ticker, bar.Date.Format("2006-01-02"),
+				bar.Open, bar.High, bar.Low, bar.Close, bar.Volume, bar.Source,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to upsert price %s %s: %w", bar.Ticker, bar.Date.Format("2006-01-02"), err)
+			}
+			written++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return written, nil
+}
+
+// GetLatestCloses returns the most recent bar of each ticker, keyed by ticker.
+// Tickers without prices are absent from the map.
+func (r *PriceRepository) GetLatestCloses(tickers []string) (map[string]*model.PriceBar, error) {
+	closes := make(map[string]*model.PriceBar)
+	if len(tickers) == 0 {
+		return closes, nil
+	}
+
+	query := `
+		SELECT DISTINCT ON (ticker) ` + priceSelectColumns + `
+		FROM prices
+		WHERE ticker = ANY($1)
+		ORDER BY ticker, date DESC
+	`
+
+	bars, err := r.queryPrices(query, pq.Array(tickers))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest closes: %w", err)
+	}
+
+	for _, bar := range bars {
+		closes[bar.Ticker] = bar
+	}
+
+	return closes, nil
+}
+
+func (r *PriceRepository) GetLatestPriceDate(ticker string) (*time.Time, error) {
+	query := `SELECT MAX(date) FROM prices WHERE ticker = $1`
+
+	var latest sql.NullTime
+	if err := r.GetDB().QueryRow(query, ticker).Scan(&latest); err != nil {
+		return nil, fmt.Errorf("failed to get latest price date: %w", err)
+	}
+
+	if !latest.Valid {
+		return nil, nil
+	}
+	return &latest.Time, nil
+}
+
+func (r *PriceRepository) GetPrices(ticker string, from, to time.Time) ([]*model.PriceBar, error) {
+	query := `
+		SELECT ` + priceSelectColumns + `
+		FROM prices
+		WHERE ticker = $1 AND date >= $2 AND date <= $3
+		ORDER BY date ASC
+	`
+
+	bars, err := r.queryPrices(query, ticker, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prices: %w", err)
+	}
+
+	return bars, nil
+}
+
+// GetTrackedTickers lists every ticker with analyst coverage, which is the set
+// of tickers the price worker keeps up to date.
+func (r *PriceRepository) GetTrackedTickers() ([]string, error) {
+	rows, err := r.GetDB().Query(`SELECT DISTINCT ticker FROM stocks ORDER BY ticker`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracked tickers: %w", err)
+	}
+	defer rows.Close()
+
+	var tickers []string
+	for rows.Next() {
+		var ticker string
+		if err := rows.Scan(&ticker); err != nil {
+			return nil, fmt.Errorf("failed to scan ticker: %w", err)
+		}
+		tickers = append(tickers, ticker)
+	}
+
+	return tickers, rows.Err()
+}
+
+func (r *PriceRepository) queryPrices(query string, args ...interface{}) ([]*model.PriceBar, error) {
+	rows, err := r.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bars []*model.PriceBar
+	for rows.Next() {
+		var bar model.PriceBar
+		err := rows.Scan(
+			&bar.Ticker, &bar.Date,
+			&bar.Open, &bar.High, &bar.Low, &bar.Close, &bar.Volume,
+			&bar.Source, &bar.CreatedAt, &bar.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		bars = append(bars, &bar)
+	}
+
+	return bars, rows.Err()
+}
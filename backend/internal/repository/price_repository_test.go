@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestPriceRepository(t *testing.T) {
+	testCfg := config.LoadTestConfig()
+	if !testCfg.HasTestDatabase() {
+		t.Skip("DATABASE_URL_TEST not set, skipping integration test")
+	}
+
+	err := connectToTestDatabase()
+	require.NoError(t, err)
+	defer database.Close()
+
+	repo := NewPriceRepository(database.DB)
+
+	_, err = database.DB.Exec("DELETE FROM prices")
+	require.NoError(t, err)
+
+	day := func(d int) time.Time {
+		return time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("Upsert and read back", func(t *testing.T) {
+		written, err := repo.UpsertPrices([]*model.PriceBar{
+			{Ticker: "AAPL", Date: day(3), Open: 100, High: 105, Low: 99, Close: 104, Volume: 1000, Source: "csv"},
+			{Ticker: "AAPL", Date: day(4), Open: 104, High: 106, Low: 101, Close: 102.5, Volume: 1500, Source: "csv"},
+			{Ticker: "MSFT", Date: day(3), Open: 400, High: 410, Low: 395, Close: 405, Volume: 2000, Source: "csv"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, written)
+
+		// Re-fetching a day replaces it
+		_, err = repo.UpsertPrices([]*model.PriceBar{
+			{Ticker: "AAPL", Date: day(4), Open: 104, High: 106, Low: 101, Close: 103, Volume: 1600, Source: "http"},
+		})
+		require.NoError(t, err)
+
+		bars, err := repo.GetPrices("AAPL", day(1), day(31))
+		require.NoError(t, err)
+		require.Len(t, bars, 2)
+		assert.Equal(t, 103.0, bars[1].Close)
+		assert.Equal(t, "http", bars[1].Source)
+	})
+
+	t.Run("Latest closes and dates", func(t *testing.T) {
+		closes, err := repo.GetLatestCloses([]string{"AAPL", "MSFT", "NVDA"})
+		require.NoError(t, err)
+		require.Len(t, closes, 2)
+		assert.Equal(t, 103.0, closes["AAPL"].Close)
+		assert.Equal(t, 405.0, closes["MSFT"].Close)
+
+		latest, err := repo.GetLatestPriceDate("AAPL")
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, "2025-03-04", latest.Format("2006-01-02"))
+
+		missing, err := repo.GetLatestPriceDate("NVDA")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+}
@@ -25,20 +25,63 @@ func NewRecommendationCommand(db *sql.DB, stockRepo interfaces.StockRepository)
 	}
 }
 
+// BulkCreate inserts recommendations in a single transaction.
 func (c *RecommendationCommandImpl) BulkCreate(recommendations []*model.Recommendation) error {
 	if len(recommendations) == 0 {
 		return nil
 	}
 
-	tx, err := c.GetDB().Begin()
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+	return c.ExecuteTransaction(func(tx *sql.Tx) error {
+		return c.insertRecommendations(tx, recommendations)
+	})
+}
+
+// PublishRun inserts the run's recommendations, marks it completed and makes it
+// the active run in one transaction, so readers of GetLatest see either the
+// previous ranking or the new one in full.
+func (c *RecommendationCommandImpl) PublishRun(run *model.RecommendationRun) error {
+	if run == nil || run.ID == "" {
+		return fmt.Errorf("recommendation run is required")
+	}
+
+	return c.ExecuteTransaction(func(tx *sql.Tx) error {
+		if err := c.insertRecommendations(tx, run.Recommendations); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`UPDATE recommendation_runs SET is_active = false WHERE is_active AND id <> $1`, run.ID); err != nil {
+			return fmt.Errorf("failed to deactivate previous recommendation run: %w", err)
+		}
+
+		result, err := tx.Exec(`
+			UPDATE recommendation_runs
+			SET status = $2, finished_at = now(), recommendation_count = $3, error_message = NULL, is_active = true
+			WHERE id = $1
+		`, run.ID, model.RecommendationRunStatusCompleted, len(run.Recommendations))
+		if err != nil {
+			return fmt.Errorf("failed to activate recommendation run: %w", err)
+		}
+
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to check activated recommendation run: %w", err)
+		}
+		if updated == 0 {
+			return fmt.Errorf("recommendation run %s not found", run.ID)
+		}
+
+		return nil
+	})
+}
+
+func (c *RecommendationCommandImpl) insertRecommendations(tx *sql.Tx, recommendations []*model.Recommendation) error {
+	if len(recommendations) == 0 {
+		return nil
 	}
-	defer tx.Rollback()
 
 	query := `
-		INSERT INTO recommendations (ticker, score, explanation, rank, run_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO recommendations (ticker, score, explanation, rank, run_at, scoring_config_version, run_id, target_price)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, '')::UUID, $8)
 	`
 
 	stmt, err := tx.Prepare(query)
@@ -55,16 +98,14 @@ func (c *RecommendationCommandImpl) BulkCreate(recommendations []*model.Recommen
 		_, err := stmt.Exec(
 			recommendation.Ticker, recommendation.Score,
 			recommendation.Explanation, recommendation.Rank, recommendation.RunAt,
+			recommendation.ScoringConfigVersion, recommendation.RunID,
+			recommendation.TargetPrice,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert recommendation %s: %w", recommendation.Ticker, err)
 		}
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return nil
 }
 
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/valeriapadilla/stock-insights/internal/model"
@@ -9,6 +10,12 @@ import (
 	"github.com/valeriapadilla/stock-insights/internal/validator"
 )
 
+const recommendationSelectColumns = `id, COALESCE(run_id::TEXT, ''), ticker, score, explanation, run_at, rank, COALESCE(scoring_config_version, ''), target_price`
+
+// activeRunQuery selects the run served by GetLatest. Retention never deletes
+// it, however old it is.
+const activeRunQuery = `SELECT id FROM recommendation_runs WHERE is_active`
+
 type RecommendationRepository struct {
 	*BaseRepository
 	validator *validator.RecommendationValidator
@@ -25,8 +32,8 @@ func NewRecommendationRepository(db *sql.DB) *RecommendationRepository {
 
 func (r *RecommendationRepository) CreateRecommendation(recommendation *model.Recommendation) error {
 	query := `
-		INSERT INTO recommendations (id, ticker, score, explanation, run_at, rank)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO recommendations (id, ticker, score, explanation, run_at, rank, scoring_config_version, run_id, target_price)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, '')::UUID, $9)
 	`
 
 	_, err := r.GetDB().Exec(query,
@@ -36,45 +43,42 @@ func (r *RecommendationRepository) CreateRecommendation(recommendation *model.Re
 		recommendation.Explanation,
 		recommendation.RunAt,
 		recommendation.Rank,
+		recommendation.ScoringConfigVersion,
+		recommendation.RunID,
+		recommendation.TargetPrice,
 	)
 
 	return err
 }
 
+// GetLatest returns the ranking of the active run.
 func (r *RecommendationRepository) GetLatest(limit int) ([]*model.Recommendation, error) {
 	if limit <= 0 {
 		limit = 10
 	}
 
 	query := `
-		SELECT id, ticker, score, explanation, run_at, rank
+		SELECT ` + recommendationSelectColumns + `
 		FROM recommendations
-		WHERE run_at = (SELECT MAX(run_at) FROM recommendations)
+		WHERE run_id = (` + activeRunQuery + `)
 		ORDER BY rank ASC
 		LIMIT $1
 	`
 
-	rows, err := r.GetDB().Query(query, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	return r.queryRecommendations(query, limit)
+}
 
-	var recommendations []*model.Recommendation
-	for rows.Next() {
-		var rec model.Recommendation
-		err := rows.Scan(
-			&rec.ID,
-			&rec.Ticker,
-			&rec.Score,
-			&rec.Explanation,
-			&rec.RunAt,
-			&rec.Rank,
-		)
-		if err != nil {
-			return nil, err
-		}
-		recommendations = append(recommendations, &rec)
+func (r *RecommendationRepository) GetRecommendationsByRun(runID string) ([]*model.Recommendation, error) {
+	query := `
+		SELECT ` + recommendationSelectColumns + `
+		FROM recommendations
+		WHERE run_id = $1
+		ORDER BY rank ASC
+	`
+
+	recommendations, err := r.queryRecommendations(query, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommendations for run: %w", err)
 	}
 
 	return recommendations, nil
@@ -86,41 +90,18 @@ func (r *RecommendationRepository) GetRecommendationsByDate(date time.Time, limi
 	}
 
 	query := `
-		SELECT id, ticker, score, explanation, run_at, rank
+		SELECT ` + recommendationSelectColumns + `
 		FROM recommendations
 		WHERE DATE(run_at) = DATE($1)
 		ORDER BY rank ASC
 		LIMIT $2
 	`
 
-	rows, err := r.GetDB().Query(query, date, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var recommendations []*model.Recommendation
-	for rows.Next() {
-		var rec model.Recommendation
-		err := rows.Scan(
-			&rec.ID,
-			&rec.Ticker,
-			&rec.Score,
-			&rec.Explanation,
-			&rec.RunAt,
-			&rec.Rank,
-		)
-		if err != nil {
-			return nil, err
-		}
-		recommendations = append(recommendations, &rec)
-	}
-
-	return recommendations, nil
+	return r.queryRecommendations(query, date, limit)
 }
 
 func (r *RecommendationRepository) GetLatestRunAt() (*time.Time, error) {
-	query := `SELECT MAX(run_at) FROM recommendations`
+	query := `SELECT started_at FROM recommendation_runs WHERE is_active`
 
 	var lastRun time.Time
 	err := r.GetDB().QueryRow(query).Scan(&lastRun)
@@ -134,12 +115,36 @@ func (r *RecommendationRepository) GetLatestRunAt() (*time.Time, error) {
 	return &lastRun, nil
 }
 
-func (r *RecommendationRepository) DeleteOldRecommendations(maxAge time.Duration) error {
-	query := `DELETE FROM recommendations WHERE run_at < $1`
-
+// DeleteOldRecommendations removes runs started before maxAge ago together with
+// their recommendations, keeping the run currently served by GetLatest. It
+// returns the number of runs deleted.
+func (r *RecommendationRepository) DeleteOldRecommendations(maxAge time.Duration) (int64, error) {
 	cutoff := time.Now().Add(-maxAge)
-	_, err := r.GetDB().Exec(query, cutoff)
-	return err
+	var deletedRuns int64
+
+	err := r.ExecuteTransaction(func(tx *sql.Tx) error {
+		result, err := tx.Exec(`
+			DELETE FROM recommendation_runs
+			WHERE started_at < $1
+			AND NOT is_active
+		`, cutoff)
+		if err != nil {
+			return fmt.Errorf("failed to delete old recommendation runs: %w", err)
+		}
+
+		deletedRuns, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to count deleted recommendation runs: %w", err)
+		}
+
+		if _, err := tx.Exec(`DELETE FROM recommendations WHERE run_id IS NULL AND run_at < $1`, cutoff); err != nil {
+			return fmt.Errorf("failed to delete old recommendations: %w", err)
+		}
+
+		return nil
+	})
+
+	return deletedRuns, err
 }
 
 func (r *RecommendationRepository) GetRecommendationCount() (int, error) {
@@ -149,3 +154,41 @@ func (r *RecommendationRepository) GetRecommendationCount() (int, error) {
 	err := r.GetDB().QueryRow(query).Scan(&count)
 	return count, err
 }
+
+func (r *RecommendationRepository) queryRecommendations(query string, args ...interface{}) ([]*model.Recommendation, error) {
+	rows, err := r.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recommendations []*model.Recommendation
+	for rows.Next() {
+		rec, err := scanRecommendation(rows)
+		if err != nil {
+			return nil, err
+		}
+		recommendations = append(recommendations, rec)
+	}
+
+	return recommendations, rows.Err()
+}
+
+func scanRecommendation(row rowScanner) (*model.Recommendation, error) {
+	var rec model.Recommendation
+	err := row.Scan(
+		&rec.ID,
+		&rec.RunID,
+		&rec.Ticker,
+		&rec.Score,
+		&rec.Explanation,
+		&rec.RunAt,
+		&rec.Rank,
+		&rec.ScoringConfigVersion,
+		&rec.TargetPrice,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
@@ -27,6 +27,7 @@ func TestRecommendationRepositoryCRUD(t *testing.T) {
 		err := createTestStocksForRecommendations(t, stockRepo, testRecommendations)
 		require.NoError(t, err)
 
+		attachCompletedRun(t, repo, testRecommendations)
 		err = command.BulkCreate(testRecommendations)
 		require.NoError(t, err)
 
@@ -55,6 +56,7 @@ func TestRecommendationRepositoryCRUD(t *testing.T) {
 		err := createTestStocksForRecommendations(t, stockRepo, uniqueRecommendations)
 		require.NoError(t, err)
 
+		attachCompletedRun(t, repo, uniqueRecommendations)
 		err = command.BulkCreate(uniqueRecommendations)
 		require.NoError(t, err)
 
@@ -75,6 +77,7 @@ func TestRecommendationRepositoryCRUD(t *testing.T) {
 
 	t.Run("GetLatestRunAt", func(t *testing.T) {
 		cleanupRecommendationTest(t, repo, now)
+		attachCompletedRun(t, repo, testRecommendations)
 		err := command.BulkCreate(testRecommendations)
 		require.NoError(t, err)
 
@@ -88,6 +91,8 @@ func TestRecommendationRepositoryCRUD(t *testing.T) {
 	t.Run("Empty Recommendations", func(t *testing.T) {
 		_, err := repo.GetDB().Exec("DELETE FROM recommendations")
 		require.NoError(t, err)
+		_, err = repo.GetDB().Exec("DELETE FROM recommendation_runs")
+		require.NoError(t, err)
 
 		var count int
 		err = repo.GetDB().QueryRow("SELECT COUNT(*) FROM recommendations").Scan(&count)
@@ -95,7 +100,7 @@ func TestRecommendationRepositoryCRUD(t *testing.T) {
 		require.Equal(t, 0, count, "Database should be empty")
 
 		latestRunAt, err := repo.GetLatestRunAt()
-		require.Error(t, err)
+		require.NoError(t, err)
 		assert.Nil(t, latestRunAt)
 
 		recommendations, err := repo.GetLatest(10)
@@ -130,6 +135,7 @@ func TestRecommendationRepositoryIntegration(t *testing.T) {
 		err := createTestStocksForRecommendations(t, stockRepo, recommendations1)
 		require.NoError(t, err)
 
+		attachCompletedRun(t, repo, recommendations1)
 		err = command.BulkCreate(recommendations1)
 		require.NoError(t, err)
 
@@ -142,6 +148,7 @@ func TestRecommendationRepositoryIntegration(t *testing.T) {
 		err = createTestStocksForRecommendations(t, stockRepo, recommendations2)
 		require.NoError(t, err)
 
+		attachCompletedRun(t, repo, recommendations2)
 		err = command.BulkCreate(recommendations2)
 		require.NoError(t, err)
 
@@ -162,6 +169,7 @@ func TestRecommendationRepositoryIntegration(t *testing.T) {
 		err := createTestStocksForRecommendations(t, stockRepo, recommendations)
 		require.NoError(t, err)
 
+		attachCompletedRun(t, repo, recommendations)
 		err = command.BulkCreate(recommendations)
 		require.NoError(t, err)
 
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+const recommendationRunSelectColumns = `id::TEXT, status, strategy, COALESCE(scoring_config_version, ''), params,
+	started_at, finished_at, recommendation_count, COALESCE(error_message, ''), is_active`
+
+type RecommendationRunRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.RecommendationRunRepository = (*RecommendationRunRepository)(nil)
+
+func NewRecommendationRunRepository(db *sql.DB) *RecommendationRunRepository {
+	return &RecommendationRunRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *RecommendationRunRepository) CreateRun(run *model.RecommendationRun) error {
+	query := `
+		INSERT INTO recommendation_runs (id, status, strategy, scoring_config_version, params, started_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+	`
+
+	params := []byte(run.Params)
+	if len(params) == 0 {
+		params = []byte("{}")
+	}
+
+	_, err := r.GetDB().Exec(query,
+		run.ID,
+		run.Status,
+		run.Strategy,
+		run.ScoringConfigVersion,
+		params,
+		run.StartedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create recommendation run: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecommendationRunRepository) FinishRun(runID string, status model.RecommendationRunStatus, recommendationCount int, errorMessage string) error {
+	query := `
+		UPDATE recommendation_runs
+		SET status = $2, finished_at = now(), recommendation_count = $3, error_message = NULLIF($4, '')
+		WHERE id = $1
+	`
+
+	if _, err := r.GetDB().Exec(query, runID, status, recommendationCount, errorMessage); err != nil {
+		return fmt.Errorf("failed to finish recommendation run: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecommendationRunRepository) GetRuns(limit, offset int) ([]*model.RecommendationRun, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT ` + recommendationRunSelectColumns + `
+		FROM recommendation_runs
+		ORDER BY started_at DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.GetDB().Query(query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recommendation runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []*model.RecommendationRun
+	for rows.Next() {
+		run, err := scanRecommendationRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recommendation run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
+
+func (r *RecommendationRunRepository) GetRunsCount() (int, error) {
+	var count int
+	if err := r.GetDB().QueryRow(`SELECT COUNT(*) FROM recommendation_runs`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count recommendation runs: %w", err)
+	}
+	return count, nil
+}
+
+func (r *RecommendationRunRepository) GetRunByID(runID string) (*model.RecommendationRun, error) {
+	query := `SELECT ` + recommendationRunSelectColumns + ` FROM recommendation_runs WHERE id = $1`
+
+	run, err := scanRecommendationRun(r.GetDB().QueryRow(query, runID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get recommendation run: %w", err)
+	}
+
+	return run, nil
+}
+
+// GetLastCompletedRunTime returns when the latest completed run started, or
+// nil when no run has completed. Completed runs are the published ones.
+func (r *RecommendationRunRepository) GetLastCompletedRunTime() (*time.Time, error) {
+	query := `SELECT MAX(started_at) FROM recommendation_runs WHERE status = $1`
+
+	var startedAt sql.NullTime
+	if err := r.GetDB().QueryRow(query, model.RecommendationRunStatusCompleted).Scan(&startedAt); err != nil {
+		return nil, fmt.Errorf("failed to get last completed recommendation run: %w", err)
+	}
+	if !startedAt.Valid {
+		return nil, nil
+	}
+
+	return &startedAt.Time, nil
+}
+
+func scanRecommendationRun(row rowScanner) (*model.RecommendationRun, error) {
+	var run model.RecommendationRun
+	var params []byte
+	var finishedAt sql.NullTime
+
+	err := row.Scan(
+		&run.ID,
+		&run.Status,
+		&run.Strategy,
+		&run.ScoringConfigVersion,
+		&params,
+		&run.StartedAt,
+		&finishedAt,
+		&run.RecommendationCount,
+		&run.ErrorMessage,
+		&run.IsActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Params = params
+	if finishedAt.Valid {
+		run.FinishedAt = &finishedAt.Time
+	}
+
+	return &run, nil
+}
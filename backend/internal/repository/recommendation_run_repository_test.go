@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestRecommendationRunRepository(t *testing.T) {
+	testCfg := config.LoadTestConfig()
+	if !testCfg.HasTestDatabase() {
+		t.Skip("DATABASE_URL_TEST not set, skipping integration test")
+	}
+
+	err := connectToTestDatabase()
+	require.NoError(t, err)
+	defer database.Close()
+
+	runRepo := NewRecommendationRunRepository(database.DB)
+	recRepo := NewRecommendationRepository(database.DB)
+	stockRepo := NewStockRepository(database.DB)
+	command := NewRecommendationCommand(database.DB, stockRepo)
+
+	_, err = database.DB.Exec("DELETE FROM recommendation_runs")
+	require.NoError(t, err)
+
+	t.Run("Create, Finish and Get", func(t *testing.T) {
+		run := &model.RecommendationRun{
+			ID:        uuid.New().String(),
+			Status:    model.RecommendationRunStatusRunning,
+			Strategy:  "additive",
+			Params:    []byte(`{"days_back": 7}`),
+			StartedAt: time.Now().UTC(),
+		}
+		require.NoError(t, runRepo.CreateRun(run))
+		require.NoError(t, runRepo.FinishRun(run.ID, model.RecommendationRunStatusCompleted, 3, ""))
+
+		stored, err := runRepo.GetRunByID(run.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, model.RecommendationRunStatusCompleted, stored.Status)
+		assert.Equal(t, 3, stored.RecommendationCount)
+		assert.NotNil(t, stored.FinishedAt)
+		assert.JSONEq(t, `{"days_back": 7}`, string(stored.Params))
+
+		missing, err := runRepo.GetRunByID(uuid.New().String())
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("PublishRun swaps the active run", func(t *testing.T) {
+		first := &model.RecommendationRun{
+			ID:        uuid.New().String(),
+			Status:    model.RecommendationRunStatusRunning,
+			Strategy:  "additive",
+			StartedAt: time.Now().UTC(),
+		}
+		first.Recommendations = createTestRecommendationsWithCustomData([]string{"RUN1"}, []float64{90}, first.StartedAt)
+		require.NoError(t, createTestStocksForRecommendations(t, stockRepo, first.Recommendations))
+		first.Recommendations[0].RunID = first.ID
+
+		require.NoError(t, runRepo.CreateRun(first))
+		require.NoError(t, command.PublishRun(first))
+
+		second := &model.RecommendationRun{
+			ID:        uuid.New().String(),
+			Status:    model.RecommendationRunStatusRunning,
+			Strategy:  "consensus",
+			StartedAt: time.Now().UTC(),
+		}
+		require.NoError(t, runRepo.CreateRun(second))
+
+		// A running run is not served until it is published
+		latest, err := recRepo.GetLatest(10)
+		require.NoError(t, err)
+		require.Len(t, latest, 1)
+		assert.Equal(t, first.ID, latest[0].RunID)
+
+		require.NoError(t, command.PublishRun(second))
+
+		latest, err = recRepo.GetLatest(10)
+		require.NoError(t, err)
+		assert.Empty(t, latest)
+
+		stored, err := runRepo.GetRunByID(first.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.IsActive)
+
+		stored, err = runRepo.GetRunByID(second.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.IsActive)
+		assert.Equal(t, model.RecommendationRunStatusCompleted, stored.Status)
+
+		assert.Error(t, command.PublishRun(&model.RecommendationRun{ID: uuid.New().String()}))
+	})
+
+	t.Run("Retention keeps the active run", func(t *testing.T) {
+		old := &model.RecommendationRun{
+			ID:        uuid.New().String(),
+			Status:    model.RecommendationRunStatusRunning,
+			Strategy:  "additive",
+			StartedAt: time.Now().UTC().AddDate(0, 0, -60),
+		}
+		require.NoError(t, runRepo.CreateRun(old))
+		require.NoError(t, runRepo.FinishRun(old.ID, model.RecommendationRunStatusFailed, 0, "boom"))
+
+		deleted, err := recRepo.DeleteOldRecommendations(30 * 24 * time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		count, err := runRepo.GetRunsCount()
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		_, err = database.DB.Exec("UPDATE recommendation_runs SET started_at = started_at - INTERVAL '90 days'")
+		require.NoError(t, err)
+
+		deleted, err = recRepo.DeleteOldRecommendations(30 * 24 * time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), deleted)
+
+		runs, err := runRepo.GetRuns(10, 0)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.True(t, runs[0].IsActive)
+	})
+}
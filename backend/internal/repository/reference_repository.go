@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+type ReferenceRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.ReferenceRepository = (*ReferenceRepository)(nil)
+
+func NewReferenceRepository(db *sql.DB) *ReferenceRepository {
+	return &ReferenceRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *ReferenceRepository) GetBrokerages() ([]*model.Brokerage, error) {
+	query := `SELECT id, name, created_at FROM brokerages ORDER BY name ASC`
+
+	rows, err := r.GetDB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brokerages: %w", err)
+	}
+	defer rows.Close()
+
+	var brokerages []*model.Brokerage
+	for rows.Next() {
+		var brokerage model.Brokerage
+		if err := rows.Scan(&brokerage.ID, &brokerage.Name, &brokerage.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan brokerage: %w", err)
+		}
+		brokerages = append(brokerages, &brokerage)
+	}
+
+	return brokerages, nil
+}
+
+func (r *ReferenceRepository) GetRatings() ([]*model.Rating, error) {
+	query := `SELECT id, name, rank, created_at FROM ratings ORDER BY rank DESC, name ASC`
+
+	rows, err := r.GetDB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []*model.Rating
+	for rows.Next() {
+		var rating model.Rating
+		if err := rows.Scan(&rating.ID, &rating.Name, &rating.Rank, &rating.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		ratings = append(ratings, &rating)
+	}
+
+	return ratings, nil
+}
+
+func (r *ReferenceRepository) GetActions() ([]*model.Action, error) {
+	query := `SELECT id, name, created_at FROM actions ORDER BY name ASC`
+
+	rows, err := r.GetDB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get actions: %w", err)
+	}
+	defer rows.Close()
+
+	var actions []*model.Action
+	for rows.Next() {
+		var action model.Action
+		if err := rows.Scan(&action.ID, &action.Name, &action.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan action: %w", err)
+		}
+		actions = append(actions, &action)
+	}
+
+	return actions, nil
+}
+
+func (r *ReferenceRepository) GetAliases() ([]*model.ReferenceAlias, error) {
+	query := `SELECT kind, alias, canonical_name FROM reference_aliases`
+
+	rows, err := r.GetDB().Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reference aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []*model.ReferenceAlias
+	for rows.Next() {
+		var alias model.ReferenceAlias
+		if err := rows.Scan(&alias.Kind, &alias.Alias, &alias.CanonicalName); err != nil {
+			return nil, fmt.Errorf("failed to scan reference alias: %w", err)
+		}
+		aliases = append(aliases, &alias)
+	}
+
+	return aliases, nil
+}
+
+// FindBrokerage returns the brokerage whose name or brokerage alias
+// normalizes to key, as model.NormalizeReferenceValue does, or nil.
+func (r *ReferenceRepository) FindBrokerage(key string) (*model.Brokerage, error) {
+	query := `
+		SELECT b.id, b.name, b.created_at
+		FROM brokerages b
+		WHERE lower(regexp_replace(btrim(b.name), '\s+', ' ', 'g')) = $1
+		   OR lower(regexp_replace(btrim(b.name), '\s+', ' ', 'g')) IN (
+			SELECT lower(regexp_replace(btrim(a.canonical_name), '\s+', ' ', 'g'))
+			FROM reference_aliases a
+			WHERE a.kind = $2 AND lower(regexp_replace(btrim(a.alias), '\s+', ' ', 'g')) = $1
+		   )
+		ORDER BY b.id
+		LIMIT 1
+	`
+
+	var brokerage model.Brokerage
+	err := r.GetDB().QueryRow(query, key, model.ReferenceKindBrokerage).Scan(&brokerage.ID, &brokerage.Name, &brokerage.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find brokerage: %w", err)
+	}
+
+	return &brokerage, nil
+}
+
+func (r *ReferenceRepository) CreateBrokerage(name string) (*model.Brokerage, error) {
+	query := `
+		INSERT INTO brokerages (name)
+		VALUES ($1)
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id, name, created_at
+	`
+
+	var brokerage model.Brokerage
+	err := r.GetDB().QueryRow(query, name).Scan(&brokerage.ID, &brokerage.Name, &brokerage.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create brokerage: %w", err)
+	}
+
+	return &brokerage, nil
+}
+
+func (r *ReferenceRepository) RecordUnmapped(kind model.ReferenceKind, rawValues map[string]int) error {
+	if len(rawValues) == 0 {
+		return nil
+	}
+
+	return r.ExecuteTransaction(func(tx *sql.Tx) error {
+		query := `
+			INSERT INTO unmapped_reference_values (kind, raw_value, occurrences, first_seen_at, last_seen_at)
+			VALUES ($1, $2, $3, $4, $4)
+			ON CONFLICT (kind, raw_value) DO UPDATE SET
+				occurrences = unmapped_reference_values.occurrences + EXCLUDED.occurrences,
+				last_seen_at = EXCLUDED.last_seen_at
+		`
+
+		stmt, err := tx.Prepare(query)
+		if err != nil {
+			return fmt.Errorf("failed to prepare statement: %w", err)
+		}
+		defer stmt.Close()
+
+		now := time.Now()
+		for rawValue, occurrences := range rawValues {
+			if _, err := stmt.Exec(kind, rawValue, occurrences, now); err != nil {
+				return fmt.Errorf("failed to record unmapped %s %q: %w", kind, rawValue, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *ReferenceRepository) GetUnmapped(kind model.ReferenceKind, limit, offset int) ([]*model.UnmappedReferenceValue, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	qb := NewQueryBuilder().
+		Select("kind", "raw_value", "occurrences", "first_seen_at", "last_seen_at").
+		From("unmapped_reference_values").
+		Where("kind = ?", string(kind)).
+		OrderBy("occurrences", "DESC").
+		Limit(limit).
+		Offset(offset)
+
+	query, args := qb.Build()
+
+	rows, err := r.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unmapped reference values: %w", err)
+	}
+	defer rows.Close()
+
+	var values []*model.UnmappedReferenceValue
+	for rows.Next() {
+		var value model.UnmappedReferenceValue
+		err := rows.Scan(&value.Kind, &value.RawValue, &value.Occurrences, &value.FirstSeenAt, &value.LastSeenAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan unmapped reference value: %w", err)
+		}
+		values = append(values, &value)
+	}
+
+	return values, nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+const scoringConfigSelectColumns = `id, version, COALESCE(description, ''), config, is_active, created_at, activated_at`
+
+type ScoringConfigRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.ScoringConfigRepository = (*ScoringConfigRepository)(nil)
+
+func NewScoringConfigRepository(db *sql.DB) *ScoringConfigRepository {
+	return &ScoringConfigRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *ScoringConfigRepository) ListConfigs(limit, offset int) ([]*model.ScoringConfigVersion, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `
+		SELECT ` + scoringConfigSelectColumns + `
+		FROM scoring_configs
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.GetDB().Query(query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scoring configs: %w", err)
+	}
+	defer rows.Close()
+
+	var configs []*model.ScoringConfigVersion
+	for rows.Next() {
+		config, err := scanScoringConfig(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan scoring config: %w", err)
+		}
+		configs = append(configs, config)
+	}
+
+	return configs, nil
+}
+
+func (r *ScoringConfigRepository) CountConfigs() (int, error) {
+	var count int
+	if err := r.GetDB().QueryRow(`SELECT COUNT(*) FROM scoring_configs`).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count scoring configs: %w", err)
+	}
+	return count, nil
+}
+
+func (r *ScoringConfigRepository) GetByVersion(version string) (*model.ScoringConfigVersion, error) {
+	query := `SELECT ` + scoringConfigSelectColumns + ` FROM scoring_configs WHERE version = $1`
+
+	config, err := scanScoringConfig(r.GetDB().QueryRow(query, version))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get scoring config: %w", err)
+	}
+
+	return config, nil
+}
+
+func (r *ScoringConfigRepository) GetActive() (*model.ScoringConfigVersion, error) {
+	query := `SELECT ` + scoringConfigSelectColumns + ` FROM scoring_configs WHERE is_active = true LIMIT 1`
+
+	config, err := scanScoringConfig(r.GetDB().QueryRow(query))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get active scoring config: %w", err)
+	}
+
+	return config, nil
+}
+
+func (r *ScoringConfigRepository) CreateConfig(config *model.ScoringConfigVersion) error {
+	query := `
+		INSERT INTO scoring_configs (version, description, config)
+		VALUES ($1, $2, $3)
+		RETURNING id, is_active, created_at
+	`
+
+	err := r.GetDB().QueryRow(query, config.Version, config.Description, []byte(config.Config)).
+		Scan(&config.ID, &config.IsActive, &config.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create scoring config: %w", err)
+	}
+
+	return nil
+}
+
+// ActivateConfig makes version the only active config. It reports false when
+// the version does not exist, leaving the current active config untouched.
+func (r *ScoringConfigRepository) ActivateConfig(version string) (bool, error) {
+	activated := false
+
+	err := r.ExecuteTransaction(func(tx *sql.Tx) error {
+		var id int64
+		err := tx.QueryRow(`SELECT id FROM scoring_configs WHERE version = $1`, version).Scan(&id)
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find scoring config: %w", err)
+		}
+
+		if _, err := tx.Exec(`UPDATE scoring_configs SET is_active = false WHERE is_active = true AND id <> $1`, id); err != nil {
+			return fmt.Errorf("failed to deactivate scoring configs: %w", err)
+		}
+
+		if _, err := tx.Exec(`UPDATE scoring_configs SET is_active = true, activated_at = now() WHERE id = $1`, id); err != nil {
+			return fmt.Errorf("failed to activate scoring config: %w", err)
+		}
+
+		activated = true
+		return nil
+	})
+
+	return activated, err
+}
+
+func scanScoringConfig(row rowScanner) (*model.ScoringConfigVersion, error) {
+	var config model.ScoringConfigVersion
+	var rawConfig []byte
+	var activatedAt sql.NullTime
+
+	err := row.Scan(
+		&config.ID,
+		&config.Version,
+		&config.Description,
+		&rawConfig,
+		&config.IsActive,
+		&config.CreatedAt,
+		&activatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Config = rawConfig
+	if activatedAt.Valid {
+		config.ActivatedAt = &activatedAt.Time
+	}
+
+	return &config, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestScoringConfigRepository(t *testing.T) {
+	testCfg := config.LoadTestConfig()
+	if !testCfg.HasTestDatabase() {
+		t.Skip("DATABASE_URL_TEST not set, skipping integration test")
+	}
+
+	err := connectToTestDatabase()
+	require.NoError(t, err)
+	defer database.Close()
+
+	repo := NewScoringConfigRepository(database.DB)
+
+	rawConfig, err := json.Marshal(model.DefaultScoringConfig())
+	require.NoError(t, err)
+
+	t.Run("Create and Get", func(t *testing.T) {
+		config := &model.ScoringConfigVersion{Version: "v1", Description: "baseline", Config: rawConfig}
+		require.NoError(t, repo.CreateConfig(config))
+		assert.NotZero(t, config.ID)
+		assert.False(t, config.IsActive)
+
+		stored, err := repo.GetByVersion("v1")
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, "baseline", stored.Description)
+		assert.JSONEq(t, string(rawConfig), string(stored.Config))
+
+		missing, err := repo.GetByVersion("missing")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("Activate switches the single active version", func(t *testing.T) {
+		require.NoError(t, repo.CreateConfig(&model.ScoringConfigVersion{Version: "v2", Config: rawConfig}))
+
+		active, err := repo.GetActive()
+		require.NoError(t, err)
+		assert.Nil(t, active)
+
+		activated, err := repo.ActivateConfig("v1")
+		require.NoError(t, err)
+		assert.True(t, activated)
+
+		activated, err = repo.ActivateConfig("v2")
+		require.NoError(t, err)
+		assert.True(t, activated)
+
+		active, err = repo.GetActive()
+		require.NoError(t, err)
+		require.NotNil(t, active)
+		assert.Equal(t, "v2", active.Version)
+		assert.NotNil(t, active.ActivatedAt)
+
+		activated, err = repo.ActivateConfig("missing")
+		require.NoError(t, err)
+		assert.False(t, activated)
+
+		active, err = repo.GetActive()
+		require.NoError(t, err)
+		assert.Equal(t, "v2", active.Version)
+	})
+
+	t.Run("List and Count", func(t *testing.T) {
+		configs, err := repo.ListConfigs(10, 0)
+		require.NoError(t, err)
+		assert.Len(t, configs, 2)
+
+		count, err := repo.CountConfigs()
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+}
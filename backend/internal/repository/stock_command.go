@@ -2,16 +2,61 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
+	"github.com/lib/pq"
 	"github.com/valeriapadilla/stock-insights/internal/model"
 	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
 	"github.com/valeriapadilla/stock-insights/internal/validator"
 )
 
+const stockInsertQuery = `
+		INSERT INTO stocks (ticker, company, target_from, target_to, rating_from, rating_to, action, brokerage, time, created_at, updated_at,
+			brokerage_id, rating_from_id, rating_to_id, action_id, target_from_price, target_to_price, source)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+
+// stockUpsertQuery replaces an event already stored for the same ticker and
+// time unless it came from a source ranked higher in $19 or nothing changed,
+// in which case no row is affected. Sources missing from the precedence list
+// rank below every listed source.
+const stockUpsertQuery = `
+		INSERT INTO stocks (ticker, company, target_from, target_to, rating_from, rating_to, action, brokerage, time, created_at, updated_at,
+			brokerage_id, rating_from_id, rating_to_id, action_id, target_from_price, target_to_price, source)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		ON CONFLICT (ticker, time) DO UPDATE SET
+			company = EXCLUDED.company,
+			target_from = EXCLUDED.target_from,
+			target_to = EXCLUDED.target_to,
+			rating_from = EXCLUDED.rating_from,
+			rating_to = EXCLUDED.rating_to,
+			action = EXCLUDED.action,
+			brokerage = EXCLUDED.brokerage,
+			updated_at = EXCLUDED.updated_at,
+			brokerage_id = EXCLUDED.brokerage_id,
+			rating_from_id = EXCLUDED.rating_from_id,
+			rating_to_id = EXCLUDED.rating_to_id,
+			action_id = EXCLUDED.action_id,
+			target_from_price = EXCLUDED.target_from_price,
+			target_to_price = EXCLUDED.target_to_price,
+			source = EXCLUDED.source
+		WHERE (stocks.source = EXCLUDED.source
+			OR COALESCE(array_position($19::TEXT[], EXCLUDED.source), 2147483647)
+				<= COALESCE(array_position($19::TEXT[], stocks.source), 2147483647))
+			AND (stocks.company, stocks.target_from, stocks.target_to, stocks.rating_from, stocks.rating_to,
+				stocks.action, stocks.brokerage, stocks.brokerage_id, stocks.rating_from_id, stocks.rating_to_id,
+				stocks.action_id, stocks.target_from_price, stocks.target_to_price, stocks.source)
+			IS DISTINCT FROM (EXCLUDED.company, EXCLUDED.target_from, EXCLUDED.target_to, EXCLUDED.rating_from, EXCLUDED.rating_to,
+				EXCLUDED.action, EXCLUDED.brokerage, EXCLUDED.brokerage_id, EXCLUDED.rating_from_id, EXCLUDED.rating_to_id,
+				EXCLUDED.action_id, EXCLUDED.target_from_price, EXCLUDED.target_to_price, EXCLUDED.source)
+	`
+
 type StockCommandImpl struct {
 	*BaseRepository
-	validator *validator.CommonValidator
+	validator        *validator.CommonValidator
+	sourcePrecedence []string
 }
 
 var _ interfaces.StockCommand = (*StockCommandImpl)(nil)
@@ -23,19 +68,23 @@ func NewStockCommand(db *sql.DB) *StockCommandImpl {
 	}
 }
 
+// SetSourcePrecedence ranks ingestion sources, highest first. When two sources
+// report the same event, the higher-ranked one is kept.
+func (c *StockCommandImpl) SetSourcePrecedence(precedence []string) {
+	c.sourcePrecedence = precedence
+}
+
 func (c *StockCommandImpl) Create(stock *model.Stock) error {
 	if err := c.validateStock(stock); err != nil {
 		return fmt.Errorf("stock validation failed: %w", err)
 	}
 
-	query := `
-		INSERT INTO stocks (ticker, company, target_from, target_to, rating_from, rating_to, action, brokerage, time, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-	`
-	_, err := c.GetDB().Exec(query,
+	_, err := c.GetDB().Exec(stockInsertQuery,
 		stock.Ticker, stock.Company, stock.TargetFrom, stock.TargetTo,
 		stock.RatingFrom, stock.RatingTo, stock.Action, stock.Brokerage, stock.Time,
 		stock.CreatedAt, stock.UpdatedAt,
+		stock.BrokerageID, stock.RatingFromID, stock.RatingToID, stock.ActionID,
+		stock.TargetFromPrice, stock.TargetToPrice, stock.SourceName(),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create stock: %w", err)
@@ -55,12 +104,7 @@ func (c *StockCommandImpl) BulkCreate(stocks []*model.Stock) error {
 	}
 	defer tx.Rollback()
 
-	query := `
-		INSERT INTO stocks (ticker, company, target_from, target_to, rating_from, rating_to, action, brokerage, time, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-	`
-
-	stmt, err := tx.Prepare(query)
+	stmt, err := tx.Prepare(stockInsertQuery)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
@@ -75,6 +119,8 @@ func (c *StockCommandImpl) BulkCreate(stocks []*model.Stock) error {
 			stock.Ticker, stock.Company, stock.TargetFrom, stock.TargetTo,
 			stock.RatingFrom, stock.RatingTo, stock.Action, stock.Brokerage, stock.Time,
 			stock.CreatedAt, stock.UpdatedAt,
+			stock.BrokerageID, stock.RatingFromID, stock.RatingToID, stock.ActionID,
+			stock.TargetFromPrice, stock.TargetToPrice, stock.SourceName(),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to insert stock %s: %w", stock.Ticker, err)
@@ -93,94 +139,212 @@ func (c *StockCommandImpl) Upsert(stock *model.Stock) error {
 		return fmt.Errorf("stock validation failed: %w", err)
 	}
 
-	query := `
-		INSERT INTO stocks (ticker, company, target_from, target_to, rating_from, rating_to, action, brokerage, time, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		ON CONFLICT (ticker, time) DO UPDATE SET
-			company = EXCLUDED.company,
-			target_from = EXCLUDED.target_from,
-			target_to = EXCLUDED.target_to,
-			rating_from = EXCLUDED.rating_from,
-			rating_to = EXCLUDED.rating_to,
-			action = EXCLUDED.action,
-			brokerage = EXCLUDED.brokerage,
-			updated_at = EXCLUDED.updated_at
-	`
+	return c.upsertValid([]*model.Stock{stock}, &model.BulkUpsertResult{}, true)
+}
 
-	_, err := c.GetDB().Exec(query,
-		stock.Ticker, stock.Company, stock.TargetFrom, stock.TargetTo,
-		stock.RatingFrom, stock.RatingTo, stock.Action, stock.Brokerage, stock.Time,
-		stock.CreatedAt, stock.UpdatedAt,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to upsert stock: %w", err)
-	}
+// BulkUpsert stores stocks in one transaction and reports which events were
+// inserted, updated, left unchanged or rejected by validation. A database
+// error rolls back the whole batch.
+func (c *StockCommandImpl) BulkUpsert(stocks []*model.Stock) (*model.BulkUpsertResult, error) {
+	return c.bulkUpsert(stocks, true)
+}
 
-	return nil
+// PreviewBulkUpsert runs BulkUpsert in a transaction that is rolled back, so
+// the result tells what storing stocks would do without changing anything.
+func (c *StockCommandImpl) PreviewBulkUpsert(stocks []*model.Stock) (*model.BulkUpsertResult, error) {
+	return c.bulkUpsert(stocks, false)
 }
 
-func (c *StockCommandImpl) BulkUpsert(stocks []*model.Stock) error {
+func (c *StockCommandImpl) bulkUpsert(stocks []*model.Stock, commit bool) (*model.BulkUpsertResult, error) {
+	result := &model.BulkUpsertResult{}
 	if len(stocks) == 0 {
-		return nil
+		return result, nil
+	}
+
+	valid := make([]*model.Stock, 0, len(stocks))
+	for _, stock := range stocks {
+		var received model.StockEvent
+		if stock != nil {
+			received = model.NewStockEvent(stock)
+		}
+		if err := c.validateStock(stock); err != nil {
+			rejection := model.StockRejection{Ticker: received.Ticker, Time: received.Time, Reason: err.Error()}
+			if stock != nil {
+				rejection.Payload, _ = json.Marshal(received)
+			}
+			result.Rejections = append(result.Rejections, rejection)
+			result.Rejected++
+			continue
+		}
+		valid = append(valid, stock)
+	}
+	if len(valid) == 0 {
+		return result, nil
+	}
+
+	if err := c.upsertValid(valid, result, commit); err != nil {
+		return nil, err
 	}
 
+	return result, nil
+}
+
+// upsertValid upserts validated stocks in one transaction, counting them into
+// result. Every update of an existing event is recorded in stock_revisions
+// with the fields it changed. Without commit the transaction is rolled back.
+func (c *StockCommandImpl) upsertValid(stocks []*model.Stock, result *model.BulkUpsertResult, commit bool) error {
 	tx, err := c.GetDB().Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	query := `
-		INSERT INTO stocks (ticker, company, target_from, target_to, rating_from, rating_to, action, brokerage, time, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-		ON CONFLICT (ticker, time) DO UPDATE SET
-			company = EXCLUDED.company,
-			target_from = EXCLUDED.target_from,
-			target_to = EXCLUDED.target_to,
-			rating_from = EXCLUDED.rating_from,
-			rating_to = EXCLUDED.rating_to,
-			action = EXCLUDED.action,
-			brokerage = EXCLUDED.brokerage,
-			updated_at = EXCLUDED.updated_at
-	`
+	existing, err := existingStocks(tx, stocks)
+	if err != nil {
+		return err
+	}
 
-	stmt, err := tx.Prepare(query)
+	stmt, err := tx.Prepare(stockUpsertQuery)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
 	}
 	defer stmt.Close()
 
-	processed := 0
-	errors := 0
-
+	now := time.Now()
+	var revisions []*model.StockRevision
 	for _, stock := range stocks {
-		if err := c.validateStock(stock); err != nil {
-			errors++
-			continue
-		}
-		_, err := stmt.Exec(
+		res, err := stmt.Exec(
 			stock.Ticker, stock.Company, stock.TargetFrom, stock.TargetTo,
 			stock.RatingFrom, stock.RatingTo, stock.Action, stock.Brokerage, stock.Time,
 			stock.CreatedAt, stock.UpdatedAt,
+			stock.BrokerageID, stock.RatingFromID, stock.RatingToID, stock.ActionID,
+			stock.TargetFromPrice, stock.TargetToPrice, stock.SourceName(),
+			pq.Array(c.sourcePrecedence),
 		)
 		if err != nil {
-			errors++
+			return fmt.Errorf("failed to upsert stock %s: %w", stock.Ticker, err)
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to read upsert result for %s: %w", stock.Ticker, err)
+		}
+
+		key := stockKey(stock.Ticker, stock.Time)
+		previous, found := existing[key]
+		switch {
+		case affected == 0:
+			result.Unchanged++
 			continue
+		case found:
+			result.Updated++
+			if changes := model.DiffStocks(previous, stock); len(changes) > 0 {
+				revisions = append(revisions, &model.StockRevision{
+					Ticker:    stock.Ticker,
+					EventTime: stock.Time,
+					Source:    stock.SourceName(),
+					Changes:   changes,
+					RevisedAt: now,
+				})
+			}
+		default:
+			result.Inserted++
 		}
+		existing[key] = stock
+	}
 
-		processed++
+	if err := insertStockRevisions(tx, revisions); err != nil {
+		return err
+	}
+
+	if !commit {
+		return nil
 	}
 
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// Log the results
-	fmt.Printf("BulkUpsert completed: processed=%d, errors=%d, total=%d\n", processed, errors, len(stocks))
+	return nil
+}
+
+// existingStocks returns the stored versions of stocks keyed by ticker and
+// time, locking exactly those rows, in key order, until the transaction ends.
+func existingStocks(tx *sql.Tx, stocks []*model.Stock) (map[string]*model.Stock, error) {
+	tickers := make([]string, 0, len(stocks))
+	times := make([]string, 0, len(stocks))
+	for _, stock := range stocks {
+		tickers = append(tickers, stock.Ticker)
+		times = append(times, stock.Time.Format(time.RFC3339Nano))
+	}
+
+	rows, err := tx.Query(`
+		SELECT ticker, time, company, COALESCE(target_from, ''), COALESCE(target_to, ''),
+			COALESCE(rating_from, ''), COALESCE(rating_to, ''), COALESCE(action, ''), COALESCE(brokerage, ''),
+			target_from_price, target_to_price, source
+		FROM stocks
+		WHERE (ticker, time) IN (SELECT * FROM unnest($1::TEXT[], $2::TIMESTAMPTZ[]))
+		ORDER BY ticker, time
+		FOR UPDATE
+	`, pq.Array(tickers), pq.Array(times))
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up existing stocks: %w", err)
+	}
+	defer rows.Close()
+
+	existing := make(map[string]*model.Stock)
+	for rows.Next() {
+		var stock model.Stock
+		if err := rows.Scan(
+			&stock.Ticker, &stock.Time, &stock.Company, &stock.TargetFrom, &stock.TargetTo,
+			&stock.RatingFrom, &stock.RatingTo, &stock.Action, &stock.Brokerage,
+			&stock.TargetFromPrice, &stock.TargetToPrice, &stock.Source,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan existing stock: %w", err)
+		}
+		existing[stockKey(stock.Ticker, stock.Time)] = &stock
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to look up existing stocks: %w", err)
+	}
+
+	return existing, nil
+}
+
+func insertStockRevisions(tx *sql.Tx, revisions []*model.StockRevision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO stock_revisions (ticker, event_time, source, changes, revised_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare revision statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, revision := range revisions {
+		changes, err := json.Marshal(revision.Changes)
+		if err != nil {
+			return fmt.Errorf("failed to encode revision of %s: %w", revision.Ticker, err)
+		}
+
+		if _, err := stmt.Exec(revision.Ticker, revision.EventTime, revision.Source, changes, revision.RevisedAt); err != nil {
+			return fmt.Errorf("failed to record revision of %s: %w", revision.Ticker, err)
+		}
+	}
 
 	return nil
 }
 
+// stockKey identifies an event by ticker and time at the database's
+// microsecond precision.
+func stockKey(ticker string, eventTime time.Time) string {
+	return ticker + "@" + eventTime.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)
+}
+
 func (c *StockCommandImpl) validateStock(stock *model.Stock) error {
 	if stock == nil {
 		return fmt.Errorf("stock cannot be nil")
@@ -202,6 +366,10 @@ func (c *StockCommandImpl) validateStock(stock *model.Stock) error {
 	stock.Action = c.validator.SanitizeString(stock.Action)
 	stock.Brokerage = c.validator.SanitizeString(stock.Brokerage)
 
+	if stock.TargetFromPrice == nil && stock.TargetToPrice == nil {
+		stock.PopulateTargetPrices()
+	}
+
 	if len(stock.Ticker) > 10 {
 		return fmt.Errorf("ticker too long (max 10 characters)")
 	}
@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+const stockRejectSelectColumns = `id::TEXT, COALESCE(run_id::TEXT, ''), source, ticker, event_time, payload,
+	error_message, status, created_at, resolved_at`
+
+// stockRejectFilterClause matches $1 status, $2 source and $3 run id, each
+// ignored when empty.
+const stockRejectFilterClause = `
+		WHERE ($1::TEXT = '' OR status = $1)
+			AND ($2::TEXT = '' OR source = $2)
+			AND ($3::TEXT = '' OR run_id::TEXT = $3)
+	`
+
+type StockRejectRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.StockRejectRepository = (*StockRejectRepository)(nil)
+
+func NewStockRejectRepository(db *sql.DB) *StockRejectRepository {
+	return &StockRejectRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+func (r *StockRejectRepository) CreateRejects(rejects []*model.StockReject) error {
+	if len(rejects) == 0 {
+		return nil
+	}
+
+	tx, err := r.GetDB().Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO stock_rejects (id, run_id, source, ticker, event_time, payload, error_message, status, created_at)
+		VALUES ($1, NULLIF($2, '')::UUID, $3, $4, $5, $6, $7, $8, $9)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, reject := range rejects {
+		payload := []byte(reject.Payload)
+		if len(payload) == 0 {
+			payload = []byte("{}")
+		}
+
+		_, err := stmt.Exec(
+			reject.ID,
+			reject.RunID,
+			reject.Source,
+			reject.Ticker,
+			reject.EventTime,
+			payload,
+			reject.ErrorMessage,
+			reject.Status,
+			reject.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create stock reject: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetRejects lists rejects matching filter, newest first.
+func (r *StockRejectRepository) GetRejects(filter model.StockRejectFilter, limit, offset int) ([]*model.StockReject, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := `SELECT ` + stockRejectSelectColumns + ` FROM stock_rejects` + stockRejectFilterClause + `
+		ORDER BY created_at DESC
+		LIMIT $4 OFFSET $5
+	`
+
+	rows, err := r.GetDB().Query(query, filter.Status, filter.Source, filter.RunID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock rejects: %w", err)
+	}
+	defer rows.Close()
+
+	var rejects []*model.StockReject
+	for rows.Next() {
+		reject, err := scanStockReject(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock reject: %w", err)
+		}
+		rejects = append(rejects, reject)
+	}
+
+	return rejects, nil
+}
+
+func (r *StockRejectRepository) GetRejectsCount(filter model.StockRejectFilter) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM stock_rejects` + stockRejectFilterClause
+	if err := r.GetDB().QueryRow(query, filter.Status, filter.Source, filter.RunID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count stock rejects: %w", err)
+	}
+	return count, nil
+}
+
+func (r *StockRejectRepository) GetRejectByID(rejectID string) (*model.StockReject, error) {
+	query := `SELECT ` + stockRejectSelectColumns + ` FROM stock_rejects WHERE id = $1`
+
+	reject, err := scanStockReject(r.GetDB().QueryRow(query, rejectID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock reject: %w", err)
+	}
+
+	return reject, nil
+}
+
+// UpdateReject stores the payload, error, status and resolution time of
+// reject.
+func (r *StockRejectRepository) UpdateReject(reject *model.StockReject) error {
+	query := `
+		UPDATE stock_rejects
+		SET ticker = $2, event_time = $3, payload = $4, error_message = $5, status = $6, resolved_at = $7
+		WHERE id = $1
+	`
+
+	_, err := r.GetDB().Exec(query,
+		reject.ID,
+		reject.Ticker,
+		reject.EventTime,
+		[]byte(reject.Payload),
+		reject.ErrorMessage,
+		reject.Status,
+		reject.ResolvedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update stock reject: %w", err)
+	}
+
+	return nil
+}
+
+func scanStockReject(row rowScanner) (*model.StockReject, error) {
+	var reject model.StockReject
+	var payload []byte
+	var eventTime, resolvedAt sql.NullTime
+
+	err := row.Scan(
+		&reject.ID,
+		&reject.RunID,
+		&reject.Source,
+		&reject.Ticker,
+		&eventTime,
+		&payload,
+		&reject.ErrorMessage,
+		&reject.Status,
+		&reject.CreatedAt,
+		&resolvedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reject.Payload = payload
+	if eventTime.Valid {
+		reject.EventTime = &eventTime.Time
+	}
+	if resolvedAt.Valid {
+		reject.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &reject, nil
+}
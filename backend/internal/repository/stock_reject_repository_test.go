@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/config"
+	"github.com/valeriapadilla/stock-insights/internal/database"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestStockRejectRepository(t *testing.T) {
+	testCfg := config.LoadTestConfig()
+	if !testCfg.HasTestDatabase() {
+		t.Skip("DATABASE_URL_TEST not set, skipping integration test")
+	}
+
+	err := connectToTestDatabase()
+	require.NoError(t, err)
+	defer database.Close()
+
+	repo := NewStockRejectRepository(database.DB)
+	runRepo := NewIngestionRunRepository(database.DB)
+
+	_, err = database.DB.Exec("DELETE FROM stock_rejects")
+	require.NoError(t, err)
+
+	run := &model.IngestionRun{
+		ID:              uuid.New().String(),
+		Status:          model.IngestionStatusRunning,
+		StartedAt:       time.Now().UTC(),
+		IngestionResult: model.IngestionResult{Source: "primary"},
+	}
+	require.NoError(t, runRepo.CreateRun(run))
+
+	eventTime := time.Now().UTC().Truncate(time.Second)
+	newReject := func(runID, source, ticker string, createdAt time.Time) *model.StockReject {
+		return &model.StockReject{
+			ID:           uuid.New().String(),
+			RunID:        runID,
+			Source:       source,
+			Ticker:       ticker,
+			EventTime:    &eventTime,
+			Payload:      []byte(`{"ticker": "` + ticker + `", "company": ""}`),
+			ErrorMessage: "company is required",
+			Status:       model.StockRejectStatusPending,
+			CreatedAt:    createdAt,
+		}
+	}
+
+	older := newReject(run.ID, "primary", "NOCO", eventTime.Add(-time.Minute))
+	newer := newReject("", "backup", "LONGTICKER1", eventTime)
+	require.NoError(t, repo.CreateRejects([]*model.StockReject{older, newer}))
+
+	t.Run("GetRejects filters newest first", func(t *testing.T) {
+		rejects, err := repo.GetRejects(model.StockRejectFilter{}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, rejects, 2)
+		assert.Equal(t, newer.ID, rejects[0].ID)
+		assert.Empty(t, rejects[0].RunID)
+
+		rejects, err = repo.GetRejects(model.StockRejectFilter{RunID: run.ID}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, rejects, 1)
+		assert.Equal(t, "NOCO", rejects[0].Ticker)
+		assert.JSONEq(t, `{"ticker": "NOCO", "company": ""}`, string(rejects[0].Payload))
+
+		count, err := repo.GetRejectsCount(model.StockRejectFilter{Source: "backup", Status: model.StockRejectStatusPending})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("UpdateReject resolves a reject", func(t *testing.T) {
+		resolvedAt := time.Now().UTC()
+		older.Status = model.StockRejectStatusReplayed
+		older.Payload = []byte(`{"ticker": "NOCO", "company": "Fixed Company"}`)
+		older.ResolvedAt = &resolvedAt
+		require.NoError(t, repo.UpdateReject(older))
+
+		stored, err := repo.GetRejectByID(older.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored)
+		assert.Equal(t, model.StockRejectStatusReplayed, stored.Status)
+		assert.NotNil(t, stored.ResolvedAt)
+		assert.JSONEq(t, `{"ticker": "NOCO", "company": "Fixed Company"}`, string(stored.Payload))
+
+		missing, err := repo.GetRejectByID(uuid.New().String())
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+}
@@ -10,6 +10,13 @@ import (
 	"github.com/valeriapadilla/stock-insights/internal/validator"
 )
 
+const stockSelectColumns = `ticker, company, target_from, target_to, rating_from, rating_to,
+	action, brokerage, time, created_at, updated_at, target_from_price, target_to_price, source`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 type StockRepository struct {
 	*BaseRepository
 	validator *validator.CommonValidator
@@ -44,9 +51,8 @@ func (r *StockRepository) GetStocks(params interfaces.GetStocksParams) ([]*model
 
 	if params.Search != nil {
 		query = `
-			SELECT ticker, company, target_from, target_to, rating_from, rating_to, 
-			       action, brokerage, time, created_at, updated_at
-			FROM stocks 
+			SELECT ` + stockSelectColumns + `
+			FROM stocks
 			WHERE 1=1
 		`
 
@@ -69,21 +75,19 @@ func (r *StockRepository) GetStocks(params interfaces.GetStocksParams) ([]*model
 		}
 
 		if params.Search.MinPrice != nil {
-			query += fmt.Sprintf(" AND CAST(REPLACE(target_to, '$', '') AS DECIMAL) >= $%d", argIndex)
+			query += fmt.Sprintf(" AND target_to_price >= $%d", argIndex)
 			args = append(args, *params.Search.MinPrice)
 			argIndex++
 		}
 
 		if params.Search.MaxPrice != nil {
-			query += fmt.Sprintf(" AND CAST(REPLACE(target_to, '$', '') AS DECIMAL) <= $%d", argIndex)
+			query += fmt.Sprintf(" AND target_to_price <= $%d", argIndex)
 			args = append(args, *params.Search.MaxPrice)
 			argIndex++
 		}
 	} else if len(params.Filters) > 0 {
 		qb := NewQueryBuilder().
-			Select("ticker", "company", "target_from", "target_to",
-				"rating_from", "rating_to", "action", "brokerage", "time",
-				"created_at", "updated_at").
+			Select(stockSelectColumns).
 			From("stocks")
 
 		if params.Filters["brokerage"] != "" {
@@ -99,12 +103,11 @@ func (r *StockRepository) GetStocks(params interfaces.GetStocksParams) ([]*model
 		query, args = qb.Build()
 	} else {
 		query = fmt.Sprintf(`
-			SELECT ticker, company, target_from, target_to, rating_from, rating_to, 
-			       action, brokerage, time, created_at, updated_at
-			FROM stocks 
-			ORDER BY %s %s 
+			SELECT %s
+			FROM stocks
+			ORDER BY %s %s
 			LIMIT %d OFFSET %d
-		`, params.Sort, params.Order, params.Limit, params.Offset)
+		`, stockSelectColumns, params.Sort, params.Order, params.Limit, params.Offset)
 	}
 
 	if params.Search != nil || len(params.Filters) > 0 {
@@ -120,16 +123,11 @@ func (r *StockRepository) GetStocks(params interfaces.GetStocksParams) ([]*model
 
 	var stocks []*model.Stock
 	for rows.Next() {
-		var stock model.Stock
-		err := rows.Scan(
-			&stock.Ticker, &stock.Company, &stock.TargetFrom, &stock.TargetTo,
-			&stock.RatingFrom, &stock.RatingTo, &stock.Action, &stock.Brokerage, &stock.Time,
-			&stock.CreatedAt, &stock.UpdatedAt,
-		)
+		stock, err := scanStock(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan stock: %w", err)
 		}
-		stocks = append(stocks, &stock)
+		stocks = append(stocks, stock)
 	}
 
 	return stocks, nil
@@ -160,13 +158,13 @@ func (r *StockRepository) GetStocksCount(params interfaces.GetStocksParams) (int
 		}
 
 		if params.Search.MinPrice != nil {
-			query += fmt.Sprintf(" AND CAST(REPLACE(target_to, '$', '') AS DECIMAL) >= $%d", argIndex)
+			query += fmt.Sprintf(" AND target_to_price >= $%d", argIndex)
 			args = append(args, *params.Search.MinPrice)
 			argIndex++
 		}
 
 		if params.Search.MaxPrice != nil {
-			query += fmt.Sprintf(" AND CAST(REPLACE(target_to, '$', '') AS DECIMAL) <= $%d", argIndex)
+			query += fmt.Sprintf(" AND target_to_price <= $%d", argIndex)
 			args = append(args, *params.Search.MaxPrice)
 			argIndex++
 		}
@@ -217,21 +215,14 @@ func (r *StockRepository) GetStocksCount(params interfaces.GetStocksParams) (int
 
 func (r *StockRepository) GetStockByTicket(ticket string) (*model.Stock, error) {
 	query := `
-		SELECT ticker, company, target_from, target_to, rating_from, rating_to, 
-		       action, brokerage, time, created_at, updated_at
-		FROM stocks 
-		WHERE ticker = $1 
-		ORDER BY time DESC 
+		SELECT ` + stockSelectColumns + `
+		FROM stocks
+		WHERE ticker = $1
+		ORDER BY time DESC
 		LIMIT 1
 	`
 
-	var stock model.Stock
-	err := r.GetDB().QueryRow(query, ticket).Scan(
-		&stock.Ticker, &stock.Company, &stock.TargetFrom, &stock.TargetTo,
-		&stock.RatingFrom, &stock.RatingTo, &stock.Action, &stock.Brokerage, &stock.Time,
-		&stock.CreatedAt, &stock.UpdatedAt,
-	)
-
+	stock, err := scanStock(r.GetDB().QueryRow(query, ticket))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -239,14 +230,84 @@ func (r *StockRepository) GetStockByTicket(ticket string) (*model.Stock, error)
 		return nil, fmt.Errorf("failed to get stock by ticket: %w", err)
 	}
 
-	return &stock, nil
+	return stock, nil
+}
+
+func (r *StockRepository) GetStockHistory(ticket string, filters interfaces.StockHistoryFilters) ([]*model.Stock, error) {
+	if filters.Limit <= 0 {
+		filters.Limit = 50
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+
+	whereClause, args := r.buildHistoryWhereClause(ticket, filters)
+
+	query := `
+		SELECT ` + stockSelectColumns + `
+		FROM stocks
+	` + whereClause
+	query += " ORDER BY time DESC"
+	query += fmt.Sprintf(" LIMIT %d OFFSET %d", filters.Limit, filters.Offset)
+
+	rows, err := r.GetDB().Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock history: %w", err)
+	}
+	defer rows.Close()
+
+	var stocks []*model.Stock
+	for rows.Next() {
+		stock, err := scanStock(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock: %w", err)
+		}
+		stocks = append(stocks, stock)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stock history: %w", err)
+	}
+
+	return stocks, nil
+}
+
+func (r *StockRepository) GetStockHistoryCount(ticket string, filters interfaces.StockHistoryFilters) (int, error) {
+	whereClause, args := r.buildHistoryWhereClause(ticket, filters)
+
+	query := "SELECT COUNT(*) FROM stocks " + whereClause
+
+	var count int
+	err := r.GetDB().QueryRow(query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count stock history: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *StockRepository) buildHistoryWhereClause(ticket string, filters interfaces.StockHistoryFilters) (string, []interface{}) {
+	whereClause := "WHERE ticker = $1"
+	args := []interface{}{ticket}
+	argIndex := 2
+
+	if filters.DateFrom != nil {
+		whereClause += fmt.Sprintf(" AND time >= $%d", argIndex)
+		args = append(args, filters.DateFrom)
+		argIndex++
+	}
+
+	if filters.DateTo != nil {
+		whereClause += fmt.Sprintf(" AND time <= $%d", argIndex)
+		args = append(args, filters.DateTo)
+	}
+
+	return whereClause, args
 }
 
 func (r *StockRepository) SearchStocks(filters interfaces.StockSearchFilters) ([]*model.Stock, error) {
 	query := `
-		SELECT ticker, company, target_from, target_to, rating_from, rating_to, 
-		       action, brokerage, time, created_at, updated_at
-		FROM stocks 
+		SELECT ` + stockSelectColumns + `
+		FROM stocks
 		WHERE 1=1
 	`
 	var args []interface{}
@@ -271,13 +332,13 @@ func (r *StockRepository) SearchStocks(filters interfaces.StockSearchFilters) ([
 	}
 
 	if filters.MinPrice != nil {
-		query += fmt.Sprintf(" AND CAST(REPLACE(target_to, '$', '') AS DECIMAL) >= $%d", argIndex)
+		query += fmt.Sprintf(" AND target_to_price >= $%d", argIndex)
 		args = append(args, *filters.MinPrice)
 		argIndex++
 	}
 
 	if filters.MaxPrice != nil {
-		query += fmt.Sprintf(" AND CAST(REPLACE(target_to, '$', '') AS DECIMAL) <= $%d", argIndex)
+		query += fmt.Sprintf(" AND target_to_price <= $%d", argIndex)
 		args = append(args, *filters.MaxPrice)
 		argIndex++
 	}
@@ -293,16 +354,11 @@ func (r *StockRepository) SearchStocks(filters interfaces.StockSearchFilters) ([
 
 	var stocks []*model.Stock
 	for rows.Next() {
-		var stock model.Stock
-		err := rows.Scan(
-			&stock.Ticker, &stock.Company, &stock.TargetFrom, &stock.TargetTo,
-			&stock.RatingFrom, &stock.RatingTo, &stock.Action, &stock.Brokerage, &stock.Time,
-			&stock.CreatedAt, &stock.UpdatedAt,
-		)
+		stock, err := scanStock(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan stock: %w", err)
 		}
-		stocks = append(stocks, &stock)
+		stocks = append(stocks, stock)
 	}
 
 	return stocks, nil
@@ -338,3 +394,17 @@ func (r *StockRepository) ExistsByTicker(ticker string) (bool, error) {
 
 	return exists, nil
 }
+
+func scanStock(row rowScanner) (*model.Stock, error) {
+	var stock model.Stock
+	err := row.Scan(
+		&stock.Ticker, &stock.Company, &stock.TargetFrom, &stock.TargetTo,
+		&stock.RatingFrom, &stock.RatingTo, &stock.Action, &stock.Brokerage, &stock.Time,
+		&stock.CreatedAt, &stock.UpdatedAt, &stock.TargetFromPrice, &stock.TargetToPrice,
+		&stock.Source,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &stock, nil
+}
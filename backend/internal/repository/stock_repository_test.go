@@ -241,9 +241,207 @@ func TestStockRepositoryCRUD(t *testing.T) {
 		assert.Greater(t, count, 0)
 	})
 
+	t.Run("Get Stock History", func(t *testing.T) {
+		cleanupStock(t, repo, "HIST")
+
+		now := time.Now()
+		for i := 0; i < 3; i++ {
+			err := createTestStock(repo, &model.Stock{
+				Ticker:     "HIST",
+				Company:    "History Company",
+				TargetFrom: "$10.00",
+				TargetTo:   "$15.00",
+				RatingFrom: "Hold",
+				RatingTo:   "Buy",
+				Action:     "upgraded by",
+				Brokerage:  "Test Brokerage",
+				Time:       now.AddDate(0, 0, -i*10),
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			})
+			require.NoError(t, err)
+		}
+
+		history, err := repo.GetStockHistory("HIST", repoInterfaces.StockHistoryFilters{Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.True(t, history[0].Time.After(history[1].Time))
+		assert.True(t, history[1].Time.After(history[2].Time))
+
+		dateFrom := now.AddDate(0, 0, -15)
+		filters := repoInterfaces.StockHistoryFilters{DateFrom: &dateFrom, Limit: 10}
+		history, err = repo.GetStockHistory("HIST", filters)
+		require.NoError(t, err)
+		assert.Len(t, history, 2)
+
+		count, err := repo.GetStockHistoryCount("HIST", filters)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		history, err = repo.GetStockHistory("HIST", repoInterfaces.StockHistoryFilters{Limit: 1, Offset: 2})
+		require.NoError(t, err)
+		assert.Len(t, history, 1)
+	})
+
+	t.Run("Search By Numeric Target Price", func(t *testing.T) {
+		cleanupStock(t, repo, "PRICE")
+
+		command := NewStockCommand(database.DB)
+		err := command.Upsert(&model.Stock{
+			Ticker:     "PRICE",
+			Company:    "Price Company",
+			TargetFrom: "$1,000.00",
+			TargetTo:   "$1,200.00",
+			RatingTo:   "Buy",
+			Time:       time.Now(),
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+		require.NoError(t, err)
+
+		minPrice := 1100.0
+		maxPrice := 1300.0
+		stocks, err := repo.GetStocks(repoInterfaces.GetStocksParams{
+			Limit: 10,
+			Search: &repoInterfaces.StockSearchFilters{
+				Ticket:   "PRICE",
+				MinPrice: &minPrice,
+				MaxPrice: &maxPrice,
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, stocks, 1)
+		require.NotNil(t, stocks[0].TargetToPrice)
+		assert.Equal(t, 1200.0, *stocks[0].TargetToPrice)
+	})
+
+	t.Run("Upsert Respects Source Precedence", func(t *testing.T) {
+		cleanupStock(t, repo, "SRC")
+
+		command := NewStockCommand(database.DB)
+		command.SetSourcePrecedence([]string{"primary", "backup"})
+
+		eventTime := time.Now().UTC().Truncate(time.Second)
+		event := func(source, targetTo string) *model.Stock {
+			return &model.Stock{
+				Ticker: "SRC", Company: "Source Company", TargetTo: targetTo, RatingTo: "Buy",
+				Time: eventTime, CreatedAt: eventTime, UpdatedAt: eventTime, Source: source,
+			}
+		}
+
+		require.NoError(t, command.Upsert(event("backup", "$10.00")))
+		require.NoError(t, command.Upsert(event("primary", "$20.00")))
+		// A lower-ranked source cannot overwrite the primary's version
+		result, err := command.BulkUpsert([]*model.Stock{event("backup", "$30.00")})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Unchanged)
+
+		stock, err := repo.GetStockByTicket("SRC")
+		require.NoError(t, err)
+		require.NotNil(t, stock)
+		assert.Equal(t, "primary", stock.Source)
+		assert.Equal(t, "$20.00", stock.TargetTo)
+	})
+
+	t.Run("BulkUpsert Classifies Rows", func(t *testing.T) {
+		cleanupStock(t, repo, "BULK")
+
+		command := NewStockCommand(database.DB)
+		eventTime := time.Now().UTC().Truncate(time.Second)
+		event := func(offset time.Duration, targetTo string) *model.Stock {
+			return &model.Stock{
+				Ticker: "BULK", Company: "Bulk Company", TargetTo: targetTo, RatingTo: "Buy",
+				Time: eventTime.Add(offset), CreatedAt: eventTime, UpdatedAt: eventTime,
+			}
+		}
+
+		result, err := command.BulkUpsert([]*model.Stock{event(0, "$10.00"), event(time.Minute, "$11.00")})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Inserted)
+
+		result, err = command.BulkUpsert([]*model.Stock{
+			event(0, "$10.00"),
+			event(time.Minute, "$12.00"),
+			event(2*time.Minute, "$13.00"),
+			{Ticker: "BULK", Time: eventTime},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Inserted)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, 1, result.Unchanged)
+		assert.Equal(t, 1, result.Rejected)
+		require.Len(t, result.Rejections, 1)
+		assert.Equal(t, "company is required", result.Rejections[0].Reason)
+	})
+
+	t.Run("Upsert Records Revisions", func(t *testing.T) {
+		cleanupStock(t, repo, "REV")
+
+		command := NewStockCommand(database.DB)
+		revisions := NewStockRevisionRepository(database.DB)
+		eventTime := time.Now().UTC().Truncate(time.Second)
+		event := func(targetTo, ratingTo string) *model.Stock {
+			return &model.Stock{
+				Ticker: "REV", Company: "Revision Company", TargetTo: targetTo, RatingTo: ratingTo,
+				Time: eventTime, CreatedAt: eventTime, UpdatedAt: eventTime,
+			}
+		}
+
+		require.NoError(t, command.Upsert(event("$10.00", "Buy")))
+		// Inserts and identical upserts are not revisions
+		_, err := command.BulkUpsert([]*model.Stock{event("$10.00", "Buy")})
+		require.NoError(t, err)
+		history, err := revisions.GetRevisions("REV", eventTime)
+		require.NoError(t, err)
+		assert.Empty(t, history)
+
+		require.NoError(t, command.Upsert(event("$12.00", "Buy")))
+		result, err := command.BulkUpsert([]*model.Stock{event("$12.00", "Hold")})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Updated)
+
+		history, err = revisions.GetRevisions("REV", eventTime)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, model.DefaultStockSource, history[0].Source)
+		assert.Equal(t, []model.StockFieldChange{
+			{Field: "target_to", Before: "$10.00", After: "$12.00"},
+			{Field: "target_to_price", Before: 10.0, After: 12.0},
+		}, history[0].Changes)
+		assert.Equal(t, []model.StockFieldChange{
+			{Field: "rating_to", Before: "Buy", After: "Hold"},
+		}, history[1].Changes)
+	})
+
+	t.Run("Preview Bulk Upsert Changes Nothing", func(t *testing.T) {
+		cleanupStock(t, repo, "PREV")
+
+		command := NewStockCommand(database.DB)
+		eventTime := time.Now().UTC().Truncate(time.Second)
+		stocks := []*model.Stock{
+			{Ticker: "PREV", Company: "Preview Company", TargetTo: "$10.00", Time: eventTime, CreatedAt: eventTime, UpdatedAt: eventTime},
+			{Ticker: "PREV", Time: eventTime.Add(-time.Hour)},
+		}
+
+		result, err := command.PreviewBulkUpsert(stocks)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Inserted)
+		assert.Equal(t, 1, result.Rejected)
+
+		count, err := repo.GetStockHistoryCount("PREV", repoInterfaces.StockHistoryFilters{})
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
 	cleanupStock(t, repo, testStock.Ticker)
 	cleanupStock(t, repo, "TEST1")
 	cleanupStock(t, repo, "TEST2")
+	cleanupStock(t, repo, "HIST")
+	cleanupStock(t, repo, "PRICE")
+	cleanupStock(t, repo, "SRC")
+	cleanupStock(t, repo, "BULK")
+	cleanupStock(t, repo, "REV")
+	cleanupStock(t, repo, "PREV")
 }
 
 func TestStockRepositoryIntegration(t *testing.T) {
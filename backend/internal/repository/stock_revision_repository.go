@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+)
+
+type StockRevisionRepository struct {
+	*BaseRepository
+}
+
+var _ interfaces.StockRevisionRepository = (*StockRevisionRepository)(nil)
+
+func NewStockRevisionRepository(db *sql.DB) *StockRevisionRepository {
+	return &StockRevisionRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// GetRevisions lists the revisions of the event at ticker and eventTime,
+// oldest first. Revisions are written by StockCommandImpl when an upsert
+// changes a stored event.
+func (r *StockRevisionRepository) GetRevisions(ticker string, eventTime time.Time) ([]*model.StockRevision, error) {
+	query := `
+		SELECT id::TEXT, ticker, event_time, source, changes, revised_at
+		FROM stock_revisions
+		WHERE ticker = $1 AND event_time = $2
+		ORDER BY revised_at ASC
+	`
+
+	rows, err := r.GetDB().Query(query, ticker, eventTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []*model.StockRevision
+	for rows.Next() {
+		revision, err := scanStockRevision(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stock revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func scanStockRevision(row rowScanner) (*model.StockRevision, error) {
+	var revision model.StockRevision
+	var changes []byte
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.Ticker,
+		&revision.EventTime,
+		&revision.Source,
+		&changes,
+		&revision.RevisedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(changes, &revision.Changes); err != nil {
+		return nil, fmt.Errorf("failed to decode revision changes: %w", err)
+	}
+
+	return &revision, nil
+}
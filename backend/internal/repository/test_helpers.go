@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"github.com/valeriapadilla/stock-insights/internal/config"
 	"github.com/valeriapadilla/stock-insights/internal/database"
@@ -31,7 +32,21 @@ func connectToTestDatabase() error {
 func cleanDatabase() {
 	queries := []string{
 		"DROP TABLE IF EXISTS recommendations CASCADE",
+		"DROP TABLE IF EXISTS recommendation_runs CASCADE",
 		"DROP TABLE IF EXISTS stocks CASCADE",
+		"DROP TABLE IF EXISTS unmapped_reference_values CASCADE",
+		"DROP TABLE IF EXISTS reference_aliases CASCADE",
+		"DROP TABLE IF EXISTS actions CASCADE",
+		"DROP TABLE IF EXISTS ratings CASCADE",
+		"DROP TABLE IF EXISTS brokerages CASCADE",
+		"DROP TABLE IF EXISTS scoring_configs CASCADE",
+		"DROP TABLE IF EXISTS prices CASCADE",
+		"DROP TABLE IF EXISTS ingestion_checkpoints CASCADE",
+		"DROP TABLE IF EXISTS stock_revisions CASCADE",
+		"DROP TABLE IF EXISTS data_quality_findings CASCADE",
+		"DROP TABLE IF EXISTS stock_rejects CASCADE",
+		"DROP TABLE IF EXISTS ingestion_runs CASCADE",
+		"DROP TABLE IF EXISTS jobs CASCADE",
 		"DELETE FROM migrations",
 		"DROP TABLE IF EXISTS migrations CASCADE",
 	}
@@ -163,6 +178,10 @@ func cleanupStock(t *testing.T, repo *StockRepository, ticker string) {
 	_, err := repo.GetDB().Exec(query, ticker)
 	require.NoError(t, err)
 
+	query = "DELETE FROM stock_revisions WHERE ticker = $1"
+	_, err = repo.GetDB().Exec(query, ticker)
+	require.NoError(t, err)
+
 	query = "DELETE FROM stocks WHERE ticker = $1"
 	_, err = repo.GetDB().Exec(query, ticker)
 	require.NoError(t, err)
@@ -172,6 +191,35 @@ func cleanupRecommendations(t *testing.T, repo *RecommendationRepository, runAt
 	query := "DELETE FROM recommendations WHERE run_at::date = $1::date"
 	_, err := repo.GetDB().Exec(query, runAt)
 	require.NoError(t, err)
+
+	query = "DELETE FROM recommendation_runs WHERE started_at::date = $1::date"
+	_, err = repo.GetDB().Exec(query, runAt)
+	require.NoError(t, err)
+}
+
+// attachCompletedRun records a completed, active run for the recommendations, as
+// PublishRun does, so GetLatest can serve them.
+func attachCompletedRun(t *testing.T, repo *RecommendationRepository, recommendations []*model.Recommendation) {
+	if len(recommendations) == 0 {
+		return
+	}
+
+	runID := uuid.New().String()
+	runAt := recommendations[0].RunAt
+
+	_, err := repo.GetDB().Exec("UPDATE recommendation_runs SET is_active = false WHERE is_active")
+	require.NoError(t, err)
+
+	query := `
+		INSERT INTO recommendation_runs (id, status, strategy, started_at, finished_at, recommendation_count, is_active)
+		VALUES ($1, 'completed', 'additive', $2, $2, $3, true)
+	`
+	_, err = repo.GetDB().Exec(query, runID, runAt, len(recommendations))
+	require.NoError(t, err)
+
+	for _, rec := range recommendations {
+		rec.RunID = runID
+	}
 }
 
 func cleanupAllTestData(t *testing.T, repo *StockRepository) {
@@ -179,6 +227,10 @@ func cleanupAllTestData(t *testing.T, repo *StockRepository) {
 	_, err := repo.GetDB().Exec(query)
 	require.NoError(t, err)
 
+	query = "DELETE FROM recommendation_runs"
+	_, err = repo.GetDB().Exec(query)
+	require.NoError(t, err)
+
 	query = "DELETE FROM stocks"
 	_, err = repo.GetDB().Exec(query)
 	require.NoError(t, err)
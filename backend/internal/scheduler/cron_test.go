@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2025, time.March, 12, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2025, time.March, 12, 10, 18, 0, 0, time.UTC)},
+		{"0 1 * * *", time.Date(2025, time.March, 13, 1, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, time.March, 12, 10, 30, 0, 0, time.UTC)},
+		{"5/20 9-17 * * *", time.Date(2025, time.March, 12, 10, 25, 0, 0, time.UTC)},
+		{"0,30 10 * * *", time.Date(2025, time.March, 12, 10, 30, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2025, time.March, 13, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 7", time.Date(2025, time.March, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either one matches
+		{"0 0 1 * fri", time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, time.March, 12, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2025, time.March, 16, 0, 0, 0, 0, time.UTC)},
+		{"@every 90m", from.Add(90 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := ParseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, schedule.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Location(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*60*60)
+	schedule, err := ParseCron("0 20 * * *")
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2025, time.March, 12, 22, 0, 0, 0, time.UTC).In(bogota))
+	assert.Equal(t, time.Date(2025, time.March, 13, 1, 0, 0, 0, time.UTC), next.UTC())
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * foo *",
+		"@every soon",
+		"@every 10ms",
+	} {
+		_, err := ParseCron(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestParseCron_NeverMatches(t *testing.T) {
+	schedule, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
+
+func TestRerunInterval(t *testing.T) {
+	from := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+
+	hourly, err := ParseCron("@hourly")
+	require.NoError(t, err)
+	assert.Equal(t, 54*time.Minute-time.Minute, RerunInterval(hourly, from, time.Minute))
+
+	// The shortest gap of a weekday schedule is one day
+	weekdays, err := ParseCron("0 1 * * 1-5")
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour-144*time.Minute, RerunInterval(weekdays, from, 0))
+
+	assert.Equal(t, time.Duration(0), RerunInterval(Every(time.Minute), from, 5*time.Minute))
+}
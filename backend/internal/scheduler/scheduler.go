@@ -0,0 +1,232 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// CatchUpPolicy decides what happens to slots missed while the process was
+// down or a previous run was still going.
+type CatchUpPolicy string
+
+const (
+	// CatchUpSkip drops missed slots and waits for the next one.
+	CatchUpSkip CatchUpPolicy = "skip"
+	// CatchUpOnce runs once right away for any number of missed slots.
+	CatchUpOnce CatchUpPolicy = "once"
+)
+
+func (p CatchUpPolicy) IsValid() bool {
+	return p == CatchUpSkip || p == CatchUpOnce
+}
+
+const defaultShutdownTimeout = 30 * time.Second
+
+// Config tunes a Scheduler. The zero value runs tasks exactly on their slots,
+// in UTC, skipping missed slots.
+type Config struct {
+	// Jitter delays each run by a random duration up to its value so
+	// replicas and upstreams are not hit at the same instant.
+	Jitter  time.Duration
+	CatchUp CatchUpPolicy
+	// ShutdownTimeout is how long Run waits for running tasks once its
+	// context is done before cancelling them; defaults to 30s.
+	ShutdownTimeout time.Duration
+	// Location is the time zone cron specs are read in; defaults to UTC.
+	Location *time.Location
+}
+
+// Task is a unit of recurring work.
+type Task struct {
+	Name     string
+	Schedule Schedule
+	// ShouldRun, when set, is asked before every run, catch-up runs
+	// included; returning false skips the slot. It also tells CatchUpOnce
+	// whether a slot was missed while the process was down; tasks without it
+	// are not caught up on start.
+	ShouldRun func(ctx context.Context) (bool, error)
+	Run       func(ctx context.Context) error
+}
+
+// Scheduler runs tasks on their schedules until its context is done. Runs of
+// a task never overlap; different tasks run concurrently.
+type Scheduler struct {
+	config Config
+	tasks  []Task
+	logger *logrus.Logger
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func New(config Config, logger *logrus.Logger) *Scheduler {
+	if config.CatchUp == "" {
+		config.CatchUp = CatchUpSkip
+	}
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = defaultShutdownTimeout
+	}
+	if config.Location == nil {
+		config.Location = time.UTC
+	}
+
+	return &Scheduler{
+		config: config,
+		logger: logger,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Add registers a task. Tasks added after Run has started are ignored.
+func (s *Scheduler) Add(task Task) {
+	s.tasks = append(s.tasks, task)
+}
+
+// Run blocks until ctx is done. It then stops starting runs and waits up to
+// ShutdownTimeout for running tasks, after which it cancels their context
+// and returns an error once they have returned.
+func (s *Scheduler) Run(ctx context.Context) error {
+	// Runs get their own context so shutdown lets them finish
+	runCtx, cancelRuns := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelRuns()
+
+	var wg sync.WaitGroup
+	for _, task := range s.tasks {
+		wg.Add(1)
+		go func(task Task) {
+			defer wg.Done()
+			s.loop(ctx, runCtx, task)
+		}(task)
+	}
+
+	s.logger.WithField("tasks", len(s.tasks)).Info("Scheduler started")
+	<-ctx.Done()
+	s.logger.Info("Scheduler stopping, waiting for running tasks")
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(s.config.ShutdownTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		s.logger.Info("Scheduler stopped")
+		return nil
+	case <-timer.C:
+		s.logger.WithField("shutdown_timeout", s.config.ShutdownTimeout.String()).Warn("Running tasks did not finish in time, cancelling them")
+		cancelRuns()
+		<-done
+		return fmt.Errorf("scheduler shutdown timed out after %s, running tasks were cancelled", s.config.ShutdownTimeout)
+	}
+}
+
+// loop serves the slots of one task until ctx is done.
+func (s *Scheduler) loop(ctx, runCtx context.Context, task Task) {
+	logger := s.logger.WithField("task", task.Name)
+
+	last := time.Now().In(s.config.Location)
+	if s.config.CatchUp == CatchUpOnce && task.ShouldRun != nil {
+		s.run(ctx, runCtx, task, "catch_up")
+		last = time.Now().In(s.config.Location)
+	}
+
+	for {
+		next := task.Schedule.Next(last)
+		if next.IsZero() {
+			logger.Warn("Schedule has no future slots, task stopped")
+			return
+		}
+
+		now := time.Now().In(s.config.Location)
+		if next.Before(now) {
+			// The previous run overran at least one slot
+			if s.config.CatchUp == CatchUpOnce {
+				logger.WithField("missed_slot", next.Format(time.RFC3339)).Info("Previous run overran a slot, catching up")
+				s.run(ctx, runCtx, task, "catch_up")
+			} else {
+				logger.WithField("missed_slot", next.Format(time.RFC3339)).Info("Previous run overran a slot, skipping it")
+			}
+			last = time.Now().In(s.config.Location)
+			continue
+		}
+
+		wait := next.Sub(now) + s.jitter()
+		logger.WithFields(logrus.Fields{
+			"next_run": now.Add(wait).Format(time.RFC3339),
+			"slot":     next.Format(time.RFC3339),
+		}).Info("Next run scheduled")
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return
+		}
+
+		s.run(ctx, runCtx, task, "scheduled")
+		last = next
+	}
+}
+
+// run executes one run of task unless the scheduler is stopping or ShouldRun
+// declines it.
+func (s *Scheduler) run(ctx, runCtx context.Context, task Task, trigger string) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	logger := s.logger.WithFields(logrus.Fields{
+		"task":    task.Name,
+		"trigger": trigger,
+	})
+
+	if task.ShouldRun != nil {
+		shouldRun, err := task.ShouldRun(runCtx)
+		if err != nil {
+			logger.WithError(err).Error("Failed to check whether task should run, skipping slot")
+			return
+		}
+		if !shouldRun {
+			logger.Info("Task ran recently, skipping slot")
+			return
+		}
+	}
+
+	logger.Info("Task started")
+	start := time.Now()
+	err := task.Run(runCtx)
+	fields := logrus.Fields{"duration": time.Since(start).String()}
+	if err != nil {
+		logger.WithError(err).WithFields(fields).Error("Task failed")
+		return
+	}
+	logger.WithFields(fields).Info("Task completed")
+}
+
+func (s *Scheduler) jitter() time.Duration {
+	if s.config.Jitter <= 0 {
+		return 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Duration(s.rand.Int63n(int64(s.config.Jitter)))
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
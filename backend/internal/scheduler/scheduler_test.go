@@ -0,0 +1,199 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	return logger
+}
+
+// runFor runs s until timeout and returns Run's error.
+func runFor(s *Scheduler, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return s.Run(ctx)
+}
+
+func TestScheduler_RunsOnSchedule(t *testing.T) {
+	var runs atomic.Int32
+	s := New(Config{}, newTestLogger())
+	s.Add(Task{
+		Name:     "ingest",
+		Schedule: Every(10 * time.Millisecond),
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	require.NoError(t, runFor(s, 100*time.Millisecond))
+	assert.GreaterOrEqual(t, runs.Load(), int32(3))
+}
+
+func TestScheduler_ShouldRunSkipsSlots(t *testing.T) {
+	var checks, runs atomic.Int32
+	s := New(Config{}, newTestLogger())
+	s.Add(Task{
+		Name:     "ingest",
+		Schedule: Every(10 * time.Millisecond),
+		// Every other slot finds a recent run, e.g. a manual one
+		ShouldRun: func(ctx context.Context) (bool, error) {
+			if checks.Add(1)%2 == 0 {
+				return false, nil
+			}
+			if checks.Load() == 3 {
+				return false, errors.New("database unavailable")
+			}
+			return true, nil
+		},
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return errors.New("failed runs do not stop the schedule")
+		},
+	})
+
+	require.NoError(t, runFor(s, 100*time.Millisecond))
+	assert.GreaterOrEqual(t, checks.Load(), int32(4))
+	assert.Less(t, runs.Load(), checks.Load()-1)
+	assert.GreaterOrEqual(t, runs.Load(), int32(1))
+}
+
+func TestScheduler_CatchUpOnStart(t *testing.T) {
+	for _, policy := range []CatchUpPolicy{CatchUpOnce, CatchUpSkip} {
+		t.Run(string(policy), func(t *testing.T) {
+			var runs atomic.Int32
+			s := New(Config{CatchUp: policy}, newTestLogger())
+			s.Add(Task{
+				Name:      "ingest",
+				Schedule:  Every(time.Hour),
+				ShouldRun: func(ctx context.Context) (bool, error) { return true, nil },
+				Run: func(ctx context.Context) error {
+					runs.Add(1)
+					return nil
+				},
+			})
+			// Without ShouldRun a task cannot tell whether it missed a slot
+			s.Add(Task{
+				Name:     "recommendations",
+				Schedule: Every(time.Hour),
+				Run: func(ctx context.Context) error {
+					runs.Add(1)
+					return nil
+				},
+			})
+
+			require.NoError(t, runFor(s, 50*time.Millisecond))
+			if policy == CatchUpOnce {
+				assert.Equal(t, int32(1), runs.Load())
+			} else {
+				assert.Equal(t, int32(0), runs.Load())
+			}
+		})
+	}
+}
+
+func TestScheduler_CatchUpAfterOverrun(t *testing.T) {
+	var mu sync.Mutex
+	var starts, ends []time.Time
+
+	s := New(Config{CatchUp: CatchUpOnce}, newTestLogger())
+	s.Add(Task{
+		Name:     "ingest",
+		Schedule: Every(30 * time.Millisecond),
+		Run: func(ctx context.Context) error {
+			mu.Lock()
+			starts = append(starts, time.Now())
+			first := len(starts) == 1
+			mu.Unlock()
+			if first {
+				time.Sleep(100 * time.Millisecond)
+			}
+			mu.Lock()
+			ends = append(ends, time.Now())
+			mu.Unlock()
+			return nil
+		},
+	})
+
+	require.NoError(t, runFor(s, 160*time.Millisecond))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.GreaterOrEqual(t, len(starts), 2)
+	// The overrun slots are made up for by one immediate run
+	assert.Less(t, starts[1].Sub(ends[0]), 20*time.Millisecond)
+}
+
+func TestScheduler_GracefulShutdown(t *testing.T) {
+	started := make(chan struct{})
+	var finished atomic.Bool
+
+	s := New(Config{ShutdownTimeout: time.Second}, newTestLogger())
+	s.Add(Task{
+		Name:     "ingest",
+		Schedule: Every(5 * time.Millisecond),
+		Run: func(ctx context.Context) error {
+			if finished.Load() {
+				return nil
+			}
+			close(started)
+			select {
+			case <-time.After(50 * time.Millisecond):
+				finished.Store(true)
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	// Stopping waits for the running task instead of cancelling it
+	require.NoError(t, s.Run(ctx))
+	assert.True(t, finished.Load())
+}
+
+func TestScheduler_ShutdownTimeoutCancelsRuns(t *testing.T) {
+	started := make(chan struct{})
+	var cancelled atomic.Bool
+
+	s := New(Config{ShutdownTimeout: 20 * time.Millisecond}, newTestLogger())
+	s.Add(Task{
+		Name:     "ingest",
+		Schedule: Every(5 * time.Millisecond),
+		Run: func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			cancelled.Store(true)
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	err := s.Run(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "shutdown timed out")
+	assert.True(t, cancelled.Load())
+}
@@ -20,16 +20,26 @@ type Server struct {
 	router           *gin.Engine
 	config           *config.Config
 	ingestionService interfaces.IngestionServiceInterface
+	importService    interfaces.ImportServiceInterface
 	jobManager       job.JobManagerInterface
 	logger           *logrus.Logger
 }
 
-func NewServer(cfg *config.Config, ingestionService interfaces.IngestionServiceInterface, logger *logrus.Logger) *Server {
+func NewServer(cfg *config.Config, ingestionService interfaces.IngestionServiceInterface, importService interfaces.ImportServiceInterface, logger *logrus.Logger) *Server {
+	jobManager := job.NewPersistentJobManager(repository.NewJobRepository(database.DB), cfg.JobWorkers, logger)
+	jobManager.SetDeadline(job.JobTypeIngest, cfg.JobIngestTimeout)
+	jobManager.SetDeadline(job.JobTypeRecommendations, cfg.JobRecommendationsTimeout)
+	jobManager.SetDeadline(job.JobTypeBackfill, cfg.JobBackfillTimeout)
+	jobManager.SetConcurrency(job.JobTypeIngest, cfg.JobIngestConcurrency)
+	jobManager.SetConcurrency(job.JobTypeRecommendations, cfg.JobRecommendationsConcurrency)
+	jobManager.SetConcurrency(job.JobTypeBackfill, cfg.JobBackfillConcurrency)
+
 	server := &Server{
 		config:           cfg,
 		router:           gin.New(),
 		ingestionService: ingestionService,
-		jobManager:       job.NewJobManager(5, logger),
+		importService:    importService,
+		jobManager:       jobManager,
 		logger:           logger,
 	}
 
@@ -73,18 +83,31 @@ func (s *Server) setupRoutes() {
 
 		stockRepo := repository.NewStockRepository(database.DB)
 		stockService := service.NewStockService(stockRepo, s.logger)
+		priceService := service.NewPriceService(repository.NewPriceRepository(database.DB), nil, s.logger)
+		stockService.SetPriceService(priceService)
+		stockService.SetRevisionRepository(repository.NewStockRevisionRepository(database.DB))
 		stockHandler := v1.NewStocksHandler(stockService, s.logger)
 
 		publicV1.GET("/stocks/search", stockHandler.SearchStocks)
 		publicV1.GET("/stocks", stockHandler.ListStocks)
 		publicV1.GET("/stocks/:ticket", stockHandler.GetStock)
+		publicV1.GET("/stocks/:ticket/history", stockHandler.GetStockHistory)
 
 		recommendationRepo := repository.NewRecommendationRepository(database.DB)
 		recommendationCmd := repository.NewRecommendationCommand(database.DB, stockRepo)
-		recommendationService := service.NewRecommendationService(stockRepo, recommendationRepo, recommendationCmd, s.logger)
-		recommendationsHandler := v1.NewRecommendationsHandler(recommendationService, s.logger)
+		referenceRepo := repository.NewReferenceRepository(database.DB)
+		referenceService := service.NewReferenceService(referenceRepo, s.logger)
+		scoringConfigRepo := repository.NewScoringConfigRepository(database.DB)
+		scoringConfigService := service.NewScoringConfigService(scoringConfigRepo, s.config.ScoringConfigFile, s.logger)
+		recommendationRunRepo := repository.NewRecommendationRunRepository(database.DB)
+		recommendationService := service.NewRecommendationService(stockRepo, recommendationRepo, recommendationCmd, recommendationRunRepo, referenceService, scoringConfigService, s.logger)
+		recommendationService.SetRetention(s.config.RecommendationRetention)
+		recommendationService.SetPriceService(priceService)
+		recommendationsHandler := v1.NewRecommendationsHandler(recommendationService, s.jobManager, s.logger)
 
 		publicV1.GET("/recommendations", recommendationsHandler.GetRecommendations)
+		publicV1.GET("/recommendations/runs", recommendationsHandler.GetRuns)
+		publicV1.GET("/recommendations/runs/:id", recommendationsHandler.GetRun)
 
 		// Admin endpoints
 		adminV1 := v1API.Group("/admin")
@@ -92,9 +115,40 @@ func (s *Server) setupRoutes() {
 		{
 			stocksIngestionHandler := v1.NewStocksIngestionHandler(s.ingestionService, s.jobManager, s.logger)
 			adminV1.POST("/ingest/stocks", stocksIngestionHandler.TriggerIngestion)
-			adminV1.GET("/jobs/:jobId", stocksIngestionHandler.GetJobStatus)
+			adminV1.GET("/ingest/sources", stocksIngestionHandler.ListSources)
+			adminV1.POST("/ingest/sources/:source", stocksIngestionHandler.TriggerSourceIngestion)
+			adminV1.GET("/ingestions", stocksIngestionHandler.ListRuns)
+			adminV1.GET("/ingestions/:id", stocksIngestionHandler.GetRun)
+			adminV1.GET("/ingestions/:id/quality", stocksIngestionHandler.GetRunQuality)
+			adminV1.GET("/health/sources", stocksIngestionHandler.SourcesHealth)
+			adminV1.GET("/metrics", stocksIngestionHandler.Metrics)
+
+			stockRejectsHandler := v1.NewStockRejectsHandler(s.ingestionService, s.logger)
+			adminV1.GET("/rejects", stockRejectsHandler.ListRejects)
+			adminV1.GET("/rejects/:id", stockRejectsHandler.GetReject)
+			adminV1.POST("/rejects/:id/replay", stockRejectsHandler.ReplayReject)
+			adminV1.POST("/rejects/:id/discard", stockRejectsHandler.DiscardReject)
+
+			jobsHandler := v1.NewJobsHandler(s.jobManager, s.logger)
+			adminV1.GET("/jobs", jobsHandler.ListJobs)
+			adminV1.GET("/jobs/:jobId", jobsHandler.GetJobStatus)
+			adminV1.DELETE("/jobs/:jobId", jobsHandler.CancelJob)
+			adminV1.POST("/jobs/:jobId/cancel", jobsHandler.CancelJob)
+
+			stockImportHandler := v1.NewStockImportHandler(s.importService, s.jobManager, s.logger)
+			adminV1.POST("/import/stocks", stockImportHandler.ImportStocks)
+
+			adminV1.GET("/stocks/:ticket/revisions", stockHandler.GetStockRevisions)
 
 			adminV1.POST("/recommendations/calculate", recommendationsHandler.CalculateRecommendations)
+
+			referenceHandler := v1.NewReferenceHandler(referenceService, s.logger)
+			adminV1.GET("/reference/unmapped", referenceHandler.GetUnmappedValues)
+
+			scoringConfigsHandler := v1.NewScoringConfigsHandler(scoringConfigService, s.logger)
+			adminV1.GET("/scoring-configs", scoringConfigsHandler.ListConfigs)
+			adminV1.POST("/scoring-configs", scoringConfigsHandler.CreateConfig)
+			adminV1.POST("/scoring-configs/:version/activate", scoringConfigsHandler.ActivateConfig)
 		}
 	}
 }
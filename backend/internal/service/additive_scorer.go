@@ -0,0 +1,64 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+// AdditiveScorer scores every bullish analyst event on its own by adding up
+// action, rating, target change and freshness points.
+type AdditiveScorer struct {
+	rules scoringRules
+}
+
+var _ Scorer = (*AdditiveScorer)(nil)
+
+func NewAdditiveScorer(config *model.ScoringConfig, normalizer ReferenceNormalizer) *AdditiveScorer {
+	return &AdditiveScorer{rules: newScoringRules(config, normalizer)}
+}
+
+func (a *AdditiveScorer) Name() string {
+	return "additive"
+}
+
+func (a *AdditiveScorer) Score(stocks []*model.Stock, asOf time.Time) []StockScore {
+	var stockScores []StockScore
+
+	for _, stock := range stocks {
+		if !a.rules.isBullish(stock) {
+			continue
+		}
+
+		score := a.scoreStock(stock, asOf)
+		stockScores = append(stockScores, StockScore{
+			Stock:       stock,
+			Score:       score,
+			Explanation: a.explain(stock, score),
+		})
+	}
+
+	return stockScores
+}
+
+func (a *AdditiveScorer) scoreStock(stock *model.Stock, asOf time.Time) int {
+	return a.rules.eventScore(stock) + a.rules.freshnessScore(stock.Time, asOf)
+}
+
+func (a *AdditiveScorer) explain(stock *model.Stock, score int) string {
+	reasons := []string{
+		a.rules.actionExplanation(stock.Action),
+		a.rules.ratingExplanation(stock.RatingTo),
+	}
+
+	if changePercent, ok := targetChangePercent(stock.TargetFrom, stock.TargetTo); ok && changePercent > 0 {
+		reasons = append(reasons, fmt.Sprintf("Target raised by %.1f%%", changePercent))
+	}
+	if stock.Brokerage != "" {
+		reasons = append(reasons, fmt.Sprintf("by %s", stock.Brokerage))
+	}
+	reasons = append(reasons, fmt.Sprintf("Score: %d/100", score))
+
+	return joinReasons(reasons)
+}
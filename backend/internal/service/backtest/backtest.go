@@ -0,0 +1,237 @@
+// Package backtest replays recommendation scoring against historical analyst
+// data and measures how the picked tickers were revised afterwards.
+package backtest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	repoInterfaces "github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+	"github.com/valeriapadilla/stock-insights/internal/validator"
+)
+
+// Recommender scores a set of analyst events as of a given date.
+type Recommender interface {
+	ReplayRecommendations(stocks []*model.Stock, params validator.RecommendationParams, asOf time.Time) ([]*model.Recommendation, error)
+}
+
+type Config struct {
+	From        time.Time
+	To          time.Time
+	StepDays    int
+	HorizonDays int
+	Strategies  []string
+	Params      validator.RecommendationParams
+}
+
+func (c Config) Validate() error {
+	if c.From.IsZero() || c.To.IsZero() {
+		return fmt.Errorf("from and to dates are required")
+	}
+	if c.To.Before(c.From) {
+		return fmt.Errorf("to date must not be before from date")
+	}
+	if c.StepDays <= 0 {
+		return fmt.Errorf("step must be at least 1 day, got %d", c.StepDays)
+	}
+	if c.HorizonDays <= 0 {
+		return fmt.Errorf("horizon must be at least 1 day, got %d", c.HorizonDays)
+	}
+	if len(c.Strategies) == 0 {
+		return fmt.Errorf("at least one strategy is required")
+	}
+	if c.Params.DaysBack <= 0 {
+		return fmt.Errorf("days back must be at least 1, got %d", c.Params.DaysBack)
+	}
+	return nil
+}
+
+// asOfDates returns every replay date from From to To, Step days apart.
+func (c Config) asOfDates() []time.Time {
+	var dates []time.Time
+	for asOf := c.From; !asOf.After(c.To); asOf = asOf.AddDate(0, 0, c.StepDays) {
+		dates = append(dates, asOf)
+	}
+	return dates
+}
+
+type Backtester struct {
+	recommender Recommender
+	stockRepo   repoInterfaces.StockRepository
+	logger      *logrus.Logger
+}
+
+func NewBacktester(recommender Recommender, stockRepo repoInterfaces.StockRepository, logger *logrus.Logger) *Backtester {
+	return &Backtester{
+		recommender: recommender,
+		stockRepo:   stockRepo,
+		logger:      logger,
+	}
+}
+
+// Run loads the analyst history the replay window needs and replays it.
+func (b *Backtester) Run(cfg Config) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	history, err := b.loadHistory(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Replay(cfg, history)
+}
+
+// Replay scores every as-of date with each strategy, using only events at or
+// before that date, then evaluates each pick against the events that followed
+// within the horizon.
+func (b *Backtester) Replay(cfg Config, history []*model.Stock) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	timeline := newTimeline(history)
+	horizon := time.Duration(cfg.HorizonDays) * 24 * time.Hour
+
+	report := &Report{
+		From:        cfg.From,
+		To:          cfg.To,
+		StepDays:    cfg.StepDays,
+		HorizonDays: cfg.HorizonDays,
+		DaysBack:    cfg.Params.DaysBack,
+		MinScore:    cfg.Params.MinScore,
+		MaxResults:  cfg.Params.MaxResults,
+	}
+
+	for _, strategy := range cfg.Strategies {
+		params := cfg.Params
+		params.Strategy = strategy
+		summary := StrategySummary{Strategy: strategy}
+
+		for _, asOf := range cfg.asOfDates() {
+			window := timeline.window(asOf.AddDate(0, 0, -cfg.Params.DaysBack), asOf)
+
+			recommendations, err := b.recommender.ReplayRecommendations(window, params, asOf)
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay %s as of %s: %w", strategy, asOf.Format("2006-01-02"), err)
+			}
+
+			summary.Dates++
+			for _, rec := range recommendations {
+				pick := timeline.evaluate(rec, asOf, horizon)
+				pick.Strategy = strategy
+				summary.add(pick)
+				report.Picks = append(report.Picks, pick)
+			}
+		}
+
+		summary.finalize()
+		report.Strategies = append(report.Strategies, summary)
+
+		b.logger.WithFields(logrus.Fields{
+			"strategy":          strategy,
+			"dates":             summary.Dates,
+			"picks":             summary.Picks,
+			"evaluated":         summary.Evaluated,
+			"hit_rate":          summary.HitRate,
+			"avg_target_change": summary.AvgTargetChangePercent,
+		}).Info("Backtest strategy completed")
+	}
+
+	return report, nil
+}
+
+// loadHistory reads every event from DaysBack before the first replay date to
+// the end of the last horizon.
+func (b *Backtester) loadHistory(cfg Config) ([]*model.Stock, error) {
+	dateFrom := cfg.From.AddDate(0, 0, -cfg.Params.DaysBack)
+	dateTo := cfg.To.AddDate(0, 0, cfg.HorizonDays)
+
+	search := &repoInterfaces.StockSearchFilters{
+		DateFrom: &dateFrom,
+		DateTo:   &dateTo,
+	}
+
+	total, err := b.stockRepo.GetStocksCount(repoInterfaces.GetStocksParams{Search: search})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count historical stocks: %w", err)
+	}
+
+	stocks, err := b.stockRepo.GetStocks(repoInterfaces.GetStocksParams{
+		Limit:  total + 100,
+		Search: search,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load historical stocks: %w", err)
+	}
+
+	b.logger.WithFields(logrus.Fields{
+		"date_from": dateFrom.Format("2006-01-02"),
+		"date_to":   dateTo.Format("2006-01-02"),
+		"stocks":    len(stocks),
+	}).Info("Loaded analyst history for backtest")
+
+	return stocks, nil
+}
+
+// ParseStrategies splits a comma-separated strategy list, dropping blanks and
+// duplicates.
+func ParseStrategies(value string) []string {
+	seen := make(map[string]bool)
+	var strategies []string
+
+	for _, strategy := range strings.Split(value, ",") {
+		strategy = strings.ToLower(strings.TrimSpace(strategy))
+		if strategy == "" || seen[strategy] {
+			continue
+		}
+		seen[strategy] = true
+		strategies = append(strategies, strategy)
+	}
+
+	return strategies
+}
+
+// timeline is the analyst history sorted by time, indexed by ticker.
+type timeline struct {
+	events   []*model.Stock
+	byTicker map[string][]*model.Stock
+}
+
+func newTimeline(history []*model.Stock) *timeline {
+	events := make([]*model.Stock, 0, len(history))
+	for _, stock := range history {
+		if stock != nil {
+			events = append(events, stock)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+
+	byTicker := make(map[string][]*model.Stock)
+	for _, stock := range events {
+		byTicker[stock.Ticker] = append(byTicker[stock.Ticker], stock)
+	}
+
+	return &timeline{events: events, byTicker: byTicker}
+}
+
+// window returns the events with from <= time <= asOf.
+func (t *timeline) window(from, asOf time.Time) []*model.Stock {
+	start := sort.Search(len(t.events), func(i int) bool {
+		return !t.events[i].Time.Before(from)
+	})
+	end := sort.Search(len(t.events), func(i int) bool {
+		return t.events[i].Time.After(asOf)
+	})
+	if start >= end {
+		return nil
+	}
+	return t.events[start:end]
+}
@@ -0,0 +1,131 @@
+package backtest
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service"
+	"github.com/valeriapadilla/stock-insights/internal/validator"
+)
+
+func newTestBacktester() *Backtester {
+	recommender := service.NewRecommendationService(nil, nil, nil, nil, nil, nil, logrus.New())
+	return NewBacktester(recommender, nil, logrus.New())
+}
+
+func day(d int) time.Time {
+	return time.Date(2025, time.March, d, 12, 0, 0, 0, time.UTC)
+}
+
+func TestBacktester_Replay(t *testing.T) {
+	history := []*model.Stock{
+		// Picked on the 10th, target raised again on the 15th
+		{Ticker: "AAPL", Brokerage: "Goldman", Action: "target raised by", RatingTo: "Buy", TargetFrom: "$100.00", TargetTo: "$160.00", Time: day(10)},
+		{Ticker: "AAPL", Brokerage: "Morgan", Action: "target raised by", RatingTo: "Buy", TargetFrom: "$160.00", TargetTo: "$176.00", Time: day(15)},
+		// Picked on the 10th, downgraded with a lower target on the 12th
+		{Ticker: "MSFT", Brokerage: "Goldman", Action: "target raised by", RatingTo: "Buy", TargetFrom: "$100.00", TargetTo: "$160.00", Time: day(9)},
+		{Ticker: "MSFT", Brokerage: "Barclays", Action: "downgraded by", RatingTo: "Sell", TargetFrom: "$160.00", TargetTo: "$120.00", Time: day(12)},
+		// Published after the as-of date, must not be picked
+		{Ticker: "NVDA", Brokerage: "Goldman", Action: "target raised by", RatingTo: "Buy", TargetFrom: "$100.00", TargetTo: "$160.00", Time: day(11)},
+	}
+
+	cfg := Config{
+		From:        day(10),
+		To:          day(10),
+		StepDays:    7,
+		HorizonDays: 30,
+		Strategies:  []string{"additive"},
+		Params:      validator.RecommendationParams{DaysBack: 7, MaxResults: 10, MinScore: 50},
+	}
+
+	report, err := newTestBacktester().Replay(cfg, history)
+	require.NoError(t, err)
+
+	require.Len(t, report.Picks, 2)
+	tickers := []string{report.Picks[0].Ticker, report.Picks[1].Ticker}
+	assert.ElementsMatch(t, []string{"AAPL", "MSFT"}, tickers)
+
+	for _, pick := range report.Picks {
+		require.True(t, pick.Evaluated)
+		require.NotNil(t, pick.TargetChangePercent)
+		switch pick.Ticker {
+		case "AAPL":
+			assert.True(t, pick.Hit)
+			assert.InDelta(t, 10.0, *pick.TargetChangePercent, 0.001)
+		case "MSFT":
+			assert.False(t, pick.Hit)
+			assert.Equal(t, 1, pick.Downgrades)
+			assert.InDelta(t, -25.0, *pick.TargetChangePercent, 0.001)
+		}
+	}
+
+	require.Len(t, report.Strategies, 1)
+	summary := report.Strategies[0]
+	assert.Equal(t, 1, summary.Dates)
+	assert.Equal(t, 2, summary.Evaluated)
+	assert.Equal(t, 1, summary.Hits)
+	assert.Equal(t, 50.0, summary.HitRate)
+	assert.Equal(t, -7.5, summary.AvgTargetChangePercent)
+}
+
+func TestBacktester_ReplayUnknownStrategy(t *testing.T) {
+	cfg := Config{
+		From:        day(10),
+		To:          day(10),
+		StepDays:    1,
+		HorizonDays: 7,
+		Strategies:  []string{"momentum"},
+		Params:      validator.RecommendationParams{DaysBack: 7, MaxResults: 10},
+	}
+
+	_, err := newTestBacktester().Replay(cfg, nil)
+	assert.Error(t, err)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{
+		From:        day(1),
+		To:          day(10),
+		StepDays:    1,
+		HorizonDays: 7,
+		Strategies:  []string{"additive"},
+		Params:      validator.RecommendationParams{DaysBack: 7},
+	}
+	assert.NoError(t, valid.Validate())
+	assert.Len(t, valid.asOfDates(), 10)
+
+	reversed := valid
+	reversed.From, reversed.To = valid.To, valid.From
+	assert.Error(t, reversed.Validate())
+
+	noStrategies := valid
+	noStrategies.Strategies = nil
+	assert.Error(t, noStrategies.Validate())
+}
+
+func TestParseStrategies(t *testing.T) {
+	assert.Equal(t, []string{"additive", "consensus"}, ParseStrategies(" Additive, consensus,,additive "))
+	assert.Empty(t, ParseStrategies(""))
+}
+
+func TestWriteCSV(t *testing.T) {
+	report := &Report{
+		Strategies: []StrategySummary{
+			{Strategy: "additive", Dates: 4, Picks: 10, Evaluated: 8, Hits: 6, HitRate: 75, AvgTargetChangePercent: 4.25},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, report))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "strategy,dates,picks,evaluated,hits,hit_rate,avg_target_change_percent,upgrades,downgrades", lines[0])
+	assert.Equal(t, "additive,4,10,8,6,75.00,4.25,0,0", lines[1])
+}
@@ -0,0 +1,67 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/utils"
+)
+
+// evaluate compares the pick's last known target price with the last target
+// published within the horizon, and counts the rating changes in between.
+// A pick is a hit when its target was raised; without a later target it is a
+// hit when upgrades outnumber downgrades.
+func (t *timeline) evaluate(rec *model.Recommendation, asOf time.Time, horizon time.Duration) PickResult {
+	pick := PickResult{
+		AsOf:   asOf,
+		Ticker: rec.Ticker,
+		Rank:   rec.Rank,
+		Score:  rec.Score,
+	}
+
+	var baseTarget float64
+	var hasBase, hasLater bool
+	var laterTarget float64
+	end := asOf.Add(horizon)
+
+	for _, event := range t.byTicker[rec.Ticker] {
+		price, ok := utils.TryParsePrice(event.TargetTo)
+
+		if !event.Time.After(asOf) {
+			if ok && price > 0 {
+				baseTarget, hasBase = price, true
+			}
+			continue
+		}
+		if event.Time.After(end) {
+			break
+		}
+
+		pick.SubsequentEvents++
+		if ok && price > 0 {
+			laterTarget, hasLater = price, true
+		}
+
+		switch model.NormalizeReferenceValue(event.Action) {
+		case "upgraded by":
+			pick.Upgrades++
+		case "downgraded by":
+			pick.Downgrades++
+		}
+	}
+
+	if pick.SubsequentEvents == 0 {
+		return pick
+	}
+	pick.Evaluated = true
+
+	if hasBase && hasLater {
+		change := (laterTarget - baseTarget) / baseTarget * 100
+		pick.TargetChangePercent = &change
+		pick.Hit = change > 0
+		return pick
+	}
+
+	pick.Hit = pick.Upgrades > pick.Downgrades
+	return pick
+}
@@ -0,0 +1,129 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+type Report struct {
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	StepDays    int               `json:"step_days"`
+	HorizonDays int               `json:"horizon_days"`
+	DaysBack    int               `json:"days_back"`
+	MinScore    int               `json:"min_score"`
+	MaxResults  int               `json:"max_results"`
+	Strategies  []StrategySummary `json:"strategies"`
+	Picks       []PickResult      `json:"picks"`
+}
+
+type StrategySummary struct {
+	Strategy string `json:"strategy"`
+	Dates    int    `json:"dates"`
+	Picks    int    `json:"picks"`
+	// Evaluated counts picks followed by at least one event within the horizon.
+	Evaluated              int     `json:"evaluated"`
+	Hits                   int     `json:"hits"`
+	HitRate                float64 `json:"hit_rate"`
+	AvgTargetChangePercent float64 `json:"avg_target_change_percent"`
+	Upgrades               int     `json:"upgrades"`
+	Downgrades             int     `json:"downgrades"`
+
+	targetChanges int
+	targetSum     float64
+}
+
+type PickResult struct {
+	Strategy            string    `json:"strategy"`
+	AsOf                time.Time `json:"as_of"`
+	Ticker              string    `json:"ticker"`
+	Rank                int       `json:"rank"`
+	Score               float64   `json:"score"`
+	SubsequentEvents    int       `json:"subsequent_events"`
+	TargetChangePercent *float64  `json:"target_change_percent,omitempty"`
+	Upgrades            int       `json:"upgrades"`
+	Downgrades          int       `json:"downgrades"`
+	Evaluated           bool      `json:"evaluated"`
+	Hit                 bool      `json:"hit"`
+}
+
+func (s *StrategySummary) add(pick PickResult) {
+	s.Picks++
+	s.Upgrades += pick.Upgrades
+	s.Downgrades += pick.Downgrades
+
+	if !pick.Evaluated {
+		return
+	}
+	s.Evaluated++
+	if pick.Hit {
+		s.Hits++
+	}
+	if pick.TargetChangePercent != nil {
+		s.targetChanges++
+		s.targetSum += *pick.TargetChangePercent
+	}
+}
+
+func (s *StrategySummary) finalize() {
+	if s.Evaluated > 0 {
+		s.HitRate = round2(float64(s.Hits) / float64(s.Evaluated) * 100)
+	}
+	if s.targetChanges > 0 {
+		s.AvgTargetChangePercent = round2(s.targetSum / float64(s.targetChanges))
+	}
+}
+
+func WriteJSON(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes one row per strategy summary.
+func WriteCSV(w io.Writer, report *Report) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{
+		"strategy", "dates", "picks", "evaluated", "hits", "hit_rate",
+		"avg_target_change_percent", "upgrades", "downgrades",
+	}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, summary := range report.Strategies {
+		row := []string{
+			summary.Strategy,
+			strconv.Itoa(summary.Dates),
+			strconv.Itoa(summary.Picks),
+			strconv.Itoa(summary.Evaluated),
+			strconv.Itoa(summary.Hits),
+			strconv.FormatFloat(summary.HitRate, 'f', 2, 64),
+			strconv.FormatFloat(summary.AvgTargetChangePercent, 'f', 2, 64),
+			strconv.Itoa(summary.Upgrades),
+			strconv.Itoa(summary.Downgrades),
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return nil
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
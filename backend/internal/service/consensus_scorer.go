@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+// ConsensusScorer aggregates every brokerage's most recent event per ticker.
+// Each brokerage gets one vote; a ticker is only scored when a majority of
+// them is bullish, and its score is the average event score plus the
+// freshness of the latest event.
+type ConsensusScorer struct {
+	rules scoringRules
+}
+
+var _ Scorer = (*ConsensusScorer)(nil)
+
+func NewConsensusScorer(config *model.ScoringConfig, normalizer ReferenceNormalizer) *ConsensusScorer {
+	return &ConsensusScorer{rules: newScoringRules(config, normalizer)}
+}
+
+func (c *ConsensusScorer) Name() string {
+	return "consensus"
+}
+
+func (c *ConsensusScorer) Score(stocks []*model.Stock, asOf time.Time) []StockScore {
+	votesByTicker := c.latestVotes(stocks)
+
+	tickers := make([]string, 0, len(votesByTicker))
+	for ticker := range votesByTicker {
+		tickers = append(tickers, ticker)
+	}
+	sort.Strings(tickers)
+
+	var stockScores []StockScore
+	for _, ticker := range tickers {
+		if score, ok := c.scoreTicker(votesByTicker[ticker], asOf); ok {
+			stockScores = append(stockScores, score)
+		}
+	}
+
+	return stockScores
+}
+
+// latestVotes keeps the most recent event of each brokerage per ticker.
+func (c *ConsensusScorer) latestVotes(stocks []*model.Stock) map[string][]*model.Stock {
+	latest := make(map[string]map[string]*model.Stock)
+
+	for _, stock := range stocks {
+		if stock == nil || stock.Ticker == "" {
+			continue
+		}
+
+		byBrokerage, ok := latest[stock.Ticker]
+		if !ok {
+			byBrokerage = make(map[string]*model.Stock)
+			latest[stock.Ticker] = byBrokerage
+		}
+
+		key := brokerageKey(stock)
+		if current, ok := byBrokerage[key]; !ok || stock.Time.After(current.Time) {
+			byBrokerage[key] = stock
+		}
+	}
+
+	votes := make(map[string][]*model.Stock, len(latest))
+	for ticker, byBrokerage := range latest {
+		for _, stock := range byBrokerage {
+			votes[ticker] = append(votes[ticker], stock)
+		}
+		sort.Slice(votes[ticker], func(i, j int) bool {
+			return votes[ticker][i].Time.After(votes[ticker][j].Time)
+		})
+	}
+
+	return votes
+}
+
+func (c *ConsensusScorer) scoreTicker(votes []*model.Stock, asOf time.Time) (StockScore, bool) {
+	bullish := 0
+	eventTotal := 0
+	targetTotal := 0.0
+	targetCount := 0
+
+	for _, vote := range votes {
+		if c.rules.isBullish(vote) {
+			bullish++
+		}
+		eventTotal += c.rules.eventScore(vote)
+
+		if changePercent, ok := targetChangePercent(vote.TargetFrom, vote.TargetTo); ok {
+			targetTotal += changePercent
+			targetCount++
+		}
+	}
+
+	if bullish*2 <= len(votes) {
+		return StockScore{}, false
+	}
+
+	latest := votes[0]
+	averageEvent := float64(eventTotal) / float64(len(votes))
+	score := int(math.Round(averageEvent)) + c.rules.freshnessScore(latest.Time, asOf)
+
+	reasons := []string{
+		fmt.Sprintf("Consensus of %d brokerages (%d bullish)", len(votes), bullish),
+	}
+	if targetCount > 0 {
+		reasons = append(reasons, fmt.Sprintf("Average target change %+.1f%%", targetTotal/float64(targetCount)))
+	}
+	if latest.Brokerage != "" {
+		reasons = append(reasons, fmt.Sprintf("latest by %s", latest.Brokerage))
+	}
+	reasons = append(reasons, fmt.Sprintf("Score: %d/100", score))
+
+	return StockScore{
+		Stock:       latest,
+		Score:       score,
+		Explanation: joinReasons(reasons),
+	}, true
+}
+
+func brokerageKey(stock *model.Stock) string {
+	if name := model.NormalizeReferenceValue(stock.Brokerage); name != "" || stock.BrokerageID == nil {
+		return name
+	}
+	return strconv.FormatInt(*stock.BrokerageID, 10)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/importer"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+	workerInterfaces "github.com/valeriapadilla/stock-insights/internal/worker/interfaces"
+)
+
+type ImportService struct {
+	dataWorker workerInterfaces.DataWorker
+	logger     *logrus.Logger
+}
+
+var _ interfaces.ImportServiceInterface = (*ImportService)(nil)
+
+func NewImportService(dataWorker workerInterfaces.DataWorker, logger *logrus.Logger) *ImportService {
+	return &ImportService{
+		dataWorker: dataWorker,
+		logger:     logger,
+	}
+}
+
+// ImportStocks reads events from r and stores them through the data worker,
+// so they get the same reference mapping, validation and source precedence as
+// ingested events. Records that cannot be read or fail validation are listed
+// in the report; a dry run stores nothing.
+func (s *ImportService) ImportStocks(ctx context.Context, r io.Reader, options model.ImportOptions) (*model.ImportReport, error) {
+	if !options.Format.IsValid() {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported import format %q, expected csv, ndjson or json", options.Format), nil)
+	}
+
+	source := strings.TrimSpace(options.Source)
+	if source == "" {
+		source = model.DefaultImportSource
+	}
+
+	start := time.Now()
+	parsed, err := importer.Read(r, options.Format, options.Columns)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid import file: %v", err), err)
+	}
+
+	report := &model.ImportReport{
+		Source:     source,
+		Format:     options.Format,
+		DryRun:     options.DryRun,
+		Rows:       parsed.Rows,
+		Unreadable: len(parsed.Errors),
+		Errors:     []model.ImportRowError{},
+	}
+	for _, rowErr := range parsed.Errors {
+		report.AddError(rowErr)
+	}
+
+	if len(parsed.Stocks) > 0 {
+		store := s.dataWorker.StoreStocks
+		if options.DryRun {
+			store = s.dataWorker.PreviewStocks
+		}
+
+		result, err := store(ctx, source, parsed.Stocks)
+		if err != nil {
+			s.logger.WithError(err).WithField("source", source).Error("Failed to import stocks")
+			return nil, err
+		}
+
+		report.Inserted = result.Inserted
+		report.Updated = result.Updated
+		report.Unchanged = result.Unchanged
+		report.Rejected = result.Rejected
+		for _, rejection := range result.Rejections {
+			rowErr := model.ImportRowError{Ticker: rejection.Ticker, Reason: rejection.Reason}
+			if !rejection.Time.IsZero() {
+				eventTime := rejection.Time
+				rowErr.Time = &eventTime
+			}
+			report.AddError(rowErr)
+		}
+	}
+	report.Duration = time.Since(start).String()
+
+	s.logger.WithFields(logrus.Fields{
+		"source":     report.Source,
+		"format":     report.Format,
+		"dry_run":    report.DryRun,
+		"rows":       report.Rows,
+		"unreadable": report.Unreadable,
+		"inserted":   report.Inserted,
+		"updated":    report.Updated,
+		"unchanged":  report.Unchanged,
+		"rejected":   report.Rejected,
+	}).Info("Imported stocks")
+
+	return report, nil
+}
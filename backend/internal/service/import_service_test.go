@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appErrors "github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+const importCSV = "ticker,company,target_to,time\n" +
+	"AAPL,Apple Inc.,$220.00,2025-03-12\n" +
+	"MSFT,,$450.00,2025-03-11\n" +
+	"NVDA,NVIDIA,$900.00,someday\n"
+
+func TestImportService_ImportStocks(t *testing.T) {
+	eventTime := time.Date(2025, time.March, 11, 0, 0, 0, 0, time.UTC)
+	twoStocks := mock.MatchedBy(func(stocks []model.Stock) bool {
+		return len(stocks) == 2 && stocks[0].Ticker == "AAPL" && stocks[1].Ticker == "MSFT"
+	})
+	upsert := &model.BulkUpsertResult{
+		Inserted: 1,
+		Rejected: 1,
+		Rejections: []model.StockRejection{
+			{Ticker: "MSFT", Time: eventTime, Reason: "company is required"},
+		},
+	}
+
+	t.Run("stores events", func(t *testing.T) {
+		dataWorker := &MockDataWorker{}
+		dataWorker.On("StoreStocks", mock.Anything, "archive", twoStocks).Return(upsert, nil)
+		service := NewImportService(dataWorker, logrus.New())
+
+		report, err := service.ImportStocks(context.Background(), strings.NewReader(importCSV), model.ImportOptions{
+			Source: "archive",
+			Format: model.ImportFormatCSV,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "archive", report.Source)
+		assert.False(t, report.DryRun)
+		assert.Equal(t, 3, report.Rows)
+		assert.Equal(t, 1, report.Unreadable)
+		assert.Equal(t, 1, report.Inserted)
+		assert.Equal(t, 1, report.Rejected)
+
+		// Unreadable rows come first, then validation rejects
+		require.Len(t, report.Errors, 2)
+		assert.Equal(t, 4, report.Errors[0].Line)
+		assert.Equal(t, "MSFT", report.Errors[1].Ticker)
+		assert.Equal(t, &eventTime, report.Errors[1].Time)
+		assert.Equal(t, "company is required", report.Errors[1].Reason)
+
+		dataWorker.AssertExpectations(t)
+	})
+
+	t.Run("dry run previews events", func(t *testing.T) {
+		dataWorker := &MockDataWorker{}
+		dataWorker.On("PreviewStocks", mock.Anything, model.DefaultImportSource, twoStocks).Return(upsert, nil)
+		service := NewImportService(dataWorker, logrus.New())
+
+		report, err := service.ImportStocks(context.Background(), strings.NewReader(importCSV), model.ImportOptions{
+			Format: model.ImportFormatCSV,
+			DryRun: true,
+		})
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, model.DefaultImportSource, report.Source)
+		assert.Equal(t, 1, report.Inserted)
+
+		dataWorker.AssertExpectations(t)
+		dataWorker.AssertNotCalled(t, "StoreStocks", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestImportService_ImportStocksValidation(t *testing.T) {
+	service := NewImportService(&MockDataWorker{}, logrus.New())
+
+	_, err := service.ImportStocks(context.Background(), strings.NewReader(importCSV), model.ImportOptions{Format: "xml"})
+	require.Error(t, err)
+	assert.Equal(t, appErrors.ErrorTypeValidation, err.(*appErrors.AppError).Type)
+
+	// The header lacks the mapped column
+	_, err = service.ImportStocks(context.Background(), strings.NewReader(importCSV), model.ImportOptions{
+		Format:  model.ImportFormatCSV,
+		Columns: map[string]string{"ticker": "Symbol"},
+	})
+	require.Error(t, err)
+	assert.Equal(t, appErrors.ErrorTypeValidation, err.(*appErrors.AppError).Type)
+
+	// Nothing readable means nothing to store
+	report, err := service.ImportStocks(context.Background(), strings.NewReader(""), model.ImportOptions{Format: model.ImportFormatNDJSON})
+	require.NoError(t, err)
+	assert.Zero(t, report.Rows)
+	assert.Empty(t, report.Errors)
+}
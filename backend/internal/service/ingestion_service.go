@@ -2,35 +2,318 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/client"
 	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	repoInterfaces "github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
 	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
 	workerInterfaces "github.com/valeriapadilla/stock-insights/internal/worker/interfaces"
 )
 
 type IngestionService struct {
-	dataWorker workerInterfaces.DataWorker
-	logger     *logrus.Logger
+	dataWorker  workerInterfaces.DataWorker
+	runRepo     repoInterfaces.IngestionRunRepository
+	rejectRepo  repoInterfaces.StockRejectRepository
+	qualityRepo repoInterfaces.DataQualityRepository
+	logger      *logrus.Logger
 }
 
 var _ interfaces.IngestionServiceInterface = (*IngestionService)(nil)
 
-func NewIngestionService(dataWorker workerInterfaces.DataWorker, logger *logrus.Logger) *IngestionService {
+func NewIngestionService(
+	dataWorker workerInterfaces.DataWorker,
+	runRepo repoInterfaces.IngestionRunRepository,
+	rejectRepo repoInterfaces.StockRejectRepository,
+	qualityRepo repoInterfaces.DataQualityRepository,
+	logger *logrus.Logger,
+) *IngestionService {
 	return &IngestionService{
-		dataWorker: dataWorker,
-		logger:     logger,
+		dataWorker:  dataWorker,
+		runRepo:     runRepo,
+		rejectRepo:  rejectRepo,
+		qualityRepo: qualityRepo,
+		logger:      logger,
 	}
 }
 
-func (s *IngestionService) TriggerIngestionAsync(ctx context.Context) error {
+// TriggerIngestionAsync ingests every source, reporting progress as it goes,
+// and returns the results of the sources it reached, even when it fails.
+func (s *IngestionService) TriggerIngestionAsync(ctx context.Context, progress model.ProgressFunc) ([]*model.IngestionResult, error) {
 	s.logger.Info("Starting async ingestion process")
 
-	if err := s.dataWorker.FetchAndProcessStocks(ctx); err != nil {
+	results, err := s.dataWorker.FetchAndProcessStocks(ctx, progress)
+	for _, result := range results {
+		s.logResult(result)
+	}
+	if err != nil {
 		s.logger.WithError(err).Error("Async ingestion failed")
-		return errors.NewInternalError("Failed to process stocks", err)
+		if stderrors.Is(err, client.ErrCircuitOpen) {
+			return results, err
+		}
+		return results, errors.NewInternalError("Failed to process stocks", err)
 	}
 
 	s.logger.Info("Async ingestion completed successfully")
-	return nil
+	return results, nil
+}
+
+func (s *IngestionService) TriggerSourceIngestionAsync(ctx context.Context, source string, progress model.ProgressFunc) (*model.IngestionResult, error) {
+	s.logger.WithField("source", source).Info("Starting async ingestion process")
+
+	result, err := s.dataWorker.FetchAndProcessSource(ctx, source, progress)
+	if result != nil {
+		s.logResult(result)
+	}
+	if err != nil {
+		s.logger.WithError(err).WithField("source", source).Error("Async ingestion failed")
+		// Keep the breaker's EXTERNAL_ERROR so the job says why it failed fast
+		if stderrors.Is(err, client.ErrCircuitOpen) {
+			return result, err
+		}
+		return result, errors.NewInternalError(fmt.Sprintf("Failed to process stocks from %s", source), err)
+	}
+
+	s.logger.WithField("source", source).Info("Async ingestion completed successfully")
+	return result, nil
+}
+
+func (s *IngestionService) GetSources() ([]*model.IngestionSource, error) {
+	return s.dataWorker.GetSources()
+}
+
+// GetSourcesHealth checks every source and reports its circuit breaker.
+func (s *IngestionService) GetSourcesHealth(ctx context.Context) []*model.SourceHealth {
+	return s.dataWorker.GetSourcesHealth(ctx)
+}
+
+func (s *IngestionService) GetSource(name string) (*model.IngestionSource, error) {
+	sources, err := s.dataWorker.GetSources()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, source := range sources {
+		if source.Name == name {
+			return source, nil
+		}
+	}
+
+	return nil, errors.NewNotFoundError(fmt.Sprintf("Ingestion source %s not found", name), nil)
+}
+
+// GetRuns lists ingestion runs newest first, for one source or, when source
+// is empty, for all of them.
+func (s *IngestionService) GetRuns(source string, limit, offset int) ([]*model.IngestionRun, int, error) {
+	runs, err := s.runRepo.GetRuns(source, limit, offset)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get ingestion runs")
+		return nil, 0, errors.NewDatabaseError("failed to get ingestion runs", err)
+	}
+
+	total, err := s.runRepo.GetRunsCount(source)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to count ingestion runs")
+		return nil, 0, errors.NewDatabaseError("failed to count ingestion runs", err)
+	}
+
+	return runs, total, nil
+}
+
+func (s *IngestionService) GetRun(runID string) (*model.IngestionRun, error) {
+	if _, err := uuid.Parse(runID); err != nil {
+		return nil, errors.NewValidationError("run id must be a valid UUID", err)
+	}
+
+	run, err := s.runRepo.GetRunByID(runID)
+	if err != nil {
+		s.logger.WithError(err).WithField("run_id", runID).Error("Failed to get ingestion run")
+		return nil, errors.NewDatabaseError("failed to get ingestion run", err)
+	}
+	if run == nil {
+		return nil, errors.NewNotFoundError("ingestion run not found", nil)
+	}
+
+	return run, nil
+}
+
+// GetQualityReport returns the data quality verdict of a run with its
+// findings matching filter, errors first.
+func (s *IngestionService) GetQualityReport(runID string, filter model.DataQualityFindingFilter, limit, offset int) (*model.DataQualityReport, error) {
+	if filter.Severity != "" && !filter.Severity.IsValid() {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid severity %q", filter.Severity), nil)
+	}
+
+	run, err := s.GetRun(runID)
+	if err != nil {
+		return nil, err
+	}
+
+	findings, err := s.qualityRepo.GetFindings(runID, filter, limit, offset)
+	if err != nil {
+		s.logger.WithError(err).WithField("run_id", runID).Error("Failed to get data quality findings")
+		return nil, errors.NewDatabaseError("failed to get data quality findings", err)
+	}
+	if findings == nil {
+		findings = []*model.DataQualityFinding{}
+	}
+
+	total, err := s.qualityRepo.GetFindingsCount(runID, filter)
+	if err != nil {
+		s.logger.WithError(err).WithField("run_id", runID).Error("Failed to count data quality findings")
+		return nil, errors.NewDatabaseError("failed to count data quality findings", err)
+	}
+
+	return &model.DataQualityReport{
+		RunID:    run.ID,
+		Source:   run.Source,
+		Status:   run.QualityStatus,
+		Errors:   run.QualityErrors,
+		Warnings: run.QualityWarnings,
+		Findings: findings,
+		Total:    total,
+	}, nil
+}
+
+// GetRejects lists quarantined events matching filter, newest first.
+func (s *IngestionService) GetRejects(filter model.StockRejectFilter, limit, offset int) ([]*model.StockReject, int, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, 0, errors.NewValidationError(fmt.Sprintf("invalid reject status %q", filter.Status), nil)
+	}
+	if filter.RunID != "" {
+		if _, err := uuid.Parse(filter.RunID); err != nil {
+			return nil, 0, errors.NewValidationError("run id must be a valid UUID", err)
+		}
+	}
+
+	rejects, err := s.rejectRepo.GetRejects(filter, limit, offset)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get stock rejects")
+		return nil, 0, errors.NewDatabaseError("failed to get stock rejects", err)
+	}
+
+	total, err := s.rejectRepo.GetRejectsCount(filter)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to count stock rejects")
+		return nil, 0, errors.NewDatabaseError("failed to count stock rejects", err)
+	}
+
+	return rejects, total, nil
+}
+
+func (s *IngestionService) GetReject(rejectID string) (*model.StockReject, error) {
+	if _, err := uuid.Parse(rejectID); err != nil {
+		return nil, errors.NewValidationError("reject id must be a valid UUID", err)
+	}
+
+	reject, err := s.rejectRepo.GetRejectByID(rejectID)
+	if err != nil {
+		s.logger.WithError(err).WithField("reject_id", rejectID).Error("Failed to get stock reject")
+		return nil, errors.NewDatabaseError("failed to get stock reject", err)
+	}
+	if reject == nil {
+		return nil, errors.NewNotFoundError("stock reject not found", nil)
+	}
+
+	return reject, nil
+}
+
+// ReplayReject stores a pending reject as a stock event of its source. A
+// non-empty payload replaces the stored one first, so the event can be fixed.
+// If the event still fails validation the reject stays pending with the new
+// error and a validation error is returned.
+func (s *IngestionService) ReplayReject(ctx context.Context, rejectID string, payload json.RawMessage) (*model.StockReject, error) {
+	reject, err := s.pendingReject(rejectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) > 0 {
+		reject.Payload = payload
+	}
+
+	var stock model.Stock
+	if err := json.Unmarshal(reject.Payload, &stock); err != nil {
+		return nil, errors.NewValidationError("payload is not a valid stock event", err)
+	}
+	reject.Ticker = stock.Ticker
+	reject.EventTime = nil
+	if !stock.Time.IsZero() {
+		reject.EventTime = &stock.Time
+	}
+
+	result, err := s.dataWorker.StoreStocks(ctx, reject.Source, []model.Stock{stock})
+	if err != nil {
+		s.logger.WithError(err).WithField("reject_id", rejectID).Error("Failed to replay stock reject")
+		return nil, err
+	}
+
+	if result.Rejected > 0 {
+		reject.ErrorMessage = result.Rejections[0].Reason
+		if err := s.rejectRepo.UpdateReject(reject); err != nil {
+			return nil, errors.NewDatabaseError("failed to update stock reject", err)
+		}
+		return nil, errors.NewValidationError(fmt.Sprintf("stock event still fails validation: %s", reject.ErrorMessage), nil)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"reject_id": rejectID,
+		"source":    reject.Source,
+		"ticker":    reject.Ticker,
+	}).Info("Replayed stock reject")
+
+	return s.resolveReject(reject, model.StockRejectStatusReplayed)
+}
+
+// DiscardReject marks a pending reject as discarded without storing it.
+func (s *IngestionService) DiscardReject(rejectID string) (*model.StockReject, error) {
+	reject, err := s.pendingReject(rejectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.resolveReject(reject, model.StockRejectStatusDiscarded)
+}
+
+func (s *IngestionService) pendingReject(rejectID string) (*model.StockReject, error) {
+	reject, err := s.GetReject(rejectID)
+	if err != nil {
+		return nil, err
+	}
+	if reject.Status != model.StockRejectStatusPending {
+		return nil, errors.NewValidationError(fmt.Sprintf("stock reject is already %s", reject.Status), nil)
+	}
+	return reject, nil
+}
+
+func (s *IngestionService) resolveReject(reject *model.StockReject, status model.StockRejectStatus) (*model.StockReject, error) {
+	now := time.Now()
+	reject.Status = status
+	reject.ResolvedAt = &now
+
+	if err := s.rejectRepo.UpdateReject(reject); err != nil {
+		s.logger.WithError(err).WithField("reject_id", reject.ID).Error("Failed to update stock reject")
+		return nil, errors.NewDatabaseError("failed to update stock reject", err)
+	}
+
+	return reject, nil
+}
+
+func (s *IngestionService) logResult(result *model.IngestionResult) {
+	s.logger.WithFields(logrus.Fields{
+		"source":           result.Source,
+		"pages_fetched":    result.PagesFetched,
+		"pages_skipped":    result.PagesSkipped,
+		"pages_resumed":    result.PagesResumed,
+		"stocks_inserted":  result.StocksInserted,
+		"stocks_updated":   result.StocksUpdated,
+		"stocks_unchanged": result.StocksUnchanged,
+		"stocks_rejected":  result.StocksRejected,
+	}).Info("Source ingestion finished")
 }
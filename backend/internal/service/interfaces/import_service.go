@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type ImportServiceInterface interface {
+	ImportStocks(ctx context.Context, r io.Reader, options model.ImportOptions) (*model.ImportReport, error)
+}
@@ -2,8 +2,22 @@ package interfaces
 
 import (
 	"context"
+	"encoding/json"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
 )
 
 type IngestionServiceInterface interface {
-	TriggerIngestionAsync(ctx context.Context) error
+	TriggerIngestionAsync(ctx context.Context, progress model.ProgressFunc) ([]*model.IngestionResult, error)
+	TriggerSourceIngestionAsync(ctx context.Context, source string, progress model.ProgressFunc) (*model.IngestionResult, error)
+	GetSources() ([]*model.IngestionSource, error)
+	GetSource(name string) (*model.IngestionSource, error)
+	GetSourcesHealth(ctx context.Context) []*model.SourceHealth
+	GetRuns(source string, limit, offset int) ([]*model.IngestionRun, int, error)
+	GetRun(runID string) (*model.IngestionRun, error)
+	GetQualityReport(runID string, filter model.DataQualityFindingFilter, limit, offset int) (*model.DataQualityReport, error)
+	GetRejects(filter model.StockRejectFilter, limit, offset int) ([]*model.StockReject, int, error)
+	GetReject(rejectID string) (*model.StockReject, error)
+	ReplayReject(ctx context.Context, rejectID string, payload json.RawMessage) (*model.StockReject, error)
+	DiscardReject(rejectID string) (*model.StockReject, error)
 }
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type PriceSyncParams struct {
+	// Tickers defaults to every ticker with analyst coverage.
+	Tickers []string
+	From    time.Time
+	To      time.Time
+}
+
+type PriceSyncResult struct {
+	Provider string   `json:"provider"`
+	Tickers  int      `json:"tickers"`
+	Bars     int      `json:"bars"`
+	Failed   []string `json:"failed,omitempty"`
+}
+
+type PriceServiceInterface interface {
+	SyncPrices(ctx context.Context, params PriceSyncParams) (*PriceSyncResult, error)
+	GetLatestCloses(tickers []string) (map[string]*model.PriceBar, error)
+}
@@ -1,12 +1,19 @@
 package interfaces
 
 import (
+	"context"
+	"time"
+
 	"github.com/valeriapadilla/stock-insights/internal/model"
 	"github.com/valeriapadilla/stock-insights/internal/validator"
 )
 
 type RecommendationServiceInterface interface {
-	CalculateRecommendations(params validator.RecommendationParams) ([]*model.Recommendation, error)
+	CalculateRecommendations(ctx context.Context, params validator.RecommendationParams) (*model.RecommendationRun, error)
 	GetLatestRecommendations(limit int) ([]*model.Recommendation, error)
-	SaveRecommendations(recommendations []*model.Recommendation) error
+	SaveRecommendations(run *model.RecommendationRun) error
+	FailRun(run *model.RecommendationRun, cause error)
+	GetRuns(limit, offset int) ([]*model.RecommendationRun, int, error)
+	GetRun(runID string) (*model.RecommendationRun, error)
+	GetLastRunTime() (*time.Time, error)
 }
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type ReferenceServiceInterface interface {
+	MapStocks(stocks []*model.Stock) error
+	CanonicalRating(raw string) string
+	CanonicalAction(raw string) string
+	RatingRank(raw string) (int, bool)
+	GetUnmappedValues(kind model.ReferenceKind, limit, offset int) ([]*model.UnmappedReferenceValue, error)
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+type CreateScoringConfigParams struct {
+	Version     string
+	Description string
+	Config      *model.ScoringConfig
+}
+
+type ScoringConfigServiceInterface interface {
+	GetActiveConfig() (*model.ScoringConfig, string, error)
+	ListConfigs(limit, offset int) ([]*model.ScoringConfigVersion, int, error)
+	CreateConfig(params CreateScoringConfigParams) (*model.ScoringConfigVersion, error)
+	ActivateConfig(version string) (*model.ScoringConfigVersion, error)
+}
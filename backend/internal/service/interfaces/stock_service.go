@@ -17,8 +17,18 @@ type StockSearchParams struct {
 	Offset   int      `json:"offset"`
 }
 
+type StockHistoryParams struct {
+	Ticket   string `json:"ticket"`
+	DateFrom string `json:"date_from"`
+	DateTo   string `json:"date_to"`
+	Limit    int    `json:"limit"`
+	Offset   int    `json:"offset"`
+}
+
 type StockServiceInterface interface {
 	ListStocks(limit, offset int, sort, order string) ([]*model.Stock, int, error)
 	GetStock(ticket string) (*model.Stock, error)
+	GetStockHistory(params StockHistoryParams) ([]*model.Stock, int, error)
 	SearchStocks(params StockSearchParams) ([]*model.Stock, int, error)
+	GetStockRevisions(ticket, eventTime string) ([]*model.StockRevision, error)
 }
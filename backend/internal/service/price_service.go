@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/client"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	repoInterfaces "github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+type PriceService struct {
+	priceRepo repoInterfaces.PriceRepository
+	provider  client.PriceProvider
+	logger    *logrus.Logger
+}
+
+var _ interfaces.PriceServiceInterface = (*PriceService)(nil)
+
+// NewPriceService builds the price service. provider may be nil for read-only
+// use, in which case SyncPrices fails.
+func NewPriceService(priceRepo repoInterfaces.PriceRepository, provider client.PriceProvider, logger *logrus.Logger) *PriceService {
+	return &PriceService{
+		priceRepo: priceRepo,
+		provider:  provider,
+		logger:    logger,
+	}
+}
+
+// SyncPrices fetches daily bars for each ticker and stores them. Tickers that
+// already have prices resume from the day after their latest bar. A failing
+// ticker is recorded in the result and does not stop the others.
+func (s *PriceService) SyncPrices(ctx context.Context, params interfaces.PriceSyncParams) (*interfaces.PriceSyncResult, error) {
+	if s.provider == nil {
+		return nil, errors.NewValidationError("no price provider configured", nil)
+	}
+	if params.From.IsZero() || params.To.IsZero() || params.To.Before(params.From) {
+		return nil, errors.NewValidationError("a valid from/to date range is required", nil)
+	}
+
+	tickers := normalizeTickers(params.Tickers)
+	if len(tickers) == 0 {
+		tracked, err := s.priceRepo.GetTrackedTickers()
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to get tracked tickers")
+			return nil, errors.NewDatabaseError("failed to get tracked tickers", err)
+		}
+		tickers = tracked
+	}
+
+	result := &interfaces.PriceSyncResult{Provider: s.provider.Name()}
+
+	for _, ticker := range tickers {
+		if err := ctx.Err(); err != nil {
+			return result, errors.NewInternalError("price sync cancelled", err)
+		}
+
+		written, err := s.syncTicker(ctx, ticker, params)
+		if err != nil {
+			s.logger.WithError(err).WithField("ticker", ticker).Warn("Failed to sync prices")
+			result.Failed = append(result.Failed, ticker)
+			continue
+		}
+
+		result.Tickers++
+		result.Bars += written
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"provider": result.Provider,
+		"tickers":  result.Tickers,
+		"bars":     result.Bars,
+		"failed":   len(result.Failed),
+	}).Info("Price sync completed")
+
+	return result, nil
+}
+
+func (s *PriceService) syncTicker(ctx context.Context, ticker string, params interfaces.PriceSyncParams) (int, error) {
+	from := params.From
+
+	latest, err := s.priceRepo.GetLatestPriceDate(ticker)
+	if err != nil {
+		return 0, err
+	}
+	if latest != nil {
+		if next := latest.AddDate(0, 0, 1); next.After(from) {
+			from = next
+		}
+	}
+	if from.After(params.To) {
+		return 0, nil
+	}
+
+	bars, err := s.provider.GetDailyBars(ctx, ticker, from, params.To)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, bar := range bars {
+		bar.Ticker = ticker
+	}
+
+	return s.priceRepo.UpsertPrices(bars)
+}
+
+func (s *PriceService) GetLatestCloses(tickers []string) (map[string]*model.PriceBar, error) {
+	closes, err := s.priceRepo.GetLatestCloses(normalizeTickers(tickers))
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get latest closes")
+		return nil, errors.NewDatabaseError("failed to get latest closes", err)
+	}
+	return closes, nil
+}
+
+func normalizeTickers(tickers []string) []string {
+	seen := make(map[string]bool, len(tickers))
+	var normalized []string
+
+	for _, ticker := range tickers {
+		ticker = strings.ToUpper(strings.TrimSpace(ticker))
+		if ticker == "" || seen[ticker] {
+			continue
+		}
+		seen[ticker] = true
+		normalized = append(normalized, ticker)
+	}
+
+	return normalized
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+func TestPriceService_SyncPrices(t *testing.T) {
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+	latestAAPL := time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC)
+
+	priceRepo := &MockPriceRepository{}
+	provider := &MockPriceProvider{}
+
+	priceRepo.On("GetTrackedTickers").Return([]string{"AAPL", "MSFT", "NVDA"}, nil)
+
+	// AAPL resumes the day after its latest stored bar
+	priceRepo.On("GetLatestPriceDate", "AAPL").Return(&latestAAPL, nil)
+	provider.On("GetDailyBars", "AAPL", latestAAPL.AddDate(0, 0, 1), to).Return([]*model.PriceBar{
+		{Date: latestAAPL.AddDate(0, 0, 1), Close: 101},
+		{Date: latestAAPL.AddDate(0, 0, 2), Close: 102},
+	}, nil)
+	priceRepo.On("UpsertPrices", mock.MatchedBy(func(bars []*model.PriceBar) bool {
+		return len(bars) == 2 && bars[0].Ticker == "AAPL"
+	})).Return(2, nil)
+
+	priceRepo.On("GetLatestPriceDate", "MSFT").Return(nil, nil)
+	provider.On("GetDailyBars", "MSFT", from, to).Return(nil, assert.AnError)
+
+	priceRepo.On("GetLatestPriceDate", "NVDA").Return(nil, nil)
+	provider.On("GetDailyBars", "NVDA", from, to).Return([]*model.PriceBar{}, nil)
+	priceRepo.On("UpsertPrices", []*model.PriceBar{}).Return(0, nil)
+
+	service := NewPriceService(priceRepo, provider, logrus.New())
+
+	result, err := service.SyncPrices(context.Background(), interfaces.PriceSyncParams{From: from, To: to})
+	require.NoError(t, err)
+	assert.Equal(t, "mock", result.Provider)
+	assert.Equal(t, 2, result.Tickers)
+	assert.Equal(t, 2, result.Bars)
+	assert.Equal(t, []string{"MSFT"}, result.Failed)
+
+	priceRepo.AssertExpectations(t)
+	provider.AssertExpectations(t)
+}
+
+func TestPriceService_SyncPricesValidation(t *testing.T) {
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	withoutProvider := NewPriceService(&MockPriceRepository{}, nil, logrus.New())
+	_, err := withoutProvider.SyncPrices(context.Background(), interfaces.PriceSyncParams{From: from, To: from})
+	assert.Error(t, err)
+
+	service := NewPriceService(&MockPriceRepository{}, &MockPriceProvider{}, logrus.New())
+	_, err = service.SyncPrices(context.Background(), interfaces.PriceSyncParams{From: from, To: from.AddDate(0, 0, -1)})
+	assert.Error(t, err)
+}
+
+func TestPriceService_SyncPricesUpToDate(t *testing.T) {
+	today := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	priceRepo := &MockPriceRepository{}
+	provider := &MockPriceProvider{}
+	priceRepo.On("GetLatestPriceDate", "AAPL").Return(&today, nil)
+
+	service := NewPriceService(priceRepo, provider, logrus.New())
+
+	result, err := service.SyncPrices(context.Background(), interfaces.PriceSyncParams{
+		Tickers: []string{" aapl ", "AAPL"},
+		From:    today.AddDate(0, 0, -7),
+		To:      today,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Tickers)
+	assert.Equal(t, 0, result.Bars)
+
+	provider.AssertNotCalled(t, "GetDailyBars", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRecommendationService_GetLatestRecommendationsAttachesUpside(t *testing.T) {
+	target := 120.0
+	recRepo := &MockRecommendationRepository{}
+	priceRepo := &MockPriceRepository{}
+
+	recRepo.On("GetLatest", 10).Return([]*model.Recommendation{
+		{Ticker: "AAPL", TargetPrice: &target},
+		{Ticker: "MSFT"},
+	}, nil)
+	priceRepo.On("GetLatestCloses", []string{"AAPL", "MSFT"}).Return(map[string]*model.PriceBar{
+		"AAPL": {Ticker: "AAPL", Close: 100},
+	}, nil)
+
+	service := NewRecommendationService(nil, recRepo, nil, nil, nil, nil, logrus.New())
+	service.SetPriceService(NewPriceService(priceRepo, nil, logrus.New()))
+
+	recommendations, err := service.GetLatestRecommendations(10)
+	require.NoError(t, err)
+	require.Len(t, recommendations, 2)
+
+	require.NotNil(t, recommendations[0].UpsidePercent)
+	assert.Equal(t, 20.0, *recommendations[0].UpsidePercent)
+	assert.Equal(t, 100.0, *recommendations[0].LastClose)
+	assert.Nil(t, recommendations[1].LastClose)
+	assert.Nil(t, recommendations[1].UpsidePercent)
+}
+
+func TestStockService_GetStockAttachesUpside(t *testing.T) {
+	stockRepo := &MockStockRepository{}
+	priceRepo := &MockPriceRepository{}
+	closeDate := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	stockRepo.On("GetStockByTicket", "AAPL").Return(&model.Stock{
+		Ticker:     "AAPL",
+		TargetFrom: "$150.00",
+		TargetTo:   "$200.00",
+	}, nil)
+	priceRepo.On("GetLatestCloses", []string{"AAPL"}).Return(map[string]*model.PriceBar{
+		"AAPL": {Ticker: "AAPL", Date: closeDate, Close: 160},
+	}, nil)
+
+	service := NewStockService(stockRepo, logrus.New())
+	service.SetPriceService(NewPriceService(priceRepo, nil, logrus.New()))
+
+	stock, err := service.GetStock("AAPL")
+	require.NoError(t, err)
+	require.NotNil(t, stock.UpsidePercent)
+	assert.Equal(t, 25.0, *stock.UpsidePercent)
+	assert.Equal(t, closeDate, *stock.LastCloseDate)
+}
@@ -1,10 +1,9 @@
 package service
 
 import (
-	"fmt"
+	"context"
+	"encoding/json"
 	"sort"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,79 +12,26 @@ import (
 	"github.com/valeriapadilla/stock-insights/internal/model"
 	repoInterfaces "github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
 	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+	"github.com/valeriapadilla/stock-insights/internal/utils"
 	"github.com/valeriapadilla/stock-insights/internal/validator"
 )
 
-type ScoringConfig struct {
-	// Action Scores (0-40 points)
-	TargetRaisedScore     int
-	UpgradedScore         int
-	InitiatedScore        int
-	TargetMaintainedScore int
-
-	// Rating Scores (0-25 points)
-	BuyScore           int
-	OverweightScore    int
-	SectorPerformScore int
-	EqualWeightScore   int
-	NeutralScore       int
-
-	// Target Change Scores (0-20 points)
-	HighTargetChangeScore   int
-	MediumTargetChangeScore int
-	LowTargetChangeScore    int
-	MinTargetChangeScore    int
-
-	// Freshness Scores (0-15 points)
-	TodayScore     int
-	YesterdayScore int
-	ThreeDaysScore int
-	WeekScore      int
-
-	// Thresholds
-	HighTargetChangePercent   float64
-	MediumTargetChangePercent float64
-	LowTargetChangePercent    float64
-	MinTargetChangePercent    float64
-}
-
-func DefaultScoringConfig() *ScoringConfig {
-	return &ScoringConfig{
-		TargetRaisedScore:     40,
-		UpgradedScore:         35,
-		InitiatedScore:        30,
-		TargetMaintainedScore: 20,
-
-		BuyScore:           25,
-		OverweightScore:    20,
-		SectorPerformScore: 15,
-		EqualWeightScore:   10,
-		NeutralScore:       5,
-
-		HighTargetChangeScore:   20,
-		MediumTargetChangeScore: 15,
-		LowTargetChangeScore:    10,
-		MinTargetChangeScore:    5,
-
-		TodayScore:     15,
-		YesterdayScore: 12,
-		ThreeDaysScore: 10,
-		WeekScore:      5,
-
-		HighTargetChangePercent:   50.0,
-		MediumTargetChangePercent: 25.0,
-		LowTargetChangePercent:    10.0,
-		MinTargetChangePercent:    5.0,
-	}
-}
+// DefaultRecommendationRetention is how long past runs are kept before
+// SaveRecommendations prunes them.
+const DefaultRecommendationRetention = 30 * 24 * time.Hour
 
 type RecommendationService struct {
 	stockRepo          repoInterfaces.StockRepository
 	recommendationRepo repoInterfaces.RecommendationRepository
 	recommendationCmd  repoInterfaces.RecommendationCommand
+	runRepo            repoInterfaces.RecommendationRunRepository
+	referenceService   interfaces.ReferenceServiceInterface
+	scoringConfigs     interfaces.ScoringConfigServiceInterface
+	prices             interfaces.PriceServiceInterface
 	logger             *logrus.Logger
-	scoringConfig      *ScoringConfig
+	scoringConfig      *model.ScoringConfig
 	validator          *validator.RecommendationValidator
+	retention          time.Duration
 }
 
 var _ interfaces.RecommendationServiceInterface = (*RecommendationService)(nil)
@@ -100,41 +46,108 @@ func NewRecommendationService(
 	stockRepo repoInterfaces.StockRepository,
 	recommendationRepo repoInterfaces.RecommendationRepository,
 	recommendationCmd repoInterfaces.RecommendationCommand,
+	runRepo repoInterfaces.RecommendationRunRepository,
+	referenceService interfaces.ReferenceServiceInterface,
+	scoringConfigs interfaces.ScoringConfigServiceInterface,
 	logger *logrus.Logger,
 ) *RecommendationService {
 	return &RecommendationService{
 		stockRepo:          stockRepo,
 		recommendationRepo: recommendationRepo,
 		recommendationCmd:  recommendationCmd,
+		runRepo:            runRepo,
+		referenceService:   referenceService,
+		scoringConfigs:     scoringConfigs,
 		logger:             logger,
-		scoringConfig:      DefaultScoringConfig(),
+		scoringConfig:      model.DefaultScoringConfig(),
 		validator:          validator.NewRecommendationValidator(),
+		retention:          DefaultRecommendationRetention,
 	}
 }
 
-func (s *RecommendationService) CalculateRecommendations(params validator.RecommendationParams) ([]*model.Recommendation, error) {
+// SetRetention changes how long past runs are kept. Zero disables pruning.
+func (s *RecommendationService) SetRetention(retention time.Duration) {
+	s.retention = retention
+}
+
+// SetPriceService enables upside versus last close on served recommendations.
+func (s *RecommendationService) SetPriceService(prices interfaces.PriceServiceInterface) {
+	s.prices = prices
+}
+
+// CalculateRecommendations records a new run and scores it. The run is left
+// running until SaveRecommendations publishes it; a scoring failure marks it
+// failed, as does ctx ending before scoring is done. Previously published
+// runs are untouched.
+func (s *RecommendationService) CalculateRecommendations(ctx context.Context, params validator.RecommendationParams) (*model.RecommendationRun, error) {
 	validatedParams := s.validator.ValidateRecommendationParams(params)
 
-	if err := s.recommendationCmd.DeleteAllRecommendations(); err != nil {
-		s.logger.WithError(err).Error("Failed to delete existing recommendations")
-		return nil, errors.NewDatabaseError("failed to delete existing recommendations", err)
+	scoringConfig, configVersion, err := s.resolveScoringConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scorer, err := s.scorerFor(validatedParams.Strategy, scoringConfig)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
 	}
+	validatedParams.Strategy = scorer.Name()
 
-	s.logger.Info("Deleted all existing recommendations, calculating new ones...")
+	run, err := s.startRun(validatedParams, configVersion)
+	if err != nil {
+		return nil, err
+	}
 
 	stocks, err := s.getStocksForRecommendations(validatedParams.DaysBack)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to get stocks for recommendations")
+		s.FailRun(run, err)
 		return nil, errors.NewDatabaseError("failed to get stocks for recommendations", err)
 	}
+	if ctx.Err() != nil {
+		s.FailRun(run, context.Cause(ctx))
+		return nil, errors.NewInternalError("recommendation calculation cancelled", ctx.Err())
+	}
 
-	stockScores := s.calculateStockScores(stocks)
+	stockScores := scorer.Score(stocks, run.StartedAt)
 	filteredScores := s.filterAndSortScores(stockScores, validatedParams.MinScore, validatedParams.MaxResults)
-	recommendations := s.convertToRecommendations(filteredScores)
+	run.Recommendations = s.convertToRecommendations(run, filteredScores)
+	if ctx.Err() != nil {
+		s.FailRun(run, context.Cause(ctx))
+		return nil, errors.NewInternalError("recommendation calculation cancelled", ctx.Err())
+	}
 
-	s.logRecommendationCalculation(stocks, stockScores, filteredScores, recommendations, validatedParams)
+	s.logRecommendationCalculation(scorer, stocks, stockScores, filteredScores, run.Recommendations, validatedParams)
 
-	return recommendations, nil
+	return run, nil
+}
+
+// ReplayRecommendations scores stocks as of a past date with the same config,
+// strategy and filtering as CalculateRecommendations, without recording a run.
+// Callers are responsible for passing only the events visible at asOf.
+func (s *RecommendationService) ReplayRecommendations(stocks []*model.Stock, params validator.RecommendationParams, asOf time.Time) ([]*model.Recommendation, error) {
+	validatedParams := s.validator.ValidateRecommendationParams(params)
+
+	scoringConfig, configVersion, err := s.resolveScoringConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	scorer, err := s.scorerFor(validatedParams.Strategy, scoringConfig)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+
+	run := &model.RecommendationRun{
+		Strategy:             scorer.Name(),
+		ScoringConfigVersion: configVersion,
+		StartedAt:            asOf,
+	}
+
+	stockScores := scorer.Score(stocks, asOf)
+	filteredScores := s.filterAndSortScores(stockScores, validatedParams.MinScore, validatedParams.MaxResults)
+
+	return s.convertToRecommendations(run, filteredScores), nil
 }
 
 func (s *RecommendationService) GetLatestRecommendations(limit int) ([]*model.Recommendation, error) {
@@ -146,219 +159,228 @@ func (s *RecommendationService) GetLatestRecommendations(limit int) ([]*model.Re
 		return nil, errors.NewDatabaseError("failed to get latest recommendations", err)
 	}
 
+	s.attachLastCloses(recommendations)
 	return recommendations, nil
 }
 
-func (s *RecommendationService) SaveRecommendations(recommendations []*model.Recommendation) error {
-	runAt := time.Now()
+// SaveRecommendations publishes the run: its recommendations are written and
+// it replaces the active run atomically. Runs older than the retention period
+// are pruned afterwards.
+func (s *RecommendationService) SaveRecommendations(run *model.RecommendationRun) error {
+	if run == nil {
+		return errors.NewValidationError("recommendation run is required", nil)
+	}
 
-	for i, rec := range recommendations {
-		if rec.ID == "" {
-			rec.ID = uuid.New().String()
-		}
-		rec.RunAt = runAt
+	for i, rec := range run.Recommendations {
+		rec.RunID = run.ID
+		rec.RunAt = run.StartedAt
 		rec.Rank = i + 1
+	}
 
-		if err := s.recommendationRepo.CreateRecommendation(rec); err != nil {
-			s.logger.WithError(err).WithField("ticker", rec.Ticker).Error("Failed to save recommendation")
-			return errors.NewDatabaseError("failed to save recommendation", err)
-		}
+	if err := s.recommendationCmd.PublishRun(run); err != nil {
+		s.logger.WithError(err).WithField("run_id", run.ID).Error("Failed to publish recommendation run")
+		s.FailRun(run, err)
+		return errors.NewDatabaseError("failed to save recommendations", err)
 	}
+	run.Status = model.RecommendationRunStatusCompleted
+	run.RecommendationCount = len(run.Recommendations)
+	run.IsActive = true
 
-	s.logger.WithField("count", len(recommendations)).Info("Recommendations saved successfully")
+	s.logger.WithFields(logrus.Fields{
+		"run_id": run.ID,
+		"count":  run.RecommendationCount,
+	}).Info("Recommendations saved successfully")
+
+	s.applyRetention()
 	return nil
 }
 
-func (s *RecommendationService) getStocksForRecommendations(daysBack int) ([]*model.Stock, error) {
-	cutoffDate := time.Now().AddDate(0, 0, -daysBack)
+// GetLastRunTime returns when the latest published run started, whether it
+// was scheduled or triggered through the admin API.
+func (s *RecommendationService) GetLastRunTime() (*time.Time, error) {
+	lastRun, err := s.runRepo.GetLastCompletedRunTime()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get last recommendation run time")
+		return nil, errors.NewDatabaseError("failed to get last recommendation run time", err)
+	}
+	return lastRun, nil
+}
 
-	countParams := repoInterfaces.GetStocksParams{
-		Search: &repoInterfaces.StockSearchFilters{
-			DateFrom: &cutoffDate,
-		},
+func (s *RecommendationService) GetRuns(limit, offset int) ([]*model.RecommendationRun, int, error) {
+	runs, err := s.runRepo.GetRuns(limit, offset)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get recommendation runs")
+		return nil, 0, errors.NewDatabaseError("failed to get recommendation runs", err)
 	}
 
-	totalCount, err := s.stockRepo.GetStocksCount(countParams)
+	total, err := s.runRepo.GetRunsCount()
 	if err != nil {
-		return nil, err
+		s.logger.WithError(err).Error("Failed to count recommendation runs")
+		return nil, 0, errors.NewDatabaseError("failed to count recommendation runs", err)
 	}
-	limit := totalCount + 100
 
-	params := repoInterfaces.GetStocksParams{
-		Limit: limit,
-		Search: &repoInterfaces.StockSearchFilters{
-			DateFrom: &cutoffDate,
-		},
+	return runs, total, nil
+}
+
+func (s *RecommendationService) GetRun(runID string) (*model.RecommendationRun, error) {
+	if _, err := uuid.Parse(runID); err != nil {
+		return nil, errors.NewValidationError("run id must be a valid UUID", err)
 	}
 
-	stocks, err := s.stockRepo.GetStocks(params)
+	run, err := s.runRepo.GetRunByID(runID)
 	if err != nil {
-		return nil, err
+		s.logger.WithError(err).WithField("run_id", runID).Error("Failed to get recommendation run")
+		return nil, errors.NewDatabaseError("failed to get recommendation run", err)
+	}
+	if run == nil {
+		return nil, errors.NewNotFoundError("recommendation run not found", nil)
 	}
 
-	s.logger.WithFields(logrus.Fields{
-		"days_back":    daysBack,
-		"total_stocks": totalCount,
-		"limit_used":   limit,
-		"stocks_found": len(stocks),
-	}).Info("Retrieved stocks for recommendations")
+	run.Recommendations, err = s.recommendationRepo.GetRecommendationsByRun(runID)
+	if err != nil {
+		s.logger.WithError(err).WithField("run_id", runID).Error("Failed to get run recommendations")
+		return nil, errors.NewDatabaseError("failed to get run recommendations", err)
+	}
+	s.attachLastCloses(run.Recommendations)
 
-	return s.filterPositiveStocks(stocks), nil
+	return run, nil
 }
 
-func (s *RecommendationService) filterPositiveStocks(stocks []*model.Stock) []*model.Stock {
-	var positiveStocks []*model.Stock
-	for _, stock := range stocks {
-		if s.isPositiveAction(stock.Action) && s.isPositiveRating(stock.RatingTo) {
-			positiveStocks = append(positiveStocks, stock)
-		}
+// attachLastCloses adds the latest close and upside to each recommendation.
+// Missing prices only leave the fields empty.
+func (s *RecommendationService) attachLastCloses(recommendations []*model.Recommendation) {
+	if s.prices == nil || len(recommendations) == 0 {
+		return
 	}
-	return positiveStocks
-}
-
-func (s *RecommendationService) calculateStockScores(stocks []*model.Stock) []StockScore {
-	var stockScores []StockScore
 
-	for _, stock := range stocks {
-		score := s.calculateStockScore(stock)
-		explanation := s.generateExplanation(stock, score)
+	tickers := make([]string, 0, len(recommendations))
+	for _, rec := range recommendations {
+		tickers = append(tickers, rec.Ticker)
+	}
 
-		stockScores = append(stockScores, StockScore{
-			Stock:       stock,
-			Score:       score,
-			Explanation: explanation,
-		})
+	closes, err := s.prices.GetLatestCloses(tickers)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to attach last closes to recommendations")
+		return
 	}
 
-	return stockScores
+	for _, rec := range recommendations {
+		rec.ApplyLastClose(closes[rec.Ticker])
+	}
 }
 
-func (s *RecommendationService) calculateStockScore(stock *model.Stock) int {
-	score := 0
-
-	score += s.getActionScore(stock.Action)
-	score += s.getRatingScore(stock.RatingTo)
-	score += s.getTargetChangeScore(stock.TargetFrom, stock.TargetTo)
-	score += s.getFreshnessScore(stock.Time)
+func (s *RecommendationService) startRun(params validator.RecommendationParams, configVersion string) (*model.RecommendationRun, error) {
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to encode recommendation params", err)
+	}
 
-	return score
-}
+	run := &model.RecommendationRun{
+		ID:                   uuid.New().String(),
+		Status:               model.RecommendationRunStatusRunning,
+		Strategy:             params.Strategy,
+		ScoringConfigVersion: configVersion,
+		Params:               rawParams,
+		StartedAt:            time.Now(),
+	}
 
-func (s *RecommendationService) getActionScore(action string) int {
-	switch strings.ToLower(action) {
-	case "target raised by":
-		return s.scoringConfig.TargetRaisedScore
-	case "upgraded by":
-		return s.scoringConfig.UpgradedScore
-	case "initiated by":
-		return s.scoringConfig.InitiatedScore
-	case "target maintained by":
-		return s.scoringConfig.TargetMaintainedScore
-	default:
-		return 0
+	if err := s.runRepo.CreateRun(run); err != nil {
+		s.logger.WithError(err).Error("Failed to create recommendation run")
+		return nil, errors.NewDatabaseError("failed to create recommendation run", err)
 	}
+
+	return run, nil
 }
 
-func (s *RecommendationService) getRatingScore(rating string) int {
-	switch strings.ToLower(rating) {
-	case "buy":
-		return s.scoringConfig.BuyScore
-	case "overweight":
-		return s.scoringConfig.OverweightScore
-	case "sector perform":
-		return s.scoringConfig.SectorPerformScore
-	case "equal weight":
-		return s.scoringConfig.EqualWeightScore
-	case "neutral":
-		return s.scoringConfig.NeutralScore
-	default:
-		return 0
+// FailRun marks a run that will not be saved as failed with cause, such as a
+// calculation abandoned because its job was cancelled.
+func (s *RecommendationService) FailRun(run *model.RecommendationRun, cause error) {
+	run.Status = model.RecommendationRunStatusFailed
+	run.ErrorMessage = cause.Error()
+
+	if err := s.runRepo.FinishRun(run.ID, run.Status, 0, run.ErrorMessage); err != nil {
+		s.logger.WithError(err).WithField("run_id", run.ID).Warn("Failed to mark recommendation run as failed")
 	}
 }
 
-func (s *RecommendationService) getTargetChangeScore(targetFrom, targetTo string) int {
-	fromPrice, err1 := s.extractPrice(targetFrom)
-	toPrice, err2 := s.extractPrice(targetTo)
+func (s *RecommendationService) applyRetention() {
+	if s.retention <= 0 {
+		return
+	}
 
-	if err1 != nil || err2 != nil {
-		return 0
+	deleted, err := s.recommendationRepo.DeleteOldRecommendations(s.retention)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to prune old recommendation runs")
+		return
 	}
 
-	if fromPrice <= 0 || toPrice <= 0 {
-		return 0
+	if deleted > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"deleted_runs": deleted,
+			"retention":    s.retention.String(),
+		}).Info("Pruned old recommendation runs")
 	}
+}
 
-	changePercent := ((toPrice - fromPrice) / fromPrice) * 100
+func (s *RecommendationService) getStocksForRecommendations(daysBack int) ([]*model.Stock, error) {
+	cutoffDate := time.Now().AddDate(0, 0, -daysBack)
 
-	switch {
-	case changePercent > s.scoringConfig.HighTargetChangePercent:
-		return s.scoringConfig.HighTargetChangeScore
-	case changePercent > s.scoringConfig.MediumTargetChangePercent:
-		return s.scoringConfig.MediumTargetChangeScore
-	case changePercent > s.scoringConfig.LowTargetChangePercent:
-		return s.scoringConfig.LowTargetChangeScore
-	case changePercent > s.scoringConfig.MinTargetChangePercent:
-		return s.scoringConfig.MinTargetChangeScore
-	case changePercent > 0:
-		return 2
-	default:
-		return 0
+	countParams := repoInterfaces.GetStocksParams{
+		Search: &repoInterfaces.StockSearchFilters{
+			DateFrom: &cutoffDate,
+		},
 	}
-}
 
-func (s *RecommendationService) getFreshnessScore(stockTime time.Time) int {
-	daysSince := int(time.Since(stockTime).Hours() / 24)
-
-	switch {
-	case daysSince == 0:
-		return s.scoringConfig.TodayScore
-	case daysSince == 1:
-		return s.scoringConfig.YesterdayScore
-	case daysSince <= 3:
-		return s.scoringConfig.ThreeDaysScore
-	case daysSince <= 7:
-		return s.scoringConfig.WeekScore
-	default:
-		return 0
+	totalCount, err := s.stockRepo.GetStocksCount(countParams)
+	if err != nil {
+		return nil, err
 	}
-}
+	limit := totalCount + 100
 
-func (s *RecommendationService) extractPrice(priceStr string) (float64, error) {
-	cleanPrice := strings.TrimSpace(strings.Replace(priceStr, "$", "", -1))
-	return strconv.ParseFloat(cleanPrice, 64)
-}
+	params := repoInterfaces.GetStocksParams{
+		Limit: limit,
+		Search: &repoInterfaces.StockSearchFilters{
+			DateFrom: &cutoffDate,
+		},
+	}
 
-func (s *RecommendationService) isPositiveAction(action string) bool {
-	positiveActions := []string{
-		"target raised by",
-		"upgraded by",
-		"initiated by",
-		"target maintained by",
+	stocks, err := s.stockRepo.GetStocks(params)
+	if err != nil {
+		return nil, err
 	}
 
-	actionLower := strings.ToLower(action)
-	for _, positive := range positiveActions {
-		if actionLower == positive {
-			return true
-		}
+	s.logger.WithFields(logrus.Fields{
+		"days_back":    daysBack,
+		"total_stocks": totalCount,
+		"limit_used":   limit,
+		"stocks_found": len(stocks),
+	}).Info("Retrieved stocks for recommendations")
+
+	return stocks, nil
+}
+
+func (s *RecommendationService) scorerFor(strategy string, scoringConfig *model.ScoringConfig) (Scorer, error) {
+	var normalizer ReferenceNormalizer
+	if s.referenceService != nil {
+		normalizer = s.referenceService
 	}
-	return false
+	return NewScorer(strategy, scoringConfig, normalizer)
 }
 
-func (s *RecommendationService) isPositiveRating(rating string) bool {
-	positiveRatings := []string{
-		"buy",
-		"overweight",
-		"sector perform",
+// resolveScoringConfig returns the weights for this run and the version id
+// recorded on every recommendation it produces.
+func (s *RecommendationService) resolveScoringConfig() (*model.ScoringConfig, string, error) {
+	if s.scoringConfigs == nil {
+		return s.scoringConfig, model.DefaultScoringConfigVersion, nil
 	}
 
-	ratingLower := strings.ToLower(rating)
-	for _, positive := range positiveRatings {
-		if ratingLower == positive {
-			return true
-		}
+	scoringConfig, version, err := s.scoringConfigs.GetActiveConfig()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to resolve scoring config")
+		return nil, "", err
 	}
-	return false
+
+	return scoringConfig, version, nil
 }
 
 func (s *RecommendationService) filterAndSortScores(scores []StockScore, minScore, maxResults int) []StockScore {
@@ -381,7 +403,7 @@ func (s *RecommendationService) filterAndSortScores(scores []StockScore, minScor
 	return filtered
 }
 
-func (s *RecommendationService) convertToRecommendations(scores []StockScore) []*model.Recommendation {
+func (s *RecommendationService) convertToRecommendations(run *model.RecommendationRun, scores []StockScore) []*model.Recommendation {
 	var recommendations []*model.Recommendation
 
 	for i, score := range scores {
@@ -390,8 +412,12 @@ func (s *RecommendationService) convertToRecommendations(scores []StockScore) []
 			Ticker:      score.Stock.Ticker,
 			Score:       float64(score.Score),
 			Explanation: score.Explanation,
-			RunAt:       time.Now(),
+			RunID:       run.ID,
+			RunAt:       run.StartedAt,
 			Rank:        i + 1,
+
+			ScoringConfigVersion: run.ScoringConfigVersion,
+			TargetPrice:          targetPriceOf(score.Stock),
 		}
 		recommendations = append(recommendations, recommendation)
 	}
@@ -399,64 +425,8 @@ func (s *RecommendationService) convertToRecommendations(scores []StockScore) []
 	return recommendations
 }
 
-func (s *RecommendationService) generateExplanation(stock *model.Stock, score int) string {
-	var reasons []string
-
-	reasons = append(reasons, s.getActionExplanation(stock.Action))
-	reasons = append(reasons, s.getRatingExplanation(stock.RatingTo))
-	reasons = append(reasons, s.getTargetChangeExplanation(stock.TargetFrom, stock.TargetTo))
-	reasons = append(reasons, s.getBrokerageExplanation(stock.Brokerage))
-	reasons = append(reasons, fmt.Sprintf("Score: %d/100", score))
-
-	return strings.Join(reasons, ", ")
-}
-
-func (s *RecommendationService) getActionExplanation(action string) string {
-	switch strings.ToLower(action) {
-	case "target raised by":
-		return "Target price raised"
-	case "upgraded by":
-		return "Rating upgraded"
-	case "initiated by":
-		return "New coverage initiated"
-	case "target maintained by":
-		return "Target price maintained"
-	default:
-		return ""
-	}
-}
-
-func (s *RecommendationService) getRatingExplanation(rating string) string {
-	switch strings.ToLower(rating) {
-	case "buy":
-		return "Buy rating"
-	case "overweight":
-		return "Overweight rating"
-	default:
-		return ""
-	}
-}
-
-func (s *RecommendationService) getTargetChangeExplanation(targetFrom, targetTo string) string {
-	if fromPrice, err1 := s.extractPrice(targetFrom); err1 == nil {
-		if toPrice, err2 := s.extractPrice(targetTo); err2 == nil {
-			if toPrice > fromPrice {
-				changePercent := ((toPrice - fromPrice) / fromPrice) * 100
-				return fmt.Sprintf("Target raised by %.1f%%", changePercent)
-			}
-		}
-	}
-	return ""
-}
-
-func (s *RecommendationService) getBrokerageExplanation(brokerage string) string {
-	if brokerage != "" {
-		return fmt.Sprintf("by %s", brokerage)
-	}
-	return ""
-}
-
 func (s *RecommendationService) logRecommendationCalculation(
+	scorer Scorer,
 	stocks []*model.Stock,
 	stockScores []StockScore,
 	filteredScores []StockScore,
@@ -464,6 +434,7 @@ func (s *RecommendationService) logRecommendationCalculation(
 	params validator.RecommendationParams,
 ) {
 	s.logger.WithFields(logrus.Fields{
+		"strategy":        scorer.Name(),
 		"total_stocks":    len(stocks),
 		"scored_stocks":   len(stockScores),
 		"filtered_stocks": len(filteredScores),
@@ -472,3 +443,10 @@ func (s *RecommendationService) logRecommendationCalculation(
 		"max_results":     params.MaxResults,
 	}).Info("Recommendations calculated successfully")
 }
+
+func targetPriceOf(stock *model.Stock) *float64 {
+	if stock.TargetToPrice != nil {
+		return stock.TargetToPrice
+	}
+	return utils.PriceOrNil(stock.TargetTo)
+}
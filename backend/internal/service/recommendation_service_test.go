@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -18,7 +20,7 @@ func TestRecommendationService_CalculateRecommendations(t *testing.T) {
 		mockStocks    []*model.Stock
 		expectedCount int
 		expectedError bool
-		setupMocks    func(*MockStockRepository, *MockRecommendationCommand)
+		setupMocks    func(*MockStockRepository, *MockRecommendationRunRepository)
 	}{
 		{
 			name: "successful calculation with valid stocks",
@@ -49,8 +51,8 @@ func TestRecommendationService_CalculateRecommendations(t *testing.T) {
 			},
 			expectedCount: 2,
 			expectedError: false,
-			setupMocks: func(stockRepo *MockStockRepository, recCmd *MockRecommendationCommand) {
-				recCmd.On("DeleteAllRecommendations").Return(nil)
+			setupMocks: func(stockRepo *MockStockRepository, runRepo *MockRecommendationRunRepository) {
+				runRepo.On("CreateRun", mock.Anything).Return(nil)
 				stockRepo.On("GetStocksCount", mock.Anything).Return(2, nil)
 				stockRepo.On("GetStocks", mock.Anything).Return([]*model.Stock{
 					{
@@ -84,8 +86,8 @@ func TestRecommendationService_CalculateRecommendations(t *testing.T) {
 			mockStocks:    []*model.Stock{},
 			expectedCount: 0,
 			expectedError: false,
-			setupMocks: func(stockRepo *MockStockRepository, recCmd *MockRecommendationCommand) {
-				recCmd.On("DeleteAllRecommendations").Return(nil)
+			setupMocks: func(stockRepo *MockStockRepository, runRepo *MockRecommendationRunRepository) {
+				runRepo.On("CreateRun", mock.Anything).Return(nil)
 				stockRepo.On("GetStocksCount", mock.Anything).Return(0, nil)
 				stockRepo.On("GetStocks", mock.Anything).Return([]*model.Stock{}, nil)
 			},
@@ -96,34 +98,155 @@ func TestRecommendationService_CalculateRecommendations(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockStockRepo := &MockStockRepository{}
 			mockRecRepo := &MockRecommendationRepository{}
-			mockRecCmd := &MockRecommendationCommand{}
+			mockRunRepo := &MockRecommendationRunRepository{}
 
-			tt.setupMocks(mockStockRepo, mockRecCmd)
+			tt.setupMocks(mockStockRepo, mockRunRepo)
 
 			service := &RecommendationService{
 				stockRepo:          mockStockRepo,
 				recommendationRepo: mockRecRepo,
-				recommendationCmd:  mockRecCmd,
+				runRepo:            mockRunRepo,
 				validator:          validator.NewRecommendationValidator(),
 				logger:             logrus.New(),
-				scoringConfig:      DefaultScoringConfig(),
+				scoringConfig:      model.DefaultScoringConfig(),
 			}
 
-			recommendations, err := service.CalculateRecommendations(tt.params)
+			run, err := service.CalculateRecommendations(context.Background(), tt.params)
 
 			if tt.expectedError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Len(t, recommendations, tt.expectedCount)
+				assert.Equal(t, model.RecommendationRunStatusRunning, run.Status)
+				assert.Equal(t, DefaultScorerName, run.Strategy)
+				assert.Len(t, run.Recommendations, tt.expectedCount)
+				for _, rec := range run.Recommendations {
+					assert.Equal(t, run.ID, rec.RunID)
+					assert.Equal(t, run.StartedAt, rec.RunAt)
+				}
 			}
 
 			mockStockRepo.AssertExpectations(t)
-			mockRecCmd.AssertExpectations(t)
+			mockRunRepo.AssertExpectations(t)
 		})
 	}
 }
 
+func TestRecommendationService_CalculateRecommendationsRecordsConfigVersion(t *testing.T) {
+	mockStockRepo := &MockStockRepository{}
+	mockRunRepo := &MockRecommendationRunRepository{}
+	mockConfigRepo := &MockScoringConfigRepository{}
+
+	mockConfigRepo.On("GetActive").Return(&model.ScoringConfigVersion{
+		Version: "tuned-v3",
+		Config:  json.RawMessage(`{"buy_score": 5}`),
+	}, nil)
+	mockRunRepo.On("CreateRun", mock.MatchedBy(func(run *model.RecommendationRun) bool {
+		return run.ScoringConfigVersion == "tuned-v3"
+	})).Return(nil)
+	mockStockRepo.On("GetStocksCount", mock.Anything).Return(1, nil)
+	mockStockRepo.On("GetStocks", mock.Anything).Return([]*model.Stock{
+		{
+			Ticker:     "AAPL",
+			Action:     "target raised by",
+			RatingTo:   "Buy",
+			TargetFrom: "$150.00",
+			TargetTo:   "$200.00",
+			Time:       time.Now(),
+		},
+	}, nil)
+
+	service := &RecommendationService{
+		stockRepo:      mockStockRepo,
+		runRepo:        mockRunRepo,
+		scoringConfigs: NewScoringConfigService(mockConfigRepo, "", logrus.New()),
+		validator:      validator.NewRecommendationValidator(),
+		logger:         logrus.New(),
+		scoringConfig:  model.DefaultScoringConfig(),
+	}
+
+	run, err := service.CalculateRecommendations(context.Background(), validator.RecommendationParams{MaxResults: 10})
+
+	assert.NoError(t, err)
+	assert.Len(t, run.Recommendations, 1)
+	assert.Equal(t, "tuned-v3", run.Recommendations[0].ScoringConfigVersion)
+	// 40 (action) + 5 (tuned buy score) + 15 (target) + 15 (freshness)
+	assert.Equal(t, 75.0, run.Recommendations[0].Score)
+	mockRunRepo.AssertExpectations(t)
+}
+
+func TestRecommendationService_CalculateRecommendationsMarksRunFailed(t *testing.T) {
+	mockStockRepo := &MockStockRepository{}
+	mockRunRepo := &MockRecommendationRunRepository{}
+
+	mockRunRepo.On("CreateRun", mock.Anything).Return(nil)
+	mockRunRepo.On("FinishRun", mock.Anything, model.RecommendationRunStatusFailed, 0, mock.Anything).Return(nil)
+	mockStockRepo.On("GetStocksCount", mock.Anything).Return(0, assert.AnError)
+
+	service := &RecommendationService{
+		stockRepo:     mockStockRepo,
+		runRepo:       mockRunRepo,
+		validator:     validator.NewRecommendationValidator(),
+		logger:        logrus.New(),
+		scoringConfig: model.DefaultScoringConfig(),
+	}
+
+	run, err := service.CalculateRecommendations(context.Background(), validator.RecommendationParams{})
+
+	assert.Error(t, err)
+	assert.Nil(t, run)
+	mockRunRepo.AssertExpectations(t)
+}
+
+func TestRecommendationService_CalculateRecommendationsCancelled(t *testing.T) {
+	mockStockRepo := &MockStockRepository{}
+	mockRunRepo := &MockRecommendationRunRepository{}
+
+	mockRunRepo.On("CreateRun", mock.Anything).Return(nil)
+	mockRunRepo.On("FinishRun", mock.Anything, model.RecommendationRunStatusFailed, 0, context.DeadlineExceeded.Error()).Return(nil)
+	mockStockRepo.On("GetStocksCount", mock.Anything).Return(0, nil)
+	mockStockRepo.On("GetStocks", mock.Anything).Return([]*model.Stock{}, nil)
+
+	service := &RecommendationService{
+		stockRepo:     mockStockRepo,
+		runRepo:       mockRunRepo,
+		validator:     validator.NewRecommendationValidator(),
+		logger:        logrus.New(),
+		scoringConfig: model.DefaultScoringConfig(),
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
+	run, err := service.CalculateRecommendations(ctx, validator.RecommendationParams{})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, run)
+	mockRunRepo.AssertExpectations(t)
+}
+
+func TestRecommendationService_CalculateRecommendationsUnknownStrategy(t *testing.T) {
+	mockStockRepo := &MockStockRepository{}
+	mockRunRepo := &MockRecommendationRunRepository{}
+
+	service := &RecommendationService{
+		stockRepo:     mockStockRepo,
+		runRepo:       mockRunRepo,
+		validator:     validator.NewRecommendationValidator(),
+		logger:        logrus.New(),
+		scoringConfig: model.DefaultScoringConfig(),
+	}
+
+	run, err := service.CalculateRecommendations(context.Background(), validator.RecommendationParams{Strategy: "momentum"})
+
+	assert.Error(t, err)
+	assert.Nil(t, run)
+	assert.Contains(t, err.Error(), "unknown scoring strategy")
+
+	// A rejected request must not record a run
+	mockRunRepo.AssertNotCalled(t, "CreateRun", mock.Anything)
+	mockStockRepo.AssertNotCalled(t, "GetStocks", mock.Anything)
+}
+
 func TestRecommendationService_GetLatestRecommendations(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -175,7 +298,7 @@ func TestRecommendationService_GetLatestRecommendations(t *testing.T) {
 				recommendationCmd:  mockRecCmd,
 				validator:          validator.NewRecommendationValidator(),
 				logger:             logrus.New(),
-				scoringConfig:      DefaultScoringConfig(),
+				scoringConfig:      model.DefaultScoringConfig(),
 			}
 
 			recommendations, err := service.GetLatestRecommendations(tt.limit)
@@ -197,7 +320,8 @@ func TestRecommendationService_SaveRecommendations(t *testing.T) {
 		name            string
 		recommendations []*model.Recommendation
 		expectedError   bool
-		setupMocks      func(*MockRecommendationRepository)
+		expectedStatus  model.RecommendationRunStatus
+		setupMocks      func(*MockRecommendationRepository, *MockRecommendationCommand, *MockRecommendationRunRepository)
 	}{
 		{
 			name: "successful save",
@@ -205,49 +329,175 @@ func TestRecommendationService_SaveRecommendations(t *testing.T) {
 				{Ticker: "AAPL", Score: 95},
 				{Ticker: "GOOGL", Score: 90},
 			},
-			expectedError: false,
-			setupMocks: func(recRepo *MockRecommendationRepository) {
-				recRepo.On("CreateRecommendation", mock.Anything).Return(nil).Times(2)
+			expectedError:  false,
+			expectedStatus: model.RecommendationRunStatusCompleted,
+			setupMocks: func(recRepo *MockRecommendationRepository, recCmd *MockRecommendationCommand, runRepo *MockRecommendationRunRepository) {
+				recCmd.On("PublishRun", mock.MatchedBy(func(run *model.RecommendationRun) bool {
+					return run.ID == "run-1" && len(run.Recommendations) == 2
+				})).Return(nil)
+				recRepo.On("DeleteOldRecommendations", DefaultRecommendationRetention).Return(int64(3), nil)
 			},
 		},
 		{
-			name: "repository error",
+			name:           "empty run is published",
+			expectedError:  false,
+			expectedStatus: model.RecommendationRunStatusCompleted,
+			setupMocks: func(recRepo *MockRecommendationRepository, recCmd *MockRecommendationCommand, runRepo *MockRecommendationRunRepository) {
+				recCmd.On("PublishRun", mock.Anything).Return(nil)
+				recRepo.On("DeleteOldRecommendations", DefaultRecommendationRetention).Return(int64(0), nil)
+			},
+		},
+		{
+			name: "publish error",
 			recommendations: []*model.Recommendation{
 				{Ticker: "AAPL", Score: 95},
 			},
-			expectedError: true,
-			setupMocks: func(recRepo *MockRecommendationRepository) {
-				recRepo.On("CreateRecommendation", mock.Anything).Return(assert.AnError)
+			expectedError:  true,
+			expectedStatus: model.RecommendationRunStatusFailed,
+			setupMocks: func(recRepo *MockRecommendationRepository, recCmd *MockRecommendationCommand, runRepo *MockRecommendationRunRepository) {
+				recCmd.On("PublishRun", mock.Anything).Return(assert.AnError)
+				runRepo.On("FinishRun", "run-1", model.RecommendationRunStatusFailed, 0, assert.AnError.Error()).Return(nil)
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockStockRepo := &MockStockRepository{}
 			mockRecRepo := &MockRecommendationRepository{}
 			mockRecCmd := &MockRecommendationCommand{}
+			mockRunRepo := &MockRecommendationRunRepository{}
 
-			tt.setupMocks(mockRecRepo)
+			tt.setupMocks(mockRecRepo, mockRecCmd, mockRunRepo)
 
 			service := &RecommendationService{
-				stockRepo:          mockStockRepo,
 				recommendationRepo: mockRecRepo,
 				recommendationCmd:  mockRecCmd,
+				runRepo:            mockRunRepo,
+				validator:          validator.NewRecommendationValidator(),
+				logger:             logrus.New(),
+				scoringConfig:      model.DefaultScoringConfig(),
+				retention:          DefaultRecommendationRetention,
+			}
+
+			run := &model.RecommendationRun{
+				ID:              "run-1",
+				Status:          model.RecommendationRunStatusRunning,
+				StartedAt:       time.Now(),
+				Recommendations: tt.recommendations,
+			}
+
+			err := service.SaveRecommendations(run)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.False(t, run.IsActive)
+				mockRecRepo.AssertNotCalled(t, "DeleteOldRecommendations", mock.Anything)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, run.IsActive)
+				for i, rec := range run.Recommendations {
+					assert.Equal(t, "run-1", rec.RunID)
+					assert.Equal(t, i+1, rec.Rank)
+				}
+			}
+			assert.Equal(t, tt.expectedStatus, run.Status)
+
+			mockRecRepo.AssertExpectations(t)
+			mockRecCmd.AssertExpectations(t)
+			mockRunRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRecommendationService_GetRun(t *testing.T) {
+	runID := "5f0c6f5e-7d7a-4c53-9d43-1f7c2f1b2a10"
+
+	tests := []struct {
+		name          string
+		runID         string
+		expectedError bool
+		errorContains string
+		setupMocks    func(*MockRecommendationRepository, *MockRecommendationRunRepository)
+	}{
+		{
+			name:  "run with recommendations",
+			runID: runID,
+			setupMocks: func(recRepo *MockRecommendationRepository, runRepo *MockRecommendationRunRepository) {
+				runRepo.On("GetRunByID", runID).Return(&model.RecommendationRun{ID: runID, Status: model.RecommendationRunStatusCompleted}, nil)
+				recRepo.On("GetRecommendationsByRun", runID).Return([]*model.Recommendation{{Ticker: "AAPL", RunID: runID}}, nil)
+			},
+		},
+		{
+			name:          "run not found",
+			runID:         runID,
+			expectedError: true,
+			errorContains: "not found",
+			setupMocks: func(recRepo *MockRecommendationRepository, runRepo *MockRecommendationRunRepository) {
+				runRepo.On("GetRunByID", runID).Return(nil, nil)
+			},
+		},
+		{
+			name:          "invalid id",
+			runID:         "not-a-uuid",
+			expectedError: true,
+			errorContains: "valid UUID",
+			setupMocks:    func(*MockRecommendationRepository, *MockRecommendationRunRepository) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRecRepo := &MockRecommendationRepository{}
+			mockRunRepo := &MockRecommendationRunRepository{}
+
+			tt.setupMocks(mockRecRepo, mockRunRepo)
+
+			service := &RecommendationService{
+				recommendationRepo: mockRecRepo,
+				runRepo:            mockRunRepo,
 				validator:          validator.NewRecommendationValidator(),
 				logger:             logrus.New(),
-				scoringConfig:      DefaultScoringConfig(),
 			}
 
-			err := service.SaveRecommendations(tt.recommendations)
+			run, err := service.GetRun(tt.runID)
 
 			if tt.expectedError {
 				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, run)
 			} else {
 				assert.NoError(t, err)
+				assert.Len(t, run.Recommendations, 1)
 			}
 
 			mockRecRepo.AssertExpectations(t)
+			mockRunRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestRecommendationService_ScoresNormalizedVariants(t *testing.T) {
+	repo := setupReferenceRepoMock()
+
+	scorer := NewAdditiveScorer(model.DefaultScoringConfig(), NewReferenceService(repo, logrus.New()))
+
+	canonical := &model.Stock{
+		Action:     "target raised by",
+		RatingTo:   "Buy",
+		TargetFrom: "$100.00",
+		TargetTo:   "$120.00",
+		Time:       time.Now(),
+	}
+	variant := &model.Stock{
+		Action:     "Price Target  Raised By",
+		RatingTo:   " BUY ",
+		TargetFrom: "$100.00",
+		TargetTo:   "$120.00",
+		Time:       time.Now(),
+	}
+
+	now := time.Now()
+	assert.Equal(t, scorer.scoreStock(canonical, now), scorer.scoreStock(variant, now))
+	assert.True(t, scorer.rules.isPositiveAction(variant.Action))
+	assert.True(t, scorer.rules.isPositiveRating(variant.RatingTo))
+}
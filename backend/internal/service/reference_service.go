@@ -0,0 +1,313 @@
+package service
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	repoInterfaces "github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+type ReferenceService struct {
+	referenceRepo repoInterfaces.ReferenceRepository
+	logger        *logrus.Logger
+
+	mutex      sync.RWMutex
+	loaded     bool
+	brokerages map[string]*model.Brokerage
+	// brokerageNames maps brokerage aliases onto canonical names, including
+	// ones whose brokerage does not exist yet.
+	brokerageNames map[string]string
+	ratings        map[string]*model.Rating
+	actions        map[string]*model.Action
+}
+
+var _ interfaces.ReferenceServiceInterface = (*ReferenceService)(nil)
+
+func NewReferenceService(referenceRepo repoInterfaces.ReferenceRepository, logger *logrus.Logger) *ReferenceService {
+	return &ReferenceService{
+		referenceRepo: referenceRepo,
+		logger:        logger,
+	}
+}
+
+// Load reads the canonical tables and aliases into memory. It is called lazily
+// on first use and can be called again to pick up newly added aliases.
+func (s *ReferenceService) Load() error {
+	brokerages, err := s.referenceRepo.GetBrokerages()
+	if err != nil {
+		return errors.NewDatabaseError("failed to load brokerages", err)
+	}
+	ratings, err := s.referenceRepo.GetRatings()
+	if err != nil {
+		return errors.NewDatabaseError("failed to load ratings", err)
+	}
+	actions, err := s.referenceRepo.GetActions()
+	if err != nil {
+		return errors.NewDatabaseError("failed to load actions", err)
+	}
+	aliases, err := s.referenceRepo.GetAliases()
+	if err != nil {
+		return errors.NewDatabaseError("failed to load reference aliases", err)
+	}
+
+	brokerageIndex := make(map[string]*model.Brokerage, len(brokerages))
+	for _, brokerage := range brokerages {
+		brokerageIndex[model.NormalizeReferenceValue(brokerage.Name)] = brokerage
+	}
+
+	ratingIndex := make(map[string]*model.Rating, len(ratings))
+	for _, rating := range ratings {
+		ratingIndex[model.NormalizeReferenceValue(rating.Name)] = rating
+	}
+
+	actionIndex := make(map[string]*model.Action, len(actions))
+	for _, action := range actions {
+		actionIndex[model.NormalizeReferenceValue(action.Name)] = action
+	}
+
+	brokerageNames := make(map[string]string)
+	for _, alias := range aliases {
+		key := model.NormalizeReferenceValue(alias.Alias)
+		canonical := model.NormalizeReferenceValue(alias.CanonicalName)
+
+		switch alias.Kind {
+		case model.ReferenceKindRating:
+			if rating, ok := ratingIndex[canonical]; ok {
+				ratingIndex[key] = rating
+			}
+		case model.ReferenceKindAction:
+			if action, ok := actionIndex[canonical]; ok {
+				actionIndex[key] = action
+			}
+		case model.ReferenceKindBrokerage:
+			brokerageNames[key] = alias.CanonicalName
+			if brokerage, ok := brokerageIndex[canonical]; ok {
+				brokerageIndex[key] = brokerage
+			}
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.brokerages = brokerageIndex
+	s.brokerageNames = brokerageNames
+	s.ratings = ratingIndex
+	s.actions = actionIndex
+	s.loaded = true
+
+	s.logger.WithFields(logrus.Fields{
+		"brokerages": len(brokerages),
+		"ratings":    len(ratings),
+		"actions":    len(actions),
+		"aliases":    len(aliases),
+	}).Debug("Loaded reference data")
+
+	return nil
+}
+
+// MapStocks sets the canonical reference ids on each stock. Unknown brokerages
+// are created on the fly; unknown ratings and actions are left unmapped and
+// recorded for review.
+func (s *ReferenceService) MapStocks(stocks []*model.Stock) error {
+	if err := s.ensureLoaded(); err != nil {
+		return err
+	}
+
+	unmappedRatings := make(map[string]int)
+	unmappedActions := make(map[string]int)
+
+	for _, stock := range stocks {
+		if stock == nil {
+			continue
+		}
+
+		brokerage, err := s.resolveBrokerage(stock.Brokerage)
+		if err != nil {
+			return err
+		}
+		if brokerage != nil {
+			stock.BrokerageID = &brokerage.ID
+		}
+
+		stock.RatingFromID = s.resolveRatingID(stock.RatingFrom, unmappedRatings)
+		stock.RatingToID = s.resolveRatingID(stock.RatingTo, unmappedRatings)
+		stock.ActionID = s.resolveActionID(stock.Action, unmappedActions)
+	}
+
+	if err := s.referenceRepo.RecordUnmapped(model.ReferenceKindRating, unmappedRatings); err != nil {
+		return errors.NewDatabaseError("failed to record unmapped ratings", err)
+	}
+	if err := s.referenceRepo.RecordUnmapped(model.ReferenceKindAction, unmappedActions); err != nil {
+		return errors.NewDatabaseError("failed to record unmapped actions", err)
+	}
+
+	if len(unmappedRatings) > 0 || len(unmappedActions) > 0 {
+		s.logger.WithFields(logrus.Fields{
+			"unmapped_ratings": len(unmappedRatings),
+			"unmapped_actions": len(unmappedActions),
+		}).Warn("Found unmapped reference values during ingestion")
+	}
+
+	return nil
+}
+
+// CanonicalRating returns the lowercase canonical rating for a raw value, or
+// the normalized raw value when no mapping exists.
+func (s *ReferenceService) CanonicalRating(raw string) string {
+	key := model.NormalizeReferenceValue(raw)
+	if err := s.ensureLoaded(); err != nil {
+		return key
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if rating, ok := s.ratings[key]; ok {
+		return strings.ToLower(rating.Name)
+	}
+	return key
+}
+
+// RatingRank returns the bullishness rank of a raw rating from the ratings
+// table, or false when the rating is not mapped. Until reference data loads
+// it falls back to the seeded ranks.
+func (s *ReferenceService) RatingRank(raw string) (int, bool) {
+	key := model.NormalizeReferenceValue(raw)
+	if err := s.ensureLoaded(); err != nil {
+		rank, ok := model.DefaultRatingRanks[key]
+		return rank, ok
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if rating, ok := s.ratings[key]; ok {
+		return rating.Rank, true
+	}
+	return 0, false
+}
+
+// CanonicalAction returns the lowercase canonical action for a raw value, or
+// the normalized raw value when no mapping exists.
+func (s *ReferenceService) CanonicalAction(raw string) string {
+	key := model.NormalizeReferenceValue(raw)
+	if err := s.ensureLoaded(); err != nil {
+		return key
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if action, ok := s.actions[key]; ok {
+		return strings.ToLower(action.Name)
+	}
+	return key
+}
+
+func (s *ReferenceService) GetUnmappedValues(kind model.ReferenceKind, limit, offset int) ([]*model.UnmappedReferenceValue, error) {
+	values, err := s.referenceRepo.GetUnmapped(kind, limit, offset)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get unmapped reference values")
+		return nil, errors.NewDatabaseError("failed to get unmapped reference values", err)
+	}
+
+	if values == nil {
+		values = []*model.UnmappedReferenceValue{}
+	}
+
+	return values, nil
+}
+
+func (s *ReferenceService) ensureLoaded() error {
+	s.mutex.RLock()
+	loaded := s.loaded
+	s.mutex.RUnlock()
+
+	if loaded {
+		return nil
+	}
+
+	if err := s.Load(); err != nil {
+		s.logger.WithError(err).Warn("Failed to load reference data")
+		return err
+	}
+	return nil
+}
+
+// resolveBrokerage maps raw onto its brokerage. Brokerages missing from
+// memory, such as ones added by another replica since Load, are looked up by
+// normalized name or alias before one is created under the canonical name:
+// the alias target, or raw with whitespace collapsed.
+func (s *ReferenceService) resolveBrokerage(raw string) (*model.Brokerage, error) {
+	name := strings.Join(strings.Fields(raw), " ")
+	if name == "" {
+		return nil, nil
+	}
+	key := model.NormalizeReferenceValue(name)
+
+	s.mutex.RLock()
+	brokerage, ok := s.brokerages[key]
+	if canonical, aliased := s.brokerageNames[key]; aliased {
+		name = canonical
+	}
+	s.mutex.RUnlock()
+	if ok {
+		return brokerage, nil
+	}
+
+	brokerage, err := s.referenceRepo.FindBrokerage(key)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to look up brokerage", err)
+	}
+	if brokerage == nil {
+		brokerage, err = s.referenceRepo.CreateBrokerage(name)
+		if err != nil {
+			return nil, errors.NewDatabaseError("failed to create brokerage", err)
+		}
+	}
+
+	s.mutex.Lock()
+	s.brokerages[key] = brokerage
+	s.mutex.Unlock()
+
+	return brokerage, nil
+}
+
+func (s *ReferenceService) resolveRatingID(raw string, unmapped map[string]int) *int64 {
+	key := model.NormalizeReferenceValue(raw)
+	if key == "" {
+		return nil
+	}
+
+	s.mutex.RLock()
+	rating, ok := s.ratings[key]
+	s.mutex.RUnlock()
+
+	if !ok {
+		unmapped[key]++
+		return nil
+	}
+	return &rating.ID
+}
+
+func (s *ReferenceService) resolveActionID(raw string, unmapped map[string]int) *int64 {
+	key := model.NormalizeReferenceValue(raw)
+	if key == "" {
+		return nil
+	}
+
+	s.mutex.RLock()
+	action, ok := s.actions[key]
+	s.mutex.RUnlock()
+
+	if !ok {
+		unmapped[key]++
+		return nil
+	}
+	return &action.ID
+}
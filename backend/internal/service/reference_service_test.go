@@ -0,0 +1,138 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func setupReferenceRepoMock() *MockReferenceRepository {
+	repo := &MockReferenceRepository{}
+	repo.On("GetBrokerages").Return([]*model.Brokerage{
+		{ID: 1, Name: "Goldman Sachs"},
+	}, nil)
+	repo.On("GetRatings").Return([]*model.Rating{
+		{ID: 10, Name: "Buy", Rank: 5},
+		{ID: 11, Name: "Equal Weight", Rank: 3},
+	}, nil)
+	repo.On("GetActions").Return([]*model.Action{
+		{ID: 20, Name: "target raised by"},
+	}, nil)
+	repo.On("GetAliases").Return([]*model.ReferenceAlias{
+		{Kind: model.ReferenceKindRating, Alias: "equal-weight", CanonicalName: "Equal Weight"},
+		{Kind: model.ReferenceKindAction, Alias: "price target raised by", CanonicalName: "target raised by"},
+	}, nil)
+	return repo
+}
+
+func TestReferenceService_MapStocks(t *testing.T) {
+	repo := setupReferenceRepoMock()
+	repo.On("FindBrokerage", "new brokerage").Return(nil, nil).Once()
+	repo.On("CreateBrokerage", "New Brokerage").Return(&model.Brokerage{ID: 2, Name: "New Brokerage"}, nil).Once()
+	repo.On("RecordUnmapped", model.ReferenceKindRating, map[string]int{"mystery rating": 2}).Return(nil)
+	repo.On("RecordUnmapped", model.ReferenceKindAction, map[string]int{"target teleported by": 1}).Return(nil)
+
+	service := NewReferenceService(repo, logrus.New())
+
+	stocks := []*model.Stock{
+		{
+			Ticker:     "AAPL",
+			Brokerage:  "goldman  sachs",
+			RatingFrom: "Equal-Weight",
+			RatingTo:   "BUY",
+			Action:     "Price Target Raised By",
+		},
+		{
+			Ticker:     "MSFT",
+			Brokerage:  "New Brokerage",
+			RatingFrom: "Mystery Rating",
+			RatingTo:   "mystery rating",
+			Action:     "target teleported by",
+		},
+		{
+			Ticker:    "TSLA",
+			Brokerage: "New Brokerage",
+		},
+	}
+
+	err := service.MapStocks(stocks)
+	require.NoError(t, err)
+
+	require.NotNil(t, stocks[0].BrokerageID)
+	assert.Equal(t, int64(1), *stocks[0].BrokerageID)
+	require.NotNil(t, stocks[0].RatingFromID)
+	assert.Equal(t, int64(11), *stocks[0].RatingFromID)
+	require.NotNil(t, stocks[0].RatingToID)
+	assert.Equal(t, int64(10), *stocks[0].RatingToID)
+	require.NotNil(t, stocks[0].ActionID)
+	assert.Equal(t, int64(20), *stocks[0].ActionID)
+
+	require.NotNil(t, stocks[1].BrokerageID)
+	assert.Equal(t, int64(2), *stocks[1].BrokerageID)
+	assert.Nil(t, stocks[1].RatingFromID)
+	assert.Nil(t, stocks[1].RatingToID)
+	assert.Nil(t, stocks[1].ActionID)
+
+	require.NotNil(t, stocks[2].BrokerageID)
+	assert.Equal(t, int64(2), *stocks[2].BrokerageID)
+	assert.Nil(t, stocks[2].RatingToID)
+
+	repo.AssertExpectations(t)
+}
+
+func TestReferenceService_MapStocksReusesStoredBrokerages(t *testing.T) {
+	repo := &MockReferenceRepository{}
+	repo.On("GetBrokerages").Return([]*model.Brokerage{}, nil)
+	repo.On("GetRatings").Return([]*model.Rating{}, nil)
+	repo.On("GetActions").Return([]*model.Action{}, nil)
+	repo.On("GetAliases").Return([]*model.ReferenceAlias{
+		{Kind: model.ReferenceKindBrokerage, Alias: "GS", CanonicalName: "Goldman Sachs"},
+	}, nil)
+	// Created by another replica since Load
+	repo.On("FindBrokerage", "jp morgan").Return(&model.Brokerage{ID: 3, Name: "JP Morgan"}, nil).Once()
+	repo.On("FindBrokerage", "gs").Return(nil, nil).Once()
+	repo.On("CreateBrokerage", "Goldman Sachs").Return(&model.Brokerage{ID: 4, Name: "Goldman Sachs"}, nil).Once()
+	repo.On("RecordUnmapped", mock.Anything, map[string]int{}).Return(nil)
+
+	service := NewReferenceService(repo, logrus.New())
+
+	stocks := []*model.Stock{
+		{Ticker: "AAPL", Brokerage: " jp  MORGAN "},
+		{Ticker: "MSFT", Brokerage: "gs"},
+		{Ticker: "TSLA", Brokerage: "JP Morgan"},
+	}
+	require.NoError(t, service.MapStocks(stocks))
+
+	assert.Equal(t, int64(3), *stocks[0].BrokerageID)
+	assert.Equal(t, int64(4), *stocks[1].BrokerageID)
+	assert.Equal(t, int64(3), *stocks[2].BrokerageID)
+	repo.AssertNotCalled(t, "CreateBrokerage", "jp  MORGAN")
+	repo.AssertExpectations(t)
+}
+
+func TestReferenceService_Canonical(t *testing.T) {
+	repo := setupReferenceRepoMock()
+	service := NewReferenceService(repo, logrus.New())
+
+	assert.Equal(t, "target raised by", service.CanonicalAction("Price Target  Raised by"))
+	assert.Equal(t, "equal weight", service.CanonicalRating("Equal-Weight"))
+	assert.Equal(t, "buy", service.CanonicalRating(" Buy "))
+	assert.Equal(t, "unknown rating", service.CanonicalRating("Unknown   Rating"))
+}
+
+func TestReferenceService_LoadError(t *testing.T) {
+	repo := &MockReferenceRepository{}
+	repo.On("GetBrokerages").Return([]*model.Brokerage{}, assert.AnError)
+
+	service := NewReferenceService(repo, logrus.New())
+
+	err := service.MapStocks([]*model.Stock{{Ticker: "AAPL"}})
+	assert.Error(t, err)
+
+	assert.Equal(t, "target raised by", service.CanonicalAction("Target Raised By"))
+	repo.AssertNotCalled(t, "RecordUnmapped", mock.Anything, mock.Anything)
+}
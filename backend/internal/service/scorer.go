@@ -0,0 +1,264 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/utils"
+)
+
+const DefaultScorerName = "additive"
+
+// Scorer turns the analyst events of a recommendation window into scored
+// candidates. asOf is the reference time used for freshness.
+type Scorer interface {
+	Name() string
+	Score(stocks []*model.Stock, asOf time.Time) []StockScore
+}
+
+// ReferenceNormalizer maps raw upstream spellings onto canonical names and
+// ranks ratings by bullishness.
+type ReferenceNormalizer interface {
+	CanonicalAction(action string) string
+	CanonicalRating(rating string) string
+	RatingRank(rating string) (int, bool)
+}
+
+type ScorerFactory func(config *model.ScoringConfig, normalizer ReferenceNormalizer) Scorer
+
+var (
+	scorerRegistryMu sync.RWMutex
+	scorerRegistry   = map[string]ScorerFactory{
+		"additive": func(config *model.ScoringConfig, normalizer ReferenceNormalizer) Scorer {
+			return NewAdditiveScorer(config, normalizer)
+		},
+		"consensus": func(config *model.ScoringConfig, normalizer ReferenceNormalizer) Scorer {
+			return NewConsensusScorer(config, normalizer)
+		},
+	}
+)
+
+// RegisterScorer makes a scoring strategy selectable by name, replacing any
+// strategy previously registered under the same name.
+func RegisterScorer(name string, factory ScorerFactory) {
+	scorerRegistryMu.Lock()
+	defer scorerRegistryMu.Unlock()
+
+	scorerRegistry[strings.ToLower(strings.TrimSpace(name))] = factory
+}
+
+// NewScorer builds the named strategy. An empty name selects DefaultScorerName.
+func NewScorer(name string, config *model.ScoringConfig, normalizer ReferenceNormalizer) (Scorer, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = DefaultScorerName
+	}
+
+	scorerRegistryMu.RLock()
+	factory, ok := scorerRegistry[name]
+	scorerRegistryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown scoring strategy %q (available: %s)", name, strings.Join(AvailableScorers(), ", "))
+	}
+
+	return factory(config, normalizer), nil
+}
+
+func AvailableScorers() []string {
+	scorerRegistryMu.RLock()
+	defer scorerRegistryMu.RUnlock()
+
+	names := make([]string, 0, len(scorerRegistry))
+	for name := range scorerRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type plainNormalizer struct{}
+
+func (plainNormalizer) CanonicalAction(action string) string {
+	return model.NormalizeReferenceValue(action)
+}
+
+func (plainNormalizer) CanonicalRating(rating string) string {
+	return model.NormalizeReferenceValue(rating)
+}
+
+func (plainNormalizer) RatingRank(rating string) (int, bool) {
+	rank, ok := model.DefaultRatingRanks[model.NormalizeReferenceValue(rating)]
+	return rank, ok
+}
+
+// scoringRules holds the per-event point tables shared by the built-in scorers.
+type scoringRules struct {
+	config     *model.ScoringConfig
+	normalizer ReferenceNormalizer
+}
+
+func newScoringRules(config *model.ScoringConfig, normalizer ReferenceNormalizer) scoringRules {
+	if config == nil {
+		config = model.DefaultScoringConfig()
+	}
+	if normalizer == nil {
+		normalizer = plainNormalizer{}
+	}
+	return scoringRules{config: config, normalizer: normalizer}
+}
+
+func (r scoringRules) eventScore(stock *model.Stock) int {
+	return r.actionScore(stock.Action) + r.ratingScore(stock.RatingTo) + r.targetChangeScore(stock.TargetFrom, stock.TargetTo)
+}
+
+func (r scoringRules) actionScore(action string) int {
+	switch r.normalizer.CanonicalAction(action) {
+	case "target raised by":
+		return r.config.TargetRaisedScore
+	case "upgraded by":
+		return r.config.UpgradedScore
+	case "initiated by":
+		return r.config.InitiatedScore
+	case "target maintained by":
+		return r.config.TargetMaintainedScore
+	default:
+		return 0
+	}
+}
+
+// ratingScore scores a target rating by its bullishness rank; unranked and
+// bearish ratings score nothing. Sector Perform and Equal Weight keep the
+// scores they had before ratings were ranked.
+func (r scoringRules) ratingScore(rating string) int {
+	switch r.normalizer.CanonicalRating(rating) {
+	case "sector perform":
+		return r.config.SectorPerformScore
+	case "equal weight":
+		return r.config.EqualWeightScore
+	}
+
+	rank, _ := r.normalizer.RatingRank(rating)
+	switch {
+	case rank >= model.RatingRankBuy:
+		return r.config.BuyScore
+	case rank == model.RatingRankOutperform:
+		return r.config.OverweightScore
+	case rank == model.RatingRankHold:
+		return r.config.NeutralScore
+	default:
+		return 0
+	}
+}
+
+func (r scoringRules) targetChangeScore(targetFrom, targetTo string) int {
+	changePercent, ok := targetChangePercent(targetFrom, targetTo)
+	if !ok {
+		return 0
+	}
+
+	switch {
+	case changePercent > r.config.HighTargetChangePercent:
+		return r.config.HighTargetChangeScore
+	case changePercent > r.config.MediumTargetChangePercent:
+		return r.config.MediumTargetChangeScore
+	case changePercent > r.config.LowTargetChangePercent:
+		return r.config.LowTargetChangeScore
+	case changePercent > r.config.MinTargetChangePercent:
+		return r.config.MinTargetChangeScore
+	case changePercent > 0:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func (r scoringRules) freshnessScore(stockTime, asOf time.Time) int {
+	daysSince := int(asOf.Sub(stockTime).Hours() / 24)
+
+	switch {
+	case daysSince == 0:
+		return r.config.TodayScore
+	case daysSince == 1:
+		return r.config.YesterdayScore
+	case daysSince <= 3:
+		return r.config.ThreeDaysScore
+	case daysSince <= 7:
+		return r.config.WeekScore
+	default:
+		return 0
+	}
+}
+
+func (r scoringRules) isPositiveAction(action string) bool {
+	switch r.normalizer.CanonicalAction(action) {
+	case "target raised by", "upgraded by", "initiated by", "target maintained by":
+		return true
+	default:
+		return false
+	}
+}
+
+// isPositiveRating reports ratings ranked outperform or higher, and Sector
+// Perform, as positive.
+func (r scoringRules) isPositiveRating(rating string) bool {
+	if r.normalizer.CanonicalRating(rating) == "sector perform" {
+		return true
+	}
+	rank, ok := r.normalizer.RatingRank(rating)
+	return ok && rank >= model.RatingRankOutperform
+}
+
+func (r scoringRules) isBullish(stock *model.Stock) bool {
+	return r.isPositiveAction(stock.Action) && r.isPositiveRating(stock.RatingTo)
+}
+
+func (r scoringRules) actionExplanation(action string) string {
+	switch r.normalizer.CanonicalAction(action) {
+	case "target raised by":
+		return "Target price raised"
+	case "upgraded by":
+		return "Rating upgraded"
+	case "initiated by":
+		return "New coverage initiated"
+	case "target maintained by":
+		return "Target price maintained"
+	default:
+		return ""
+	}
+}
+
+// ratingExplanation names target ratings ranked outperform or higher, such
+// as "Buy rating".
+func (r scoringRules) ratingExplanation(rating string) string {
+	if rank, ok := r.normalizer.RatingRank(rating); !ok || rank < model.RatingRankOutperform {
+		return ""
+	}
+	canonical := r.normalizer.CanonicalRating(rating)
+	return strings.ToUpper(canonical[:1]) + canonical[1:] + " rating"
+}
+
+func targetChangePercent(targetFrom, targetTo string) (float64, bool) {
+	fromPrice, ok1 := utils.TryParsePrice(targetFrom)
+	toPrice, ok2 := utils.TryParsePrice(targetTo)
+
+	if !ok1 || !ok2 || fromPrice <= 0 || toPrice <= 0 {
+		return 0, false
+	}
+
+	return ((toPrice - fromPrice) / fromPrice) * 100, true
+}
+
+func joinReasons(reasons []string) string {
+	var nonEmpty []string
+	for _, reason := range reasons {
+		if reason != "" {
+			nonEmpty = append(nonEmpty, reason)
+		}
+	}
+	return strings.Join(nonEmpty, ", ")
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestNewScorer(t *testing.T) {
+	tests := []struct {
+		name         string
+		strategy     string
+		expectedName string
+		expectedErr  bool
+	}{
+		{name: "empty selects default", strategy: "", expectedName: DefaultScorerName},
+		{name: "additive", strategy: "additive", expectedName: "additive"},
+		{name: "consensus is case insensitive", strategy: " Consensus ", expectedName: "consensus"},
+		{name: "unknown strategy", strategy: "momentum", expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer, err := NewScorer(tt.strategy, model.DefaultScoringConfig(), nil)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, scorer)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, scorer.Name())
+		})
+	}
+}
+
+func TestRegisterScorer(t *testing.T) {
+	RegisterScorer("test-only", func(config *model.ScoringConfig, normalizer ReferenceNormalizer) Scorer {
+		return NewAdditiveScorer(config, normalizer)
+	})
+	defer func() {
+		scorerRegistryMu.Lock()
+		delete(scorerRegistry, "test-only")
+		scorerRegistryMu.Unlock()
+	}()
+
+	assert.Contains(t, AvailableScorers(), "test-only")
+
+	scorer, err := NewScorer("test-only", nil, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, scorer)
+}
+
+func TestScoringRules_ScoredValues(t *testing.T) {
+	rules := newScoringRules(model.DefaultScoringConfig(), nil)
+
+	// The data quality checks treat this list as what scoring recognizes
+	for _, action := range model.ScoredActions {
+		assert.Positive(t, rules.actionScore(action), action)
+	}
+	assert.Zero(t, rules.actionScore("downgraded by"))
+}
+
+func TestScoringRules_RatingsByRank(t *testing.T) {
+	config := model.DefaultScoringConfig()
+	rules := newScoringRules(config, nil)
+
+	tests := []struct {
+		rating       string
+		wantScore    int
+		wantPositive bool
+	}{
+		{rating: "Strong-Buy", wantScore: config.BuyScore, wantPositive: true},
+		{rating: "Buy", wantScore: config.BuyScore, wantPositive: true},
+		{rating: "Outperform", wantScore: config.OverweightScore, wantPositive: true},
+		{rating: "Market Outperform", wantScore: config.OverweightScore, wantPositive: true},
+		{rating: "Positive", wantScore: config.OverweightScore, wantPositive: true},
+		{rating: "Sector Perform", wantScore: config.SectorPerformScore, wantPositive: true},
+		{rating: "Equal Weight", wantScore: config.EqualWeightScore},
+		{rating: "Neutral", wantScore: config.NeutralScore},
+		{rating: "Hold", wantScore: config.NeutralScore},
+		{rating: "Underperform"},
+		{rating: "Sell"},
+		{rating: "Accumulate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rating, func(t *testing.T) {
+			assert.Equal(t, tt.wantScore, rules.ratingScore(tt.rating))
+			assert.Equal(t, tt.wantPositive, rules.isPositiveRating(tt.rating))
+		})
+	}
+}
+
+func TestAdditiveScorer_Score(t *testing.T) {
+	now := time.Now()
+	scorer := NewAdditiveScorer(model.DefaultScoringConfig(), nil)
+
+	stocks := []*model.Stock{
+		{Ticker: "AAPL", Action: "target raised by", RatingTo: "Buy", TargetFrom: "$100.00", TargetTo: "$160.00", Brokerage: "Goldman", Time: now},
+		{Ticker: "MSFT", Action: "downgraded by", RatingTo: "Sell", TargetFrom: "$100.00", TargetTo: "$80.00", Time: now},
+	}
+
+	scores := scorer.Score(stocks, now)
+
+	require.Len(t, scores, 1)
+	assert.Equal(t, "AAPL", scores[0].Stock.Ticker)
+	assert.Equal(t, 100, scores[0].Score)
+	assert.Equal(t, "Target price raised, Buy rating, Target raised by 60.0%, by Goldman, Score: 100/100", scores[0].Explanation)
+}
+
+func TestConsensusScorer_Score(t *testing.T) {
+	now := time.Now()
+	scorer := NewConsensusScorer(model.DefaultScoringConfig(), nil)
+
+	stocks := []*model.Stock{
+		// AAPL: two brokerages bullish, one bearish
+		{Ticker: "AAPL", Brokerage: "Goldman", Action: "target raised by", RatingTo: "Buy", TargetFrom: "$100.00", TargetTo: "$160.00", Time: now},
+		{Ticker: "AAPL", Brokerage: "Morgan", Action: "upgraded by", RatingTo: "Overweight", TargetFrom: "$100.00", TargetTo: "$120.00", Time: now.Add(-time.Hour)},
+		{Ticker: "AAPL", Brokerage: "Barclays", Action: "downgraded by", RatingTo: "Sell", TargetFrom: "$100.00", TargetTo: "$90.00", Time: now.Add(-2 * time.Hour)},
+		// Older Goldman event is superseded by the latest one
+		{Ticker: "AAPL", Brokerage: "goldman ", Action: "downgraded by", RatingTo: "Sell", Time: now.AddDate(0, 0, -2)},
+		// MSFT: split vote has no bullish majority
+		{Ticker: "MSFT", Brokerage: "Goldman", Action: "target raised by", RatingTo: "Buy", Time: now},
+		{Ticker: "MSFT", Brokerage: "Morgan", Action: "downgraded by", RatingTo: "Sell", Time: now},
+	}
+
+	scores := scorer.Score(stocks, now)
+
+	require.Len(t, scores, 1)
+	assert.Equal(t, "AAPL", scores[0].Stock.Ticker)
+	assert.Equal(t, "Goldman", scores[0].Stock.Brokerage)
+	// Event scores 85, 65 and 0 average to 50, plus 15 for freshness
+	assert.Equal(t, 65, scores[0].Score)
+	assert.Contains(t, scores[0].Explanation, "Consensus of 3 brokerages (2 bullish)")
+	assert.Contains(t, scores[0].Explanation, "Average target change +23.3%")
+}
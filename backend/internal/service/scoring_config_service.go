@@ -0,0 +1,179 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	repoInterfaces "github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+	"github.com/valeriapadilla/stock-insights/internal/validator"
+	"gopkg.in/yaml.v3"
+)
+
+// ScoringConfigService resolves the scoring weights used by the recommendation
+// engine. The active database version wins, then the optional config file,
+// then model.DefaultScoringConfig.
+type ScoringConfigService struct {
+	scoringConfigRepo repoInterfaces.ScoringConfigRepository
+	configFile        string
+	validator         *validator.ScoringConfigValidator
+	logger            *logrus.Logger
+}
+
+var _ interfaces.ScoringConfigServiceInterface = (*ScoringConfigService)(nil)
+
+func NewScoringConfigService(
+	scoringConfigRepo repoInterfaces.ScoringConfigRepository,
+	configFile string,
+	logger *logrus.Logger,
+) *ScoringConfigService {
+	return &ScoringConfigService{
+		scoringConfigRepo: scoringConfigRepo,
+		configFile:        configFile,
+		validator:         validator.NewScoringConfigValidator(),
+		logger:            logger,
+	}
+}
+
+func (s *ScoringConfigService) GetActiveConfig() (*model.ScoringConfig, string, error) {
+	active, err := s.scoringConfigRepo.GetActive()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get active scoring config")
+		return nil, "", errors.NewDatabaseError("failed to get active scoring config", err)
+	}
+
+	if active != nil {
+		config, err := decodeScoringConfig(active.Config)
+		if err != nil {
+			return nil, "", errors.NewInternalError(fmt.Sprintf("stored scoring config %s is invalid", active.Version), err)
+		}
+		return config, active.Version, nil
+	}
+
+	if s.configFile != "" {
+		version, config, err := LoadScoringConfigFile(s.configFile)
+		if err != nil {
+			s.logger.WithError(err).WithField("file", s.configFile).Error("Failed to load scoring config file")
+			return nil, "", errors.NewInternalError("failed to load scoring config file", err)
+		}
+		if err := s.validator.Validate(config); err != nil {
+			return nil, "", errors.NewInternalError("scoring config file is invalid", err)
+		}
+		return config, version, nil
+	}
+
+	return model.DefaultScoringConfig(), model.DefaultScoringConfigVersion, nil
+}
+
+func (s *ScoringConfigService) ListConfigs(limit, offset int) ([]*model.ScoringConfigVersion, int, error) {
+	configs, err := s.scoringConfigRepo.ListConfigs(limit, offset)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list scoring configs")
+		return nil, 0, errors.NewDatabaseError("failed to list scoring configs", err)
+	}
+
+	total, err := s.scoringConfigRepo.CountConfigs()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to count scoring configs")
+		return nil, 0, errors.NewDatabaseError("failed to count scoring configs", err)
+	}
+
+	return configs, total, nil
+}
+
+func (s *ScoringConfigService) CreateConfig(params interfaces.CreateScoringConfigParams) (*model.ScoringConfigVersion, error) {
+	version := strings.TrimSpace(params.Version)
+	if err := s.validator.ValidateVersion(version); err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+	if version == model.DefaultScoringConfigVersion {
+		return nil, errors.NewValidationError(fmt.Sprintf("version %q is reserved", version), nil)
+	}
+	if err := s.validator.Validate(params.Config); err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+
+	existing, err := s.scoringConfigRepo.GetByVersion(version)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to check scoring config version", err)
+	}
+	if existing != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("scoring config version %s already exists", version), nil)
+	}
+
+	rawConfig, err := json.Marshal(params.Config)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to encode scoring config", err)
+	}
+
+	config := &model.ScoringConfigVersion{
+		Version:     version,
+		Description: strings.TrimSpace(params.Description),
+		Config:      rawConfig,
+	}
+	if err := s.scoringConfigRepo.CreateConfig(config); err != nil {
+		s.logger.WithError(err).WithField("version", version).Error("Failed to create scoring config")
+		return nil, errors.NewDatabaseError("failed to create scoring config", err)
+	}
+
+	s.logger.WithField("version", version).Info("Scoring config created")
+	return config, nil
+}
+
+func (s *ScoringConfigService) ActivateConfig(version string) (*model.ScoringConfigVersion, error) {
+	activated, err := s.scoringConfigRepo.ActivateConfig(version)
+	if err != nil {
+		s.logger.WithError(err).WithField("version", version).Error("Failed to activate scoring config")
+		return nil, errors.NewDatabaseError("failed to activate scoring config", err)
+	}
+	if !activated {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("scoring config version %s not found", version), nil)
+	}
+
+	config, err := s.scoringConfigRepo.GetByVersion(version)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to get scoring config", err)
+	}
+
+	s.logger.WithField("version", version).Info("Scoring config activated")
+	return config, nil
+}
+
+type scoringConfigFile struct {
+	Version     string               `yaml:"version"`
+	Description string               `yaml:"description"`
+	Config      *model.ScoringConfig `yaml:"config"`
+}
+
+// LoadScoringConfigFile reads a YAML or JSON scoring config. Weights missing
+// from the file keep their model.DefaultScoringConfig value.
+func LoadScoringConfigFile(path string) (string, *model.ScoringConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read scoring config file: %w", err)
+	}
+
+	file := scoringConfigFile{Config: model.DefaultScoringConfig()}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return "", nil, fmt.Errorf("failed to parse scoring config file %s: %w", path, err)
+	}
+
+	if strings.TrimSpace(file.Version) == "" {
+		return "", nil, fmt.Errorf("scoring config file %s has no version", path)
+	}
+
+	return strings.TrimSpace(file.Version), file.Config, nil
+}
+
+func decodeScoringConfig(raw json.RawMessage) (*model.ScoringConfig, error) {
+	config := model.DefaultScoringConfig()
+	if err := json.Unmarshal(raw, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
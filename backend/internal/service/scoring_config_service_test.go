@@ -0,0 +1,185 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appErrors "github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
+)
+
+func TestScoringConfigService_GetActiveConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "scoring.yaml")
+	err := os.WriteFile(configFile, []byte("version: file-v1\nconfig:\n  buy_score: 22\n"), 0o600)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		configFile      string
+		setupMocks      func(*MockScoringConfigRepository)
+		expectedVersion string
+		expectedBuy     int
+	}{
+		{
+			name:       "active database version wins",
+			configFile: configFile,
+			setupMocks: func(repo *MockScoringConfigRepository) {
+				repo.On("GetActive").Return(&model.ScoringConfigVersion{
+					Version: "db-v2",
+					Config:  json.RawMessage(`{"buy_score": 18}`),
+				}, nil)
+			},
+			expectedVersion: "db-v2",
+			expectedBuy:     18,
+		},
+		{
+			name:       "falls back to config file",
+			configFile: configFile,
+			setupMocks: func(repo *MockScoringConfigRepository) {
+				repo.On("GetActive").Return(nil, nil)
+			},
+			expectedVersion: "file-v1",
+			expectedBuy:     22,
+		},
+		{
+			name: "falls back to defaults",
+			setupMocks: func(repo *MockScoringConfigRepository) {
+				repo.On("GetActive").Return(nil, nil)
+			},
+			expectedVersion: model.DefaultScoringConfigVersion,
+			expectedBuy:     25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockScoringConfigRepository{}
+			tt.setupMocks(repo)
+
+			service := NewScoringConfigService(repo, tt.configFile, logrus.New())
+
+			config, version, err := service.GetActiveConfig()
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, version)
+			assert.Equal(t, tt.expectedBuy, config.BuyScore)
+			// Weights not overridden keep their defaults
+			assert.Equal(t, 40, config.TargetRaisedScore)
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLoadScoringConfigFile(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonFile := filepath.Join(dir, "scoring.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"version": "json-v1", "config": {"today_score": 10}}`), 0o600))
+
+	version, config, err := LoadScoringConfigFile(jsonFile)
+	require.NoError(t, err)
+	assert.Equal(t, "json-v1", version)
+	assert.Equal(t, 10, config.TodayScore)
+
+	noVersion := filepath.Join(dir, "no-version.yaml")
+	require.NoError(t, os.WriteFile(noVersion, []byte("config:\n  today_score: 10\n"), 0o600))
+
+	_, _, err = LoadScoringConfigFile(noVersion)
+	assert.Error(t, err)
+
+	_, _, err = LoadScoringConfigFile(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestScoringConfigService_CreateConfig(t *testing.T) {
+	invalid := model.DefaultScoringConfig()
+	invalid.TodayScore = 60
+
+	tests := []struct {
+		name         string
+		params       interfaces.CreateScoringConfigParams
+		setupMocks   func(*MockScoringConfigRepository)
+		expectedType appErrors.ErrorType
+	}{
+		{
+			name:   "successful create",
+			params: interfaces.CreateScoringConfigParams{Version: "v2", Config: model.DefaultScoringConfig()},
+			setupMocks: func(repo *MockScoringConfigRepository) {
+				repo.On("GetByVersion", "v2").Return(nil, nil)
+				repo.On("CreateConfig", mock.MatchedBy(func(config *model.ScoringConfigVersion) bool {
+					return config.Version == "v2" && len(config.Config) > 0
+				})).Return(nil)
+			},
+		},
+		{
+			name:         "invalid weights",
+			params:       interfaces.CreateScoringConfigParams{Version: "v2", Config: invalid},
+			setupMocks:   func(repo *MockScoringConfigRepository) {},
+			expectedType: appErrors.ErrorTypeValidation,
+		},
+		{
+			name:         "reserved version",
+			params:       interfaces.CreateScoringConfigParams{Version: "default", Config: model.DefaultScoringConfig()},
+			setupMocks:   func(repo *MockScoringConfigRepository) {},
+			expectedType: appErrors.ErrorTypeValidation,
+		},
+		{
+			name:   "duplicate version",
+			params: interfaces.CreateScoringConfigParams{Version: "v1", Config: model.DefaultScoringConfig()},
+			setupMocks: func(repo *MockScoringConfigRepository) {
+				repo.On("GetByVersion", "v1").Return(&model.ScoringConfigVersion{Version: "v1"}, nil)
+			},
+			expectedType: appErrors.ErrorTypeValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockScoringConfigRepository{}
+			tt.setupMocks(repo)
+
+			service := NewScoringConfigService(repo, "", logrus.New())
+
+			config, err := service.CreateConfig(tt.params)
+
+			if tt.expectedType != "" {
+				require.Error(t, err)
+				appErr, ok := err.(*appErrors.AppError)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedType, appErr.Type)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.params.Version, config.Version)
+			}
+
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScoringConfigService_ActivateConfig(t *testing.T) {
+	repo := &MockScoringConfigRepository{}
+	repo.On("ActivateConfig", "missing").Return(false, nil)
+	repo.On("ActivateConfig", "v2").Return(true, nil)
+	repo.On("GetByVersion", "v2").Return(&model.ScoringConfigVersion{Version: "v2", IsActive: true}, nil)
+
+	service := NewScoringConfigService(repo, "", logrus.New())
+
+	_, err := service.ActivateConfig("missing")
+	require.Error(t, err)
+	assert.Equal(t, appErrors.ErrorTypeNotFound, err.(*appErrors.AppError).Type)
+
+	config, err := service.ActivateConfig("v2")
+	require.NoError(t, err)
+	assert.True(t, config.IsActive)
+
+	repo.AssertExpectations(t)
+}
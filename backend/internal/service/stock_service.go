@@ -14,8 +14,10 @@ import (
 )
 
 type StockService struct {
-	stockRepo repoInterfaces.StockRepository
-	logger    *logrus.Logger
+	stockRepo    repoInterfaces.StockRepository
+	revisionRepo repoInterfaces.StockRevisionRepository
+	prices       interfaces.PriceServiceInterface
+	logger       *logrus.Logger
 }
 
 var _ interfaces.StockServiceInterface = (*StockService)(nil)
@@ -27,6 +29,16 @@ func NewStockService(stockRepo repoInterfaces.StockRepository, logger *logrus.Lo
 	}
 }
 
+// SetPriceService enables last close and upside on stock detail responses.
+func (s *StockService) SetPriceService(prices interfaces.PriceServiceInterface) {
+	s.prices = prices
+}
+
+// SetRevisionRepository enables GetStockRevisions.
+func (s *StockService) SetRevisionRepository(revisionRepo repoInterfaces.StockRevisionRepository) {
+	s.revisionRepo = revisionRepo
+}
+
 func (s *StockService) ListStocks(limit, offset int, sort, order string) ([]*model.Stock, int, error) {
 	if limit <= 0 {
 		limit = 50
@@ -75,11 +87,15 @@ func (s *StockService) GetStock(ticket string) (*model.Stock, error) {
 	}
 
 	s.calculateChangePercentForStock(stock)
+	s.attachLastClose(stock)
 
 	return stock, nil
 }
 
-func (s *StockService) SearchStocks(params interfaces.StockSearchParams) ([]*model.Stock, int, error) {
+func (s *StockService) GetStockHistory(params interfaces.StockHistoryParams) ([]*model.Stock, int, error) {
+	if params.Ticket == "" {
+		return nil, 0, errors.NewValidationError("ticket is required", nil)
+	}
 	if params.Limit <= 0 {
 		params.Limit = 50
 	}
@@ -87,20 +103,83 @@ func (s *StockService) SearchStocks(params interfaces.StockSearchParams) ([]*mod
 		params.Offset = 0
 	}
 
-	var dateFrom, dateTo *time.Time
-	if params.DateFrom != "" {
-		if parsed, err := time.Parse("2006-01-02", params.DateFrom); err == nil {
-			dateFrom = &parsed
-		}
+	dateFrom, dateTo := s.parseDateRange(params.DateFrom, params.DateTo)
+
+	filters := repoInterfaces.StockHistoryFilters{
+		DateFrom: dateFrom,
+		DateTo:   dateTo,
+		Limit:    params.Limit,
+		Offset:   params.Offset,
 	}
 
-	if params.DateTo != "" {
-		if parsed, err := time.Parse("2006-01-02", params.DateTo); err == nil {
-			endOfDay := parsed.Add(24*time.Hour - time.Nanosecond)
-			dateTo = &endOfDay
+	total, err := s.stockRepo.GetStockHistoryCount(params.Ticket, filters)
+	if err != nil {
+		s.logger.WithError(err).WithField("ticket", params.Ticket).Error("Failed to get stock history count from repository")
+		return nil, 0, errors.NewDatabaseError("failed to get stock history count", err)
+	}
+
+	if total == 0 {
+		exists, err := s.stockRepo.ExistsByTicker(params.Ticket)
+		if err != nil {
+			s.logger.WithError(err).WithField("ticket", params.Ticket).Error("Failed to check stock existence")
+			return nil, 0, errors.NewDatabaseError("failed to retrieve stock history", err)
+		}
+		if !exists {
+			return nil, 0, errors.NewNotFoundError("stock not found", nil)
 		}
+		return []*model.Stock{}, 0, nil
+	}
+
+	history, err := s.stockRepo.GetStockHistory(params.Ticket, filters)
+	if err != nil {
+		s.logger.WithError(err).WithField("ticket", params.Ticket).Error("Failed to get stock history from repository")
+		return nil, 0, errors.NewDatabaseError("failed to retrieve stock history", err)
+	}
+
+	s.calculateChangePercentForStocks(history)
+
+	return history, total, nil
+}
+
+// GetStockRevisions lists how upstream corrections changed the event of
+// ticket at eventTime, an RFC3339 timestamp, oldest first.
+func (s *StockService) GetStockRevisions(ticket, eventTime string) ([]*model.StockRevision, error) {
+	if ticket == "" {
+		return nil, errors.NewValidationError("ticket is required", nil)
+	}
+	if eventTime == "" {
+		return nil, errors.NewValidationError("time is required", nil)
+	}
+	parsed, err := time.Parse(time.RFC3339, eventTime)
+	if err != nil {
+		return nil, errors.NewValidationError("time must be an RFC3339 timestamp", err)
+	}
+	if s.revisionRepo == nil {
+		return nil, errors.NewInternalError("stock revisions are not configured", nil)
+	}
+
+	revisions, err := s.revisionRepo.GetRevisions(ticket, parsed)
+	if err != nil {
+		s.logger.WithError(err).WithField("ticket", ticket).Error("Failed to get stock revisions from repository")
+		return nil, errors.NewDatabaseError("failed to retrieve stock revisions", err)
+	}
+	if revisions == nil {
+		revisions = []*model.StockRevision{}
+	}
+
+	return revisions, nil
+}
+
+func (s *StockService) SearchStocks(params interfaces.StockSearchParams) ([]*model.Stock, int, error) {
+	if params.Limit <= 0 {
+		params.Limit = 50
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
 	}
 
+	dateFrom, dateTo := s.parseDateRange(params.DateFrom, params.DateTo)
+
 	limitForDB := params.Limit
 	if params.Rating != "" {
 		limitForDB = params.Limit * 10
@@ -142,6 +221,24 @@ func (s *StockService) SearchStocks(params interfaces.StockSearchParams) ([]*mod
 	return sortedStocks, total, nil
 }
 
+func (s *StockService) parseDateRange(dateFromStr, dateToStr string) (*time.Time, *time.Time) {
+	var dateFrom, dateTo *time.Time
+	if dateFromStr != "" {
+		if parsed, err := time.Parse("2006-01-02", dateFromStr); err == nil {
+			dateFrom = &parsed
+		}
+	}
+
+	if dateToStr != "" {
+		if parsed, err := time.Parse("2006-01-02", dateToStr); err == nil {
+			endOfDay := parsed.Add(24*time.Hour - time.Nanosecond)
+			dateTo = &endOfDay
+		}
+	}
+
+	return dateFrom, dateTo
+}
+
 func (s *StockService) applyFilters(stocks []*model.Stock, params interfaces.StockSearchParams) []*model.Stock {
 	if params.Rating == "" {
 		return stocks
@@ -213,3 +310,17 @@ func (s *StockService) formatChangePercent(change float64) string {
 
 	return fmt.Sprintf("%s%.1f%%", sign, change)
 }
+
+func (s *StockService) attachLastClose(stock *model.Stock) {
+	if s.prices == nil {
+		return
+	}
+
+	closes, err := s.prices.GetLatestCloses([]string{stock.Ticker})
+	if err != nil {
+		s.logger.WithError(err).WithField("ticker", stock.Ticker).Warn("Failed to attach last close to stock")
+		return
+	}
+
+	stock.ApplyLastClose(closes[stock.Ticker])
+}
@@ -280,3 +280,193 @@ func TestStockService_SearchStocks(t *testing.T) {
 		})
 	}
 }
+
+func TestStockService_GetStockHistory(t *testing.T) {
+	tests := []struct {
+		name            string
+		params          serviceInterfaces.StockHistoryParams
+		expectedCount   int
+		expectedTotal   int
+		expectedChanges []string
+		expectedError   bool
+		setupMocks      func(*MockStockRepository)
+	}{
+		{
+			name: "successful history",
+			params: serviceInterfaces.StockHistoryParams{
+				Ticket:   "AAPL",
+				DateFrom: "2025-01-01",
+				DateTo:   "2025-12-31",
+				Limit:    10,
+			},
+			expectedCount:   2,
+			expectedTotal:   2,
+			expectedChanges: []string{"+33.3%", "-25.0%"},
+			expectedError:   false,
+			setupMocks: func(stockRepo *MockStockRepository) {
+				stockRepo.On("GetStockHistoryCount", "AAPL", mock.Anything).Return(2, nil)
+				stockRepo.On("GetStockHistory", "AAPL", mock.Anything).Return([]*model.Stock{
+					{
+						Ticker:     "AAPL",
+						Company:    "Apple Inc",
+						Action:     "target raised by",
+						RatingTo:   "Buy",
+						TargetFrom: "$150.00",
+						TargetTo:   "$200.00",
+						Time:       time.Now(),
+					},
+					{
+						Ticker:     "AAPL",
+						Company:    "Apple Inc",
+						Action:     "target lowered by",
+						RatingTo:   "Neutral",
+						TargetFrom: "$200.00",
+						TargetTo:   "$150.00",
+						Time:       time.Now().AddDate(0, 0, -30),
+					},
+				}, nil)
+			},
+		},
+		{
+			name:          "unknown ticker",
+			params:        serviceInterfaces.StockHistoryParams{Ticket: "INVALID", Limit: 10},
+			expectedError: true,
+			setupMocks: func(stockRepo *MockStockRepository) {
+				stockRepo.On("GetStockHistoryCount", "INVALID", mock.Anything).Return(0, nil)
+				stockRepo.On("ExistsByTicker", "INVALID").Return(false, nil)
+			},
+		},
+		{
+			name:          "no events in date range",
+			params:        serviceInterfaces.StockHistoryParams{Ticket: "AAPL", DateFrom: "2030-01-01", Limit: 10},
+			expectedCount: 0,
+			expectedTotal: 0,
+			expectedError: false,
+			setupMocks: func(stockRepo *MockStockRepository) {
+				stockRepo.On("GetStockHistoryCount", "AAPL", mock.Anything).Return(0, nil)
+				stockRepo.On("ExistsByTicker", "AAPL").Return(true, nil)
+			},
+		},
+		{
+			name:          "repository error",
+			params:        serviceInterfaces.StockHistoryParams{Ticket: "AAPL", Limit: 10},
+			expectedError: true,
+			setupMocks: func(stockRepo *MockStockRepository) {
+				stockRepo.On("GetStockHistoryCount", "AAPL", mock.Anything).Return(0, assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStockRepo := &MockStockRepository{}
+
+			tt.setupMocks(mockStockRepo)
+
+			service := &StockService{
+				stockRepo: mockStockRepo,
+				logger:    logrus.New(),
+			}
+
+			history, total, err := service.GetStockHistory(tt.params)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, history)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, history, tt.expectedCount)
+				assert.Equal(t, tt.expectedTotal, total)
+				for i, change := range tt.expectedChanges {
+					assert.Equal(t, change, history[i].ChangePercent)
+				}
+			}
+
+			mockStockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStockService_GetStockRevisions(t *testing.T) {
+	eventTime := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		ticket        string
+		eventTime     string
+		expectedCount int
+		expectedError bool
+		setupMocks    func(*MockStockRevisionRepository)
+	}{
+		{
+			name:          "successful revisions",
+			ticket:        "AAPL",
+			eventTime:     "2025-03-12T10:00:00Z",
+			expectedCount: 1,
+			setupMocks: func(revisionRepo *MockStockRevisionRepository) {
+				revisionRepo.On("GetRevisions", "AAPL", eventTime).Return([]*model.StockRevision{
+					{
+						Ticker:    "AAPL",
+						EventTime: eventTime,
+						Source:    model.DefaultStockSource,
+						Changes:   []model.StockFieldChange{{Field: "target_to", Before: "$200.00", After: "$210.00"}},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:      "no revisions",
+			ticket:    "AAPL",
+			eventTime: "2025-03-12T10:00:00Z",
+			setupMocks: func(revisionRepo *MockStockRevisionRepository) {
+				revisionRepo.On("GetRevisions", "AAPL", eventTime).Return([]*model.StockRevision(nil), nil)
+			},
+		},
+		{
+			name:          "missing time",
+			ticket:        "AAPL",
+			expectedError: true,
+			setupMocks:    func(revisionRepo *MockStockRevisionRepository) {},
+		},
+		{
+			name:          "invalid time",
+			ticket:        "AAPL",
+			eventTime:     "2025-03-12",
+			expectedError: true,
+			setupMocks:    func(revisionRepo *MockStockRevisionRepository) {},
+		},
+		{
+			name:          "repository error",
+			ticket:        "AAPL",
+			eventTime:     "2025-03-12T10:00:00Z",
+			expectedError: true,
+			setupMocks: func(revisionRepo *MockStockRevisionRepository) {
+				revisionRepo.On("GetRevisions", "AAPL", eventTime).Return([]*model.StockRevision(nil), assert.AnError)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRevisionRepo := &MockStockRevisionRepository{}
+
+			tt.setupMocks(mockRevisionRepo)
+
+			service := NewStockService(&MockStockRepository{}, logrus.New())
+			service.SetRevisionRepository(mockRevisionRepo)
+
+			revisions, err := service.GetStockRevisions(tt.ticket, tt.eventTime)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, revisions)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, revisions)
+				assert.Len(t, revisions, tt.expectedCount)
+			}
+
+			mockRevisionRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"time"
 
@@ -41,6 +42,16 @@ func (m *MockStockRepository) GetStockByTicket(ticket string) (*model.Stock, err
 	return args.Get(0).(*model.Stock), args.Error(1)
 }
 
+func (m *MockStockRepository) GetStockHistory(ticket string, filters repoInterfaces.StockHistoryFilters) ([]*model.Stock, error) {
+	args := m.Called(ticket, filters)
+	return args.Get(0).([]*model.Stock), args.Error(1)
+}
+
+func (m *MockStockRepository) GetStockHistoryCount(ticket string, filters repoInterfaces.StockHistoryFilters) (int, error) {
+	args := m.Called(ticket, filters)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockStockRepository) SearchStocks(filters repoInterfaces.StockSearchFilters) ([]*model.Stock, error) {
 	args := m.Called(filters)
 	return args.Get(0).([]*model.Stock), args.Error(1)
@@ -51,6 +62,20 @@ func (m *MockStockRepository) GetDB() *sql.DB {
 	return args.Get(0).(*sql.DB)
 }
 
+type MockStockRevisionRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockRevisionRepository) GetRevisions(ticker string, eventTime time.Time) ([]*model.StockRevision, error) {
+	args := m.Called(ticker, eventTime)
+	return args.Get(0).([]*model.StockRevision), args.Error(1)
+}
+
+func (m *MockStockRevisionRepository) GetDB() *sql.DB {
+	args := m.Called()
+	return args.Get(0).(*sql.DB)
+}
+
 type MockRecommendationRepository struct {
 	mock.Mock
 }
@@ -70,9 +95,14 @@ func (m *MockRecommendationRepository) GetLatestRunAt() (*time.Time, error) {
 	return args.Get(0).(*time.Time), args.Error(1)
 }
 
-func (m *MockRecommendationRepository) DeleteOldRecommendations(days int) error {
-	args := m.Called(days)
-	return args.Error(0)
+func (m *MockRecommendationRepository) GetRecommendationsByRun(runID string) ([]*model.Recommendation, error) {
+	args := m.Called(runID)
+	return args.Get(0).([]*model.Recommendation), args.Error(1)
+}
+
+func (m *MockRecommendationRepository) DeleteOldRecommendations(maxAge time.Duration) (int64, error) {
+	args := m.Called(maxAge)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 func (m *MockRecommendationRepository) GetRecommendationCount() (int, error) {
@@ -99,6 +129,11 @@ func (m *MockRecommendationCommand) BulkCreate(recommendations []*model.Recommen
 	return args.Error(0)
 }
 
+func (m *MockRecommendationCommand) PublishRun(run *model.RecommendationRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
 func (m *MockRecommendationCommand) DeleteAllRecommendations() error {
 	args := m.Called()
 	return args.Error(0)
@@ -108,3 +143,274 @@ func (m *MockRecommendationCommand) GetDB() *sql.DB {
 	args := m.Called()
 	return args.Get(0).(*sql.DB)
 }
+
+type MockRecommendationRunRepository struct {
+	mock.Mock
+}
+
+func (m *MockRecommendationRunRepository) CreateRun(run *model.RecommendationRun) error {
+	args := m.Called(run)
+	return args.Error(0)
+}
+
+func (m *MockRecommendationRunRepository) FinishRun(runID string, status model.RecommendationRunStatus, count int, errorMessage string) error {
+	args := m.Called(runID, status, count, errorMessage)
+	return args.Error(0)
+}
+
+func (m *MockRecommendationRunRepository) GetRuns(limit, offset int) ([]*model.RecommendationRun, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]*model.RecommendationRun), args.Error(1)
+}
+
+func (m *MockRecommendationRunRepository) GetRunsCount() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRecommendationRunRepository) GetRunByID(runID string) (*model.RecommendationRun, error) {
+	args := m.Called(runID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RecommendationRun), args.Error(1)
+}
+
+func (m *MockRecommendationRunRepository) GetLastCompletedRunTime() (*time.Time, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockRecommendationRunRepository) GetDB() *sql.DB {
+	args := m.Called()
+	return args.Get(0).(*sql.DB)
+}
+
+type MockReferenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockReferenceRepository) GetBrokerages() ([]*model.Brokerage, error) {
+	args := m.Called()
+	return args.Get(0).([]*model.Brokerage), args.Error(1)
+}
+
+func (m *MockReferenceRepository) GetRatings() ([]*model.Rating, error) {
+	args := m.Called()
+	return args.Get(0).([]*model.Rating), args.Error(1)
+}
+
+func (m *MockReferenceRepository) GetActions() ([]*model.Action, error) {
+	args := m.Called()
+	return args.Get(0).([]*model.Action), args.Error(1)
+}
+
+func (m *MockReferenceRepository) GetAliases() ([]*model.ReferenceAlias, error) {
+	args := m.Called()
+	return args.Get(0).([]*model.ReferenceAlias), args.Error(1)
+}
+
+func (m *MockReferenceRepository) FindBrokerage(key string) (*model.Brokerage, error) {
+	args := m.Called(key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Brokerage), args.Error(1)
+}
+
+func (m *MockReferenceRepository) CreateBrokerage(name string) (*model.Brokerage, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Brokerage), args.Error(1)
+}
+
+func (m *MockReferenceRepository) RecordUnmapped(kind model.ReferenceKind, rawValues map[string]int) error {
+	args := m.Called(kind, rawValues)
+	return args.Error(0)
+}
+
+func (m *MockReferenceRepository) GetUnmapped(kind model.ReferenceKind, limit, offset int) ([]*model.UnmappedReferenceValue, error) {
+	args := m.Called(kind, limit, offset)
+	return args.Get(0).([]*model.UnmappedReferenceValue), args.Error(1)
+}
+
+func (m *MockReferenceRepository) GetDB() *sql.DB {
+	args := m.Called()
+	return args.Get(0).(*sql.DB)
+}
+
+type MockScoringConfigRepository struct {
+	mock.Mock
+}
+
+func (m *MockScoringConfigRepository) ListConfigs(limit, offset int) ([]*model.ScoringConfigVersion, error) {
+	args := m.Called(limit, offset)
+	return args.Get(0).([]*model.ScoringConfigVersion), args.Error(1)
+}
+
+func (m *MockScoringConfigRepository) CountConfigs() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockScoringConfigRepository) GetByVersion(version string) (*model.ScoringConfigVersion, error) {
+	args := m.Called(version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ScoringConfigVersion), args.Error(1)
+}
+
+func (m *MockScoringConfigRepository) GetActive() (*model.ScoringConfigVersion, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ScoringConfigVersion), args.Error(1)
+}
+
+func (m *MockScoringConfigRepository) CreateConfig(config *model.ScoringConfigVersion) error {
+	args := m.Called(config)
+	return args.Error(0)
+}
+
+func (m *MockScoringConfigRepository) ActivateConfig(version string) (bool, error) {
+	args := m.Called(version)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockScoringConfigRepository) GetDB() *sql.DB {
+	args := m.Called()
+	return args.Get(0).(*sql.DB)
+}
+
+type MockPriceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPriceRepository) UpsertPrices(bars []*model.PriceBar) (int, error) {
+	args := m.Called(bars)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPriceRepository) GetLatestCloses(tickers []string) (map[string]*model.PriceBar, error) {
+	args := m.Called(tickers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*model.PriceBar), args.Error(1)
+}
+
+func (m *MockPriceRepository) GetLatestPriceDate(ticker string) (*time.Time, error) {
+	args := m.Called(ticker)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockPriceRepository) GetPrices(ticker string, from, to time.Time) ([]*model.PriceBar, error) {
+	args := m.Called(ticker, from, to)
+	return args.Get(0).([]*model.PriceBar), args.Error(1)
+}
+
+func (m *MockPriceRepository) GetTrackedTickers() ([]string, error) {
+	args := m.Called()
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockPriceRepository) GetDB() *sql.DB {
+	args := m.Called()
+	return args.Get(0).(*sql.DB)
+}
+
+type MockPriceProvider struct {
+	mock.Mock
+}
+
+func (m *MockPriceProvider) Name() string {
+	return "mock"
+}
+
+func (m *MockPriceProvider) GetDailyBars(ctx context.Context, ticker string, from, to time.Time) ([]*model.PriceBar, error) {
+	args := m.Called(ticker, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.PriceBar), args.Error(1)
+}
+
+type MockDataWorker struct {
+	mock.Mock
+}
+
+func (m *MockDataWorker) FetchAndProcessStocks(ctx context.Context, progress model.ProgressFunc) ([]*model.IngestionResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.IngestionResult), args.Error(1)
+}
+
+func (m *MockDataWorker) FetchAndProcessSource(ctx context.Context, source string, progress model.ProgressFunc) (*model.IngestionResult, error) {
+	args := m.Called(ctx, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.IngestionResult), args.Error(1)
+}
+
+func (m *MockDataWorker) GetSources() ([]*model.IngestionSource, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.IngestionSource), args.Error(1)
+}
+
+func (m *MockDataWorker) StoreStocks(ctx context.Context, source string, stocks []model.Stock) (*model.BulkUpsertResult, error) {
+	args := m.Called(ctx, source, stocks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BulkUpsertResult), args.Error(1)
+}
+
+func (m *MockDataWorker) PreviewStocks(ctx context.Context, source string, stocks []model.Stock) (*model.BulkUpsertResult, error) {
+	args := m.Called(ctx, source, stocks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.BulkUpsertResult), args.Error(1)
+}
+
+func (m *MockDataWorker) HealthCheck(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockDataWorker) GetSourcesHealth(ctx context.Context) []*model.SourceHealth {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]*model.SourceHealth)
+}
+
+func (m *MockDataWorker) GetLastRunTime(ctx context.Context, source string) (*time.Time, error) {
+	args := m.Called(ctx, source)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockDataWorker) ShouldRun(ctx context.Context, source string) (bool, error) {
+	args := m.Called(ctx, source)
+	return args.Bool(0), args.Error(1)
+}
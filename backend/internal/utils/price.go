@@ -7,18 +7,41 @@ import (
 )
 
 func ParsePrice(priceStr string) float64 {
-	if priceStr == "" {
+	price, ok := TryParsePrice(priceStr)
+	if !ok {
 		return 0.0
 	}
 
-	cleanPrice := strings.TrimSpace(strings.ReplaceAll(priceStr, "$", ""))
+	return price
+}
+
+// TryParsePrice parses upstream price strings such as "$1,200.00" and reports
+// whether the value was a valid number.
+func TryParsePrice(priceStr string) (float64, bool) {
+	cleanPrice := strings.TrimSpace(priceStr)
+	cleanPrice = strings.ReplaceAll(cleanPrice, "$", "")
+	cleanPrice = strings.ReplaceAll(cleanPrice, ",", "")
+	cleanPrice = strings.TrimSpace(cleanPrice)
+
+	if cleanPrice == "" {
+		return 0.0, false
+	}
 
 	price, err := strconv.ParseFloat(cleanPrice, 64)
-	if err != nil {
-		return 0.0
+	if err != nil || math.IsNaN(price) || math.IsInf(price, 0) {
+		return 0.0, false
 	}
 
-	return price
+	return price, true
+}
+
+// PriceOrNil is TryParsePrice for nullable numeric columns.
+func PriceOrNil(priceStr string) *float64 {
+	price, ok := TryParsePrice(priceStr)
+	if !ok {
+		return nil
+	}
+	return &price
 }
 
 func CalculateChangePercentage(fromPrice, toPrice float64) float64 {
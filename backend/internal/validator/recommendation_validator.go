@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/valeriapadilla/stock-insights/internal/model"
 )
@@ -97,9 +98,10 @@ func (v *RecommendationValidator) validateRank(rank int) error {
 }
 
 type RecommendationParams struct {
-	DaysBack   int `json:"days_back"`   // Default: 7 days
-	MaxResults int `json:"max_results"` // Default: 15
-	MinScore   int `json:"min_score"`   // Default: 50
+	DaysBack   int    `json:"days_back"`   // Default: 7 days
+	MaxResults int    `json:"max_results"` // Default: 15
+	MinScore   int    `json:"min_score"`   // Default: 50
+	Strategy   string `json:"strategy"`    // Default: additive
 }
 
 func (v *RecommendationValidator) ValidateRecommendationParams(params RecommendationParams) RecommendationParams {
@@ -107,6 +109,7 @@ func (v *RecommendationValidator) ValidateRecommendationParams(params Recommenda
 		DaysBack:   v.ValidateLimit(params.DaysBack, 7),
 		MaxResults: v.ValidateLimit(params.MaxResults, 0), // No default aquí, lo maneja el handler
 		MinScore:   v.ValidateLimit(params.MinScore, 0),   // No default aquí, lo maneja el handler
+		Strategy:   strings.ToLower(strings.TrimSpace(params.Strategy)),
 	}
 }
 
@@ -0,0 +1,83 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+var scoringConfigVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+type ScoringConfigValidator struct{}
+
+func NewScoringConfigValidator() *ScoringConfigValidator {
+	return &ScoringConfigValidator{}
+}
+
+func (v *ScoringConfigValidator) ValidateVersion(version string) error {
+	if !scoringConfigVersionPattern.MatchString(version) {
+		return fmt.Errorf("version must be 1-64 letters, digits, '.', '_' or '-', got %q", version)
+	}
+	return nil
+}
+
+// Validate checks that no weight is negative, that thresholds are strictly
+// descending, and that the best possible event cannot exceed a score of 100.
+func (v *ScoringConfigValidator) Validate(cfg *model.ScoringConfig) error {
+	if cfg == nil {
+		return fmt.Errorf("scoring config cannot be nil")
+	}
+
+	scores := map[string]int{
+		"target_raised_score":        cfg.TargetRaisedScore,
+		"upgraded_score":             cfg.UpgradedScore,
+		"initiated_score":            cfg.InitiatedScore,
+		"target_maintained_score":    cfg.TargetMaintainedScore,
+		"buy_score":                  cfg.BuyScore,
+		"overweight_score":           cfg.OverweightScore,
+		"sector_perform_score":       cfg.SectorPerformScore,
+		"equal_weight_score":         cfg.EqualWeightScore,
+		"neutral_score":              cfg.NeutralScore,
+		"high_target_change_score":   cfg.HighTargetChangeScore,
+		"medium_target_change_score": cfg.MediumTargetChangeScore,
+		"low_target_change_score":    cfg.LowTargetChangeScore,
+		"min_target_change_score":    cfg.MinTargetChangeScore,
+		"today_score":                cfg.TodayScore,
+		"yesterday_score":            cfg.YesterdayScore,
+		"three_days_score":           cfg.ThreeDaysScore,
+		"week_score":                 cfg.WeekScore,
+	}
+	for name, score := range scores {
+		if score < 0 {
+			return fmt.Errorf("%s: must be 0 or greater, got %d", name, score)
+		}
+	}
+
+	if cfg.MinTargetChangePercent <= 0 ||
+		cfg.LowTargetChangePercent <= cfg.MinTargetChangePercent ||
+		cfg.MediumTargetChangePercent <= cfg.LowTargetChangePercent ||
+		cfg.HighTargetChangePercent <= cfg.MediumTargetChangePercent {
+		return fmt.Errorf("target change thresholds must be positive and ordered min < low < medium < high")
+	}
+
+	maxScore := maxInt(cfg.TargetRaisedScore, cfg.UpgradedScore, cfg.InitiatedScore, cfg.TargetMaintainedScore) +
+		maxInt(cfg.BuyScore, cfg.OverweightScore, cfg.SectorPerformScore, cfg.EqualWeightScore, cfg.NeutralScore) +
+		maxInt(cfg.HighTargetChangeScore, cfg.MediumTargetChangeScore, cfg.LowTargetChangeScore, cfg.MinTargetChangeScore, 2) +
+		maxInt(cfg.TodayScore, cfg.YesterdayScore, cfg.ThreeDaysScore, cfg.WeekScore)
+	if maxScore > 100 {
+		return fmt.Errorf("maximum attainable score must be 100 or less, got %d", maxScore)
+	}
+
+	return nil
+}
+
+func maxInt(values ...int) int {
+	result := 0
+	for _, value := range values {
+		if value > result {
+			result = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+)
+
+func TestScoringConfigValidator_Validate(t *testing.T) {
+	validator := NewScoringConfigValidator()
+
+	tests := []struct {
+		name    string
+		modify  func(*model.ScoringConfig)
+		wantErr bool
+	}{
+		{
+			name:    "default config",
+			modify:  func(cfg *model.ScoringConfig) {},
+			wantErr: false,
+		},
+		{
+			name:    "negative score",
+			modify:  func(cfg *model.ScoringConfig) { cfg.NeutralScore = -1 },
+			wantErr: true,
+		},
+		{
+			name:    "unordered thresholds",
+			modify:  func(cfg *model.ScoringConfig) { cfg.LowTargetChangePercent = 30 },
+			wantErr: true,
+		},
+		{
+			name:    "maximum score above 100",
+			modify:  func(cfg *model.ScoringConfig) { cfg.UpgradedScore = 45 },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := model.DefaultScoringConfig()
+			tt.modify(cfg)
+
+			err := validator.Validate(cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	assert.Error(t, validator.Validate(nil))
+}
+
+func TestScoringConfigValidator_ValidateVersion(t *testing.T) {
+	validator := NewScoringConfigValidator()
+
+	assert.NoError(t, validator.ValidateVersion("2025-06.tuned_v2"))
+	assert.Error(t, validator.ValidateVersion(""))
+	assert.Error(t, validator.ValidateVersion("has spaces"))
+	assert.Error(t, validator.ValidateVersion("-leading-dash"))
+}
@@ -2,13 +2,19 @@ package implementations
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/valeriapadilla/stock-insights/internal/client"
 	"github.com/valeriapadilla/stock-insights/internal/errors"
 	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/quality"
 	repoInterfaces "github.com/valeriapadilla/stock-insights/internal/repository/interfaces"
+	serviceInterfaces "github.com/valeriapadilla/stock-insights/internal/service/interfaces"
 	workerInterfaces "github.com/valeriapadilla/stock-insights/internal/worker/interfaces"
 )
 
@@ -16,70 +22,721 @@ type DataWorkerConfig struct {
 	ScheduleInterval time.Duration
 	MaxRetries       int
 	RetryDelay       time.Duration
+	// PageBuffer bounds how many fetched pages may wait for the database
+	// before fetching pauses. Defaults to 2.
+	PageBuffer int
+	// Quality configures the data quality checks run on every ingestion.
+	Quality quality.Config
+	// RevisionLookback is how far below the high-water mark events are
+	// upserted again, so upstream corrections to recent events are stored
+	// and recorded as revisions. Zero only stores events past the mark.
+	RevisionLookback time.Duration
 }
 
+const defaultPageBuffer = 2
+
 type DataWorkerImpl struct {
-	externalClient *client.ExternalAPIClient
-	stockRepo      repoInterfaces.StockRepository
-	stockCommand   repoInterfaces.StockCommand
-	logger         *logrus.Logger
-	config         DataWorkerConfig
+	sources          []client.StockSource
+	stockRepo        repoInterfaces.StockRepository
+	stockCommand     repoInterfaces.StockCommand
+	checkpointRepo   repoInterfaces.IngestionCheckpointRepository
+	runRepo          repoInterfaces.IngestionRunRepository
+	rejectRepo       repoInterfaces.StockRejectRepository
+	qualityRepo      repoInterfaces.DataQualityRepository
+	referenceService serviceInterfaces.ReferenceServiceInterface
+	quality          *quality.Checker
+	logger           *logrus.Logger
+	config           DataWorkerConfig
+}
+
+// runScope collects what one ingestion run accumulates across its pages.
+type runScope struct {
+	// id is empty when the run could not be recorded.
+	id       string
+	findings []*model.DataQualityFinding
+
+	progress model.ProgressFunc
+	// startedAt and percent track the progress estimate of the run, see
+	// estimateProgress.
+	startedAt time.Time
+	percent   int
 }
 
+// retryConfigurable is implemented by sources whose retry policy can be set.
+type retryConfigurable interface {
+	SetRetryPolicy(policy client.RetryPolicy)
+}
+
+// NewDataWorker ingests from sources, given in precedence order, highest
+// first.
 func NewDataWorker(
-	externalClient *client.ExternalAPIClient,
+	sources []client.StockSource,
 	stockRepo repoInterfaces.StockRepository,
 	stockCommand repoInterfaces.StockCommand,
+	checkpointRepo repoInterfaces.IngestionCheckpointRepository,
+	runRepo repoInterfaces.IngestionRunRepository,
+	rejectRepo repoInterfaces.StockRejectRepository,
+	qualityRepo repoInterfaces.DataQualityRepository,
+	referenceService serviceInterfaces.ReferenceServiceInterface,
 	logger *logrus.Logger,
 	config DataWorkerConfig,
 ) workerInterfaces.DataWorker {
+	if config.MaxRetries > 0 {
+		for _, source := range sources {
+			if configurable, ok := source.(retryConfigurable); ok {
+				configurable.SetRetryPolicy(client.RetryPolicy{
+					MaxRetries: config.MaxRetries,
+					BaseDelay:  config.RetryDelay,
+				})
+			}
+		}
+	}
+
 	return &DataWorkerImpl{
-		externalClient: externalClient,
-		stockRepo:      stockRepo,
-		stockCommand:   stockCommand,
-		logger:         logger,
-		config:         config,
+		sources:          sources,
+		stockRepo:        stockRepo,
+		stockCommand:     stockCommand,
+		checkpointRepo:   checkpointRepo,
+		runRepo:          runRepo,
+		rejectRepo:       rejectRepo,
+		qualityRepo:      qualityRepo,
+		referenceService: referenceService,
+		quality:          quality.NewChecker(config.Quality, referenceService),
+		logger:           logger,
+		config:           config,
+	}
+}
+
+// FetchAndProcessStocks ingests every source in precedence order. A failing
+// source does not stop the others; the returned error names the sources that
+// failed. Sources already being ingested elsewhere are skipped, and only
+// fail the call when every source was skipped. Each source gets an equal
+// share of progress.
+func (w *DataWorkerImpl) FetchAndProcessStocks(ctx context.Context, progress model.ProgressFunc) ([]*model.IngestionResult, error) {
+	var results []*model.IngestionResult
+	var failed, skipped []string
+	var firstErr, conflictErr error
+
+	for i, source := range w.sources {
+		if ctx.Err() != nil {
+			break
+		}
+
+		share := progress.Scale(i*100/len(w.sources), (i+1)*100/len(w.sources))
+		result, err := w.ingestSource(ctx, source, share)
+		if result != nil {
+			results = append(results, result)
+		}
+		if errors.IsConflictError(err) {
+			skipped = append(skipped, source.Name())
+			conflictErr = err
+			continue
+		}
+		if err != nil {
+			failed = append(failed, source.Name())
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if ctx.Err() != nil && firstErr == nil {
+		return results, errors.NewInternalError("ingestion cancelled", ctx.Err())
+	}
+	if len(skipped) == len(w.sources) {
+		return results, conflictErr
+	}
+	if len(failed) == 1 {
+		return results, firstErr
+	}
+	if len(failed) > 1 {
+		return results, errors.NewExternalError(fmt.Sprintf("ingestion failed for sources: %s", strings.Join(failed, ", ")), firstErr)
+	}
+
+	return results, nil
+}
+
+// FetchAndProcessSource ingests the named source only.
+func (w *DataWorkerImpl) FetchAndProcessSource(ctx context.Context, name string, progress model.ProgressFunc) (*model.IngestionResult, error) {
+	source := w.findSource(name)
+	if source == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("unknown ingestion source %q", name), nil)
+	}
+	return w.ingestSource(ctx, source, progress)
+}
+
+// GetSources lists the sources in precedence order with their checkpoints
+// and circuit breaker states.
+func (w *DataWorkerImpl) GetSources() ([]*model.IngestionSource, error) {
+	sources := make([]*model.IngestionSource, 0, len(w.sources))
+	for i, source := range w.sources {
+		checkpoint, err := w.checkpointRepo.GetCheckpoint(source.Name())
+		if err != nil {
+			return nil, errors.NewDatabaseError("failed to load ingestion checkpoint", err)
+		}
+		ingestionSource := &model.IngestionSource{
+			Name:       source.Name(),
+			Precedence: i + 1,
+			Checkpoint: checkpoint,
+		}
+		if breaking, ok := source.(client.CircuitBreaking); ok {
+			status := breaking.CircuitStatus()
+			ingestionSource.Circuit = &status
+		}
+		sources = append(sources, ingestionSource)
 	}
+	return sources, nil
 }
 
-func (w *DataWorkerImpl) FetchAndProcessStocks(ctx context.Context) error {
-	return w.FetchAndProcessStocksEfficient(ctx)
+func (w *DataWorkerImpl) findSource(name string) client.StockSource {
+	for _, source := range w.sources {
+		if source.Name() == name {
+			return source
+		}
+	}
+	return nil
+}
+
+// ingestSource runs ingestFromSource, applies the data quality checks and
+// records the run in the ingestion run history. Failing to record history is
+// logged but does not fail ingestion.
+// Only one ingestion of a source runs at a time across replicas and the cron
+// worker; the others return a conflict error without recording a run or
+// touching the checkpoint.
+func (w *DataWorkerImpl) ingestSource(ctx context.Context, source client.StockSource, progress model.ProgressFunc) (*model.IngestionResult, error) {
+	sourceName := source.Name()
+	unlock, locked, err := w.checkpointRepo.LockSource(ctx, sourceName)
+	if err != nil {
+		w.logger.WithError(err).WithField("source", sourceName).Error("Failed to take ingestion lock")
+		return nil, errors.NewDatabaseError("failed to take ingestion lock", err)
+	}
+	if !locked {
+		w.logger.WithField("source", sourceName).Warn("Skipping ingestion, another ingestion of the source is running")
+		return nil, errors.NewConflictError(fmt.Sprintf("ingestion of %s is already running", sourceName), nil)
+	}
+	defer unlock()
+
+	run := &model.IngestionRun{
+		ID:              uuid.New().String(),
+		Status:          model.IngestionStatusRunning,
+		StartedAt:       time.Now(),
+		IngestionResult: model.IngestionResult{Source: source.Name()},
+	}
+	scope := &runScope{id: run.ID, progress: progress, startedAt: run.StartedAt}
+	if err := w.runRepo.CreateRun(run); err != nil {
+		w.logger.WithError(err).WithField("source", source.Name()).Warn("Failed to record ingestion run")
+		run, scope.id = nil, ""
+	}
+
+	result, err := w.ingestFromSource(ctx, source, scope)
+	if result != nil {
+		err = w.applyQuality(scope, result, err)
+	}
+
+	if run != nil {
+		if result != nil {
+			run.IngestionResult = *result
+		}
+		run.Status = model.IngestionStatusCompleted
+		if err != nil {
+			run.Status = model.IngestionStatusFailed
+			run.ErrorMessage = err.Error()
+		}
+		if finishErr := w.runRepo.FinishRun(run); finishErr != nil {
+			w.logger.WithError(finishErr).WithField("run_id", run.ID).Warn("Failed to finish ingestion run")
+		}
+	}
+
+	return result, err
 }
 
-func (w *DataWorkerImpl) FetchAndProcessStocksEfficient(ctx context.Context) error {
-	w.logger.Info("Starting stock data fetch and processing (UPSERT strategy)")
+// ingestFromSource streams pages from source while upserting and
+// checkpointing the pages already received. Rejected events are quarantined
+// under the run and stored events are checked for data quality. Fetching runs
+// ahead by at most PageBuffer pages.
+// A run left unfinished is resumed from its last committed page. Sources list
+// newest events first, so paging stops at the first page holding events
+// already stored by a completed run. The caller holds the lock of the source.
+func (w *DataWorkerImpl) ingestFromSource(ctx context.Context, source client.StockSource, scope *runScope) (*model.IngestionResult, error) {
+	sourceName := source.Name()
+	if breaking, ok := source.(client.CircuitBreaking); ok {
+		if err := breaking.CheckCircuit(); err != nil {
+			w.logger.WithError(err).WithField("source", sourceName).Warn("Skipping ingestion, circuit breaker is open")
+			return nil, err
+		}
+	}
+
+	w.logger.WithField("source", sourceName).Info("Starting stock data fetch and processing (UPSERT strategy)")
+
+	checkpoint, err := w.checkpointRepo.GetCheckpoint(sourceName)
+	if err != nil {
+		w.logger.WithError(err).Error("Failed to load ingestion checkpoint")
+		return nil, errors.NewDatabaseError("failed to load ingestion checkpoint", err)
+	}
+
+	result := &model.IngestionResult{Source: sourceName}
+	checkpoint = w.startCheckpoint(sourceName, checkpoint, result)
+	scope.progress.Report(0, fmt.Sprintf("Fetching stocks from %s", sourceName))
+	if err := w.checkpointRepo.SaveCheckpoint(checkpoint); err != nil {
+		w.logger.WithError(err).Error("Failed to save ingestion checkpoint")
+		return nil, errors.NewDatabaseError("failed to save ingestion checkpoint", err)
+	}
+
+	fetchCtx, stopFetching := context.WithCancel(ctx)
+	defer stopFetching()
+
+	pages := make(chan *client.StockPage, w.pageBuffer())
+	fetchErr := make(chan error, 1)
+	go func() {
+		defer close(pages)
+		fetchErr <- source.StreamStocksFrom(fetchCtx, checkpoint.NextPage, checkpoint.PagesCommitted+1,
+			func(page *client.StockPage) error {
+				select {
+				case pages <- page:
+					return nil
+				case <-fetchCtx.Done():
+					return fetchCtx.Err()
+				}
+			})
+	}()
+
+	// stopStream ends the producer and waits for it so no goroutine outlives
+	// the run.
+	stopStream := func() error {
+		stopFetching()
+		for range pages {
+		}
+		return <-fetchErr
+	}
+
+	for page := range pages {
+		if ctx.Err() != nil {
+			break
+		}
 
-	allStocks, err := w.externalClient.GetAllStocks(ctx)
+		done, err := w.commitPage(ctx, scope, page, checkpoint, result)
+		if err != nil {
+			stopStream()
+			w.failCheckpoint(checkpoint)
+			return result, err
+		}
+		if done {
+			stopStream()
+			return w.finishIngestion(checkpoint, result)
+		}
+	}
+
+	err = stopStream()
+	if ctx.Err() != nil {
+		w.logger.WithField("pages_committed", checkpoint.PagesCommitted).Warn("Ingestion cancelled, progress kept in checkpoint")
+		w.failCheckpoint(checkpoint)
+		return result, errors.NewInternalError("ingestion cancelled", ctx.Err())
+	}
 	if err != nil {
-		w.logger.WithError(err).Error("Failed to fetch stocks from external API")
-		return errors.NewExternalError("failed to fetch stocks from external API", err)
+		w.logger.WithError(err).WithFields(logrus.Fields{
+			"source":    sourceName,
+			"next_page": checkpoint.NextPage,
+		}).Error("Failed to fetch stocks from source")
+		w.failCheckpoint(checkpoint)
+		return result, errors.NewExternalError(fmt.Sprintf("failed to fetch stocks from source %s", sourceName), err)
+	}
+
+	return w.finishIngestion(checkpoint, result)
+}
+
+// commitPage upserts the unseen events of page and advances the checkpoint
+// past it. It reports done once the page reached the high-water mark or was
+// the last one.
+func (w *DataWorkerImpl) commitPage(ctx context.Context, scope *runScope, page *client.StockPage, checkpoint *model.IngestionCheckpoint, result *model.IngestionResult) (bool, error) {
+	result.PagesFetched++
+	result.StocksFetched += len(page.Items)
+
+	unseen := unseenStocks(page.Items, checkpoint, w.config.RevisionLookback)
+	for i := range unseen {
+		unseen[i].Source = checkpoint.Source
+	}
+	pageStart := scope.percent
+	scope.percent = estimateProgress(scope, checkpoint, page, len(unseen) < len(page.Items))
+
+	if len(unseen) == 0 {
+		result.PagesSkipped++
+	} else {
+		var findings []*model.DataQualityFinding
+		var blocked []model.StockRejection
+		stocks := unseen
+		if w.quality.Blocking() {
+			findings = w.checkQuality(checkpoint.Source, unseen, nil)
+			stocks, blocked = blockFailing(unseen, findings)
+		}
+
+		batchProgress := scope.progress.Scale(pageStart, scope.percent)
+		upsert, err := w.saveStocksInBatchesOptimized(ctx, stocks, false, batchProgress)
+		if err != nil && ctx.Err() != nil {
+			return false, errors.NewInternalError("ingestion cancelled", ctx.Err())
+		}
+		if err != nil {
+			w.logger.WithError(err).Error("Failed to save stocks to database")
+			return false, errors.NewDatabaseError("failed to save stocks to database", err)
+		}
+		if w.quality.Blocking() {
+			findings = withoutRejected(findings, upsert.Rejections)
+		} else {
+			findings = w.checkQuality(checkpoint.Source, stocks, upsert.Rejections)
+		}
+		upsert.Rejected += len(blocked)
+		upsert.Rejections = append(blocked, upsert.Rejections...)
+		if err := w.quarantine(scope.id, checkpoint.Source, upsert.Rejections); err != nil {
+			w.logger.WithError(err).Error("Failed to quarantine rejected stocks")
+			return false, errors.NewDatabaseError("failed to quarantine rejected stocks", err)
+		}
+		result.AddUpsert(upsert)
+		scope.findings = append(scope.findings, findings...)
 	}
 
+	checkpoint.NextPage = page.NextPage
+	checkpoint.PagesCommitted++
+	checkpoint.RunHighWaterMark = latestEventTime(checkpoint.RunHighWaterMark, unseen)
+
 	w.logger.WithFields(logrus.Fields{
-		"total_stocks": len(allStocks),
-	}).Info("Fetched all stocks from external API")
+		"source":        checkpoint.Source,
+		"page":          page.Number,
+		"items_in_page": len(page.Items),
+		"unseen_items":  len(unseen),
+		"has_next_page": page.NextPage != "",
+		"progress":      scope.percent,
+	}).Info("Committed page from external API")
+	scope.progress.Report(scope.percent, fmt.Sprintf("Source %s: committed page %d, %d stocks saved",
+		checkpoint.Source, page.Number, result.StocksSaved))
+
+	if len(unseen) < len(page.Items) {
+		result.ReachedHighWaterMark = true
+		return true, nil
+	}
+	if page.NextPage == "" {
+		return true, nil
+	}
+
+	if err := w.checkpointRepo.SaveCheckpoint(checkpoint); err != nil {
+		w.logger.WithError(err).Error("Failed to save ingestion checkpoint")
+		return false, errors.NewDatabaseError("failed to save ingestion checkpoint", err)
+	}
+	return false, nil
+}
+
+// quarantine stores rejected events in stock_rejects so they can be fixed and
+// replayed or discarded.
+func (w *DataWorkerImpl) quarantine(runID, source string, rejections []model.StockRejection) error {
+	if len(rejections) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	rejects := make([]*model.StockReject, 0, len(rejections))
+	for _, rejection := range rejections {
+		reject := &model.StockReject{
+			ID:           uuid.New().String(),
+			RunID:        runID,
+			Source:       source,
+			Ticker:       rejection.Ticker,
+			Payload:      rejection.Payload,
+			ErrorMessage: rejection.Reason,
+			Status:       model.StockRejectStatusPending,
+			CreatedAt:    now,
+		}
+		if !rejection.Time.IsZero() {
+			eventTime := rejection.Time
+			reject.EventTime = &eventTime
+		}
+		rejects = append(rejects, reject)
+	}
 
-	if len(allStocks) == 0 {
-		w.logger.Info("No stocks found from external API")
+	return w.rejectRepo.CreateRejects(rejects)
+}
+
+// checkQuality runs the data quality rules over the events of a page,
+// leaving out the rejected ones.
+func (w *DataWorkerImpl) checkQuality(source string, stocks []model.Stock, rejections []model.StockRejection) []*model.DataQualityFinding {
+	if !w.quality.Enabled() {
 		return nil
 	}
 
-	if err := w.saveStocksInBatchesOptimized(ctx, allStocks); err != nil {
-		w.logger.WithError(err).Error("Failed to save stocks to database")
-		return errors.NewDatabaseError("failed to save stocks to database", err)
+	rejected := make(map[string]bool, len(rejections))
+	for _, rejection := range rejections {
+		rejected[eventKey(rejection.Ticker, rejection.Time)] = true
+	}
+
+	stored := make([]*model.Stock, 0, len(stocks))
+	for i := range stocks {
+		if !rejected[eventKey(stocks[i].Ticker, stocks[i].Time)] {
+			stored = append(stored, &stocks[i])
+		}
+	}
+	return w.quality.Check(source, stored)
+}
+
+// blockFailing splits stocks into the ones to store and rejections for the
+// ones with error findings, which the fail policy keeps out of storage. The
+// rejections are quarantined like invalid events, so they can be fixed and
+// replayed.
+func blockFailing(stocks []model.Stock, findings []*model.DataQualityFinding) ([]model.Stock, []model.StockRejection) {
+	reasons := make(map[string][]string)
+	for _, finding := range findings {
+		if finding.Severity == model.DataQualitySeverityError {
+			key := eventKey(finding.Ticker, finding.EventTime)
+			reasons[key] = append(reasons[key], finding.Message)
+		}
+	}
+	if len(reasons) == 0 {
+		return stocks, nil
+	}
+
+	kept := make([]model.Stock, 0, len(stocks))
+	var blocked []model.StockRejection
+	for i := range stocks {
+		messages, failed := reasons[eventKey(stocks[i].Ticker, stocks[i].Time)]
+		if !failed {
+			kept = append(kept, stocks[i])
+			continue
+		}
+		payload, _ := json.Marshal(model.NewStockEvent(&stocks[i]))
+		blocked = append(blocked, model.StockRejection{
+			Ticker:  stocks[i].Ticker,
+			Time:    stocks[i].Time,
+			Reason:  "data quality: " + strings.Join(messages, "; "),
+			Payload: payload,
+		})
+	}
+	return kept, blocked
+}
+
+// withoutRejected drops the findings of events the upsert rejected.
+func withoutRejected(findings []*model.DataQualityFinding, rejections []model.StockRejection) []*model.DataQualityFinding {
+	if len(rejections) == 0 {
+		return findings
+	}
+
+	rejected := make(map[string]bool, len(rejections))
+	for _, rejection := range rejections {
+		rejected[eventKey(rejection.Ticker, rejection.Time)] = true
+	}
+
+	kept := findings[:0]
+	for _, finding := range findings {
+		if !rejected[eventKey(finding.Ticker, finding.EventTime)] {
+			kept = append(kept, finding)
+		}
+	}
+	return kept
+}
+
+func eventKey(ticker string, eventTime time.Time) string {
+	return ticker + "@" + eventTime.String()
+}
+
+// applyQuality sets the quality verdict of a run on result and stores its
+// findings. Under the fail policy, error findings turn a successful run into
+// a failed one; commitPage has already quarantined their events instead of
+// storing them.
+func (w *DataWorkerImpl) applyQuality(scope *runScope, result *model.IngestionResult, err error) error {
+	if !w.quality.Enabled() {
+		return err
+	}
+
+	qualityErrors, qualityWarnings := quality.CountSeverities(scope.findings)
+	result.QualityErrors = qualityErrors
+	result.QualityWarnings = qualityWarnings
+	result.QualityStatus = w.quality.Status(qualityErrors)
+
+	if scope.id != "" && w.qualityRepo != nil {
+		for _, finding := range scope.findings {
+			finding.ID = uuid.New().String()
+			finding.RunID = scope.id
+		}
+		if storeErr := w.qualityRepo.CreateFindings(scope.findings); storeErr != nil {
+			w.logger.WithError(storeErr).WithField("run_id", scope.id).Warn("Failed to store data quality findings")
+		}
+	}
+
+	fields := logrus.Fields{
+		"source":   result.Source,
+		"status":   result.QualityStatus,
+		"errors":   qualityErrors,
+		"warnings": qualityWarnings,
+	}
+	if result.QualityStatus == model.DataQualityStatusPassed {
+		w.logger.WithFields(fields).Info("Data quality checks passed")
+		return err
+	}
+	w.logger.WithFields(fields).Warn("Data quality checks found errors")
+
+	if result.QualityStatus == model.DataQualityStatusFailed && err == nil {
+		return errors.NewValidationError(fmt.Sprintf("data quality checks failed for source %s: %d error findings", result.Source, qualityErrors), nil)
+	}
+	return err
+}
+
+// StoreStocks saves stocks as events of source outside of any ingestion run,
+// with the same reference mapping and source precedence. Rejected events are
+// reported in the result but not quarantined.
+func (w *DataWorkerImpl) StoreStocks(ctx context.Context, source string, stocks []model.Stock) (*model.BulkUpsertResult, error) {
+	for i := range stocks {
+		stocks[i].Source = source
+	}
+
+	result, err := w.saveStocksInBatchesOptimized(ctx, stocks, false, nil)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to save stocks to database", err)
+	}
+	return result, nil
+}
+
+// PreviewStocks reports what StoreStocks would do with stocks, through the
+// same validation and upsert, without storing anything.
+func (w *DataWorkerImpl) PreviewStocks(ctx context.Context, source string, stocks []model.Stock) (*model.BulkUpsertResult, error) {
+	for i := range stocks {
+		stocks[i].Source = source
+	}
+
+	result, err := w.saveStocksInBatchesOptimized(ctx, stocks, true, nil)
+	if err != nil {
+		return nil, errors.NewDatabaseError("failed to preview stocks", err)
 	}
+	return result, nil
+}
+
+func (w *DataWorkerImpl) finishIngestion(checkpoint *model.IngestionCheckpoint, result *model.IngestionResult) (*model.IngestionResult, error) {
+	if err := w.completeCheckpoint(checkpoint); err != nil {
+		w.logger.WithError(err).Error("Failed to complete ingestion checkpoint")
+		return result, errors.NewDatabaseError("failed to complete ingestion checkpoint", err)
+	}
+	result.HighWaterMark = checkpoint.HighWaterMark
 
 	w.logger.WithFields(logrus.Fields{
-		"stocks_processed": len(allStocks),
-		"stocks_saved":     len(allStocks),
-	}).Info("Successfully processed and saved all stocks using UPSERT")
+		"source":                  result.Source,
+		"pages_fetched":           result.PagesFetched,
+		"pages_skipped":           result.PagesSkipped,
+		"pages_resumed":           result.PagesResumed,
+		"stocks_fetched":          result.StocksFetched,
+		"stocks_inserted":         result.StocksInserted,
+		"stocks_updated":          result.StocksUpdated,
+		"stocks_unchanged":        result.StocksUnchanged,
+		"stocks_rejected":         result.StocksRejected,
+		"reached_high_water_mark": result.ReachedHighWaterMark,
+	}).Info("Successfully processed and saved stocks using UPSERT")
+
+	return result, nil
+}
 
-	return nil
+func (w *DataWorkerImpl) pageBuffer() int {
+	if w.config.PageBuffer > 0 {
+		return w.config.PageBuffer
+	}
+	return defaultPageBuffer
 }
 
 func (w *DataWorkerImpl) processAllStocks(ctx context.Context) error {
 	w.logger.Info("Redirecting to efficient processing method")
-	return w.FetchAndProcessStocksEfficient(ctx)
+	_, err := w.FetchAndProcessStocks(ctx, nil)
+	return err
+}
+
+// startCheckpoint continues an unfinished run from its cursor, or starts a new
+// run from the first page.
+func (w *DataWorkerImpl) startCheckpoint(source string, checkpoint *model.IngestionCheckpoint, result *model.IngestionResult) *model.IngestionCheckpoint {
+	if checkpoint == nil {
+		checkpoint = &model.IngestionCheckpoint{Source: source}
+	}
+
+	if checkpoint.CanResume() {
+		result.Resumed = true
+		result.PagesResumed = checkpoint.PagesCommitted
+
+		w.logger.WithFields(logrus.Fields{
+			"source":          source,
+			"next_page":       checkpoint.NextPage,
+			"pages_committed": checkpoint.PagesCommitted,
+			"previous_status": checkpoint.Status,
+		}).Info("Resuming unfinished ingestion from checkpoint")
+	} else {
+		checkpoint.NextPage = ""
+		checkpoint.RunHighWaterMark = nil
+		checkpoint.PagesCommitted = 0
+		checkpoint.StartedAt = time.Now()
+	}
+
+	checkpoint.Status = model.IngestionStatusRunning
+	return checkpoint
+}
+
+func (w *DataWorkerImpl) failCheckpoint(checkpoint *model.IngestionCheckpoint) {
+	checkpoint.Status = model.IngestionStatusFailed
+	if err := w.checkpointRepo.SaveCheckpoint(checkpoint); err != nil {
+		w.logger.WithError(err).Warn("Failed to record failed ingestion checkpoint")
+	}
+}
+
+// completeCheckpoint promotes the run's newest event time to the high-water
+// mark and clears the cursor so the next run starts from the first page.
+func (w *DataWorkerImpl) completeCheckpoint(checkpoint *model.IngestionCheckpoint) error {
+	if checkpoint.RunHighWaterMark != nil &&
+		(checkpoint.HighWaterMark == nil || checkpoint.RunHighWaterMark.After(*checkpoint.HighWaterMark)) {
+		checkpoint.HighWaterMark = checkpoint.RunHighWaterMark
+	}
+	checkpoint.RunHighWaterMark = nil
+	checkpoint.NextPage = ""
+	checkpoint.Status = model.IngestionStatusCompleted
+
+	return w.checkpointRepo.SaveCheckpoint(checkpoint)
+}
+
+func unseenStocks(stocks []model.Stock, checkpoint *model.IngestionCheckpoint, lookback time.Duration) []model.Stock {
+	var unseen []model.Stock
+	for _, stock := range stocks {
+		if !checkpoint.IsSeen(stock.Time, lookback) {
+			unseen = append(unseen, stock)
+		}
+	}
+	return unseen
+}
+
+func latestEventTime(latest *time.Time, stocks []model.Stock) *time.Time {
+	for i := range stocks {
+		if latest == nil || stocks[i].Time.After(*latest) {
+			eventTime := stocks[i].Time
+			latest = &eventTime
+		}
+	}
+	return latest
+}
+
+// estimateProgress returns how far, from 0 to 100, a run through a source
+// got once page is committed; done means paging stops after it. Sources list
+// newest events first and a run stops at the high-water mark of the last
+// completed run, so the age of the oldest event on the page against the age
+// of the mark tells how much is left. Without a mark, as on a first run, the
+// estimate stays where it was. It never goes back.
+func estimateProgress(scope *runScope, checkpoint *model.IngestionCheckpoint, page *client.StockPage, done bool) int {
+	if done || page.NextPage == "" {
+		return 100
+	}
+	if checkpoint.HighWaterMark == nil || len(page.Items) == 0 {
+		return scope.percent
+	}
+
+	total := scope.startedAt.Sub(*checkpoint.HighWaterMark)
+	if total <= 0 {
+		return scope.percent
+	}
+
+	oldest := page.Items[0].Time
+	for _, item := range page.Items[1:] {
+		if item.Time.Before(oldest) {
+			oldest = item.Time
+		}
+	}
+
+	percent := int(float64(scope.startedAt.Sub(oldest)) / float64(total) * 100)
+	return min(max(percent, scope.percent), 99)
 }
 
 func (w *DataWorkerImpl) filterStocksByDate(allStocks []model.Stock, since time.Time) []model.Stock {
@@ -100,10 +757,14 @@ func (w *DataWorkerImpl) filterStocksByDate(allStocks []model.Stock, since time.
 	return filteredStocks
 }
 
-func (w *DataWorkerImpl) saveStocksInBatchesOptimized(ctx context.Context, stocks []model.Stock) error {
+// saveStocksInBatchesOptimized upserts stocks in batches and adds up what
+// each batch did, reporting progress after every batch. A dry run rolls every
+// batch back.
+func (w *DataWorkerImpl) saveStocksInBatchesOptimized(ctx context.Context, stocks []model.Stock, dryRun bool, progress model.ProgressFunc) (*model.BulkUpsertResult, error) {
+	total := &model.BulkUpsertResult{}
 	if len(stocks) == 0 {
 		w.logger.WithContext(ctx).Warn("No stocks to save")
-		return nil
+		return total, nil
 	}
 
 	const batchSize = 500
@@ -116,6 +777,13 @@ func (w *DataWorkerImpl) saveStocksInBatchesOptimized(ctx context.Context, stock
 	}).Info("Starting batch processing of stocks")
 
 	for i := 0; i < totalStocks; i += batchSize {
+		// Batches already written stay; upserts are idempotent so a rerun
+		// redoes the rest
+		if err := ctx.Err(); err != nil {
+			w.logger.WithField("batch_start", i).Warn("Stopped saving stocks, context cancelled")
+			return total, err
+		}
+
 		end := i + batchSize
 		if end > totalStocks {
 			end = totalStocks
@@ -129,46 +797,128 @@ func (w *DataWorkerImpl) saveStocksInBatchesOptimized(ctx context.Context, stock
 				stockPtrs[j].CreatedAt = now
 			}
 			stockPtrs[j].UpdatedAt = now
+			stockPtrs[j].PopulateTargetPrices()
 		}
 
-		if err := w.stockCommand.BulkUpsert(stockPtrs); err != nil {
+		if w.referenceService != nil {
+			if err := w.referenceService.MapStocks(stockPtrs); err != nil {
+				w.logger.WithError(err).WithFields(logrus.Fields{
+					"batch_start": i,
+					"batch_end":   end,
+				}).Warn("Failed to map reference data for batch, saving without canonical ids")
+			}
+		}
+
+		bulkUpsert := w.stockCommand.BulkUpsert
+		if dryRun {
+			bulkUpsert = w.stockCommand.PreviewBulkUpsert
+		}
+
+		upsert, err := bulkUpsert(stockPtrs)
+		if err != nil {
 			w.logger.WithError(err).WithFields(logrus.Fields{
 				"batch_start": i,
 				"batch_end":   end,
 			}).Error("Failed to save batch of stocks")
-			return err
+			return total, err
+		}
+		total.Add(upsert)
+
+		for _, rejection := range upsert.Rejections {
+			w.logger.WithFields(logrus.Fields{
+				"ticker": rejection.Ticker,
+				"time":   rejection.Time,
+				"reason": rejection.Reason,
+			}).Warn("Rejected stock event")
 		}
 
-		progress := (end * 100) / totalStocks
+		percent := (end * 100) / totalStocks
 		w.logger.WithFields(logrus.Fields{
 			"batch_start": i,
 			"batch_end":   end,
-			"progress":    progress,
+			"progress":    percent,
+			"inserted":    upsert.Inserted,
+			"updated":     upsert.Updated,
+			"unchanged":   upsert.Unchanged,
+			"rejected":    upsert.Rejected,
+			"dry_run":     dryRun,
 		}).Info("Saved batch of stocks")
+		progress.Report(percent, fmt.Sprintf("Saved %d of %d stocks", end, totalStocks))
 	}
 
 	w.logger.WithField("stocks_count", totalStocks).Info("Successfully saved all stocks to database")
-	return nil
+	return total, nil
 }
 
 func (w *DataWorkerImpl) HealthCheck(ctx context.Context) error {
-	w.logger.Debug("Performing external API health check")
+	w.logger.Debug("Performing stock sources health check")
 
-	if err := w.externalClient.HealthCheck(ctx); err != nil {
-		w.logger.WithError(err).Error("External API health check failed")
-		return errors.NewExternalError("external API health check failed", err)
+	for _, source := range w.sources {
+		if err := source.HealthCheck(ctx); err != nil {
+			w.logger.WithError(err).WithField("source", source.Name()).Error("Stock source health check failed")
+			return errors.NewExternalError(fmt.Sprintf("stock source %s health check failed", source.Name()), err)
+		}
 	}
 
-	w.logger.Info("External API health check passed")
+	w.logger.Info("Stock sources health check passed")
 	return nil
 }
 
-func (w *DataWorkerImpl) GetLastRunTime(ctx context.Context) (*time.Time, error) {
-	return w.stockRepo.GetLastUpdateTime()
+// GetSourcesHealth checks every source, unlike HealthCheck which stops at
+// the first failure. Sources with an open circuit breaker are reported
+// unhealthy without being called.
+func (w *DataWorkerImpl) GetSourcesHealth(ctx context.Context) []*model.SourceHealth {
+	health := make([]*model.SourceHealth, 0, len(w.sources))
+	for _, source := range w.sources {
+		sourceHealth := &model.SourceHealth{Name: source.Name(), Healthy: true}
+		if err := source.HealthCheck(ctx); err != nil {
+			sourceHealth.Healthy = false
+			sourceHealth.Error = err.Error()
+		}
+		if breaking, ok := source.(client.CircuitBreaking); ok {
+			status := breaking.CircuitStatus()
+			sourceHealth.Circuit = &status
+		}
+		sourceHealth.CheckedAt = time.Now()
+		health = append(health, sourceHealth)
+	}
+	return health
+}
+
+// GetLastRunTime returns when the latest completed ingestion run of source
+// started, whether it was scheduled or triggered through the admin API. With
+// source empty it covers every source and returns the oldest of their last
+// runs. It is nil while a covered source has no completed run.
+func (w *DataWorkerImpl) GetLastRunTime(ctx context.Context, source string) (*time.Time, error) {
+	sources := []string{source}
+	if source == "" {
+		sources = sources[:0]
+		for _, configured := range w.sources {
+			sources = append(sources, configured.Name())
+		}
+	}
+
+	var oldest *time.Time
+	for _, name := range sources {
+		lastRun, err := w.runRepo.GetLastCompletedRunTime(name)
+		if err != nil {
+			return nil, err
+		}
+		if lastRun == nil {
+			return nil, nil
+		}
+		if oldest == nil || lastRun.Before(*oldest) {
+			oldest = lastRun
+		}
+	}
+	return oldest, nil
 }
 
-func (w *DataWorkerImpl) ShouldRun(ctx context.Context) (bool, error) {
-	lastRun, err := w.GetLastRunTime(ctx)
+// ShouldRun reports whether ScheduleInterval has passed since the last
+// completed run of source, or of every source when empty, so a manual run
+// pushes back the next scheduled one.
+func (w *DataWorkerImpl) ShouldRun(ctx context.Context, source string) (bool, error) {
+	lastRun, err := w.GetLastRunTime(ctx, source)
 	if err != nil {
 		return false, errors.NewInternalError("failed to get last run time", err)
 	}
@@ -182,6 +932,7 @@ func (w *DataWorkerImpl) ShouldRun(ctx context.Context) (bool, error) {
 	shouldRun := timeSinceLastRun >= w.config.ScheduleInterval
 
 	w.logger.WithFields(logrus.Fields{
+		"source":              source,
 		"last_run":            lastRun,
 		"time_since_last_run": timeSinceLastRun,
 		"schedule_interval":   w.config.ScheduleInterval,
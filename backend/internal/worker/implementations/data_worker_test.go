@@ -1,13 +1,258 @@
 package implementations
 
 import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/client"
+	"github.com/valeriapadilla/stock-insights/internal/errors"
+	"github.com/valeriapadilla/stock-insights/internal/fakeupstream"
+	"github.com/valeriapadilla/stock-insights/internal/model"
+	"github.com/valeriapadilla/stock-insights/internal/quality"
 )
 
+type memoryCheckpointRepository struct {
+	checkpoints map[string]*model.IngestionCheckpoint
+	locked      map[string]bool
+}
+
+func newMemoryCheckpointRepository(seed ...*model.IngestionCheckpoint) *memoryCheckpointRepository {
+	r := &memoryCheckpointRepository{checkpoints: make(map[string]*model.IngestionCheckpoint)}
+	for _, checkpoint := range seed {
+		r.checkpoints[checkpoint.Source] = checkpoint
+	}
+	return r
+}
+
+func (r *memoryCheckpointRepository) get(source string) *model.IngestionCheckpoint {
+	return r.checkpoints[source]
+}
+
+func (r *memoryCheckpointRepository) GetCheckpoint(source string) (*model.IngestionCheckpoint, error) {
+	checkpoint, ok := r.checkpoints[source]
+	if !ok {
+		return nil, nil
+	}
+	copied := *checkpoint
+	return &copied, nil
+}
+
+func (r *memoryCheckpointRepository) SaveCheckpoint(checkpoint *model.IngestionCheckpoint) error {
+	copied := *checkpoint
+	r.checkpoints[checkpoint.Source] = &copied
+	return nil
+}
+
+func (r *memoryCheckpointRepository) LockSource(ctx context.Context, source string) (func(), bool, error) {
+	if r.locked[source] {
+		return nil, false, nil
+	}
+	if r.locked == nil {
+		r.locked = make(map[string]bool)
+	}
+	r.locked[source] = true
+	return func() { delete(r.locked, source) }, true, nil
+}
+
+func (r *memoryCheckpointRepository) GetDB() *sql.DB {
+	return nil
+}
+
+type recordingStockCommand struct {
+	upserted []string
+	sources  []string
+	onUpsert func()
+	// rejected lists tickers BulkUpsert rejects instead of saving.
+	rejected map[string]string
+}
+
+func (c *recordingStockCommand) Create(stock *model.Stock) error { return nil }
+
+func (c *recordingStockCommand) BulkCreate(stocks []*model.Stock) error { return nil }
+
+func (c *recordingStockCommand) Upsert(stock *model.Stock) error { return nil }
+
+func (c *recordingStockCommand) BulkUpsert(stocks []*model.Stock) (*model.BulkUpsertResult, error) {
+	result := &model.BulkUpsertResult{}
+	for _, stock := range stocks {
+		if reason, ok := c.rejected[stock.Ticker]; ok {
+			payload, _ := json.Marshal(model.NewStockEvent(stock))
+			result.Rejected++
+			result.Rejections = append(result.Rejections, model.StockRejection{
+				Ticker:  stock.Ticker,
+				Time:    stock.Time,
+				Reason:  reason,
+				Payload: payload,
+			})
+			continue
+		}
+		c.upserted = append(c.upserted, stock.Ticker)
+		c.sources = append(c.sources, stock.Source)
+		result.Inserted++
+	}
+	if c.onUpsert != nil {
+		c.onUpsert()
+	}
+	return result, nil
+}
+
+func (c *recordingStockCommand) PreviewBulkUpsert(stocks []*model.Stock) (*model.BulkUpsertResult, error) {
+	return &model.BulkUpsertResult{Inserted: len(stocks)}, nil
+}
+
+// memoryIngestionRunRepository keeps ingestion runs in creation order.
+type memoryIngestionRunRepository struct {
+	runs []*model.IngestionRun
+}
+
+func (r *memoryIngestionRunRepository) CreateRun(run *model.IngestionRun) error {
+	stored := *run
+	r.runs = append(r.runs, &stored)
+	return nil
+}
+
+func (r *memoryIngestionRunRepository) FinishRun(run *model.IngestionRun) error {
+	for i, stored := range r.runs {
+		if stored.ID == run.ID {
+			finished := *run
+			r.runs[i] = &finished
+		}
+	}
+	return nil
+}
+
+func (r *memoryIngestionRunRepository) GetRuns(source string, limit, offset int) ([]*model.IngestionRun, error) {
+	return r.runs, nil
+}
+
+func (r *memoryIngestionRunRepository) GetRunsCount(source string) (int, error) {
+	return len(r.runs), nil
+}
+
+func (r *memoryIngestionRunRepository) GetRunByID(runID string) (*model.IngestionRun, error) {
+	for _, run := range r.runs {
+		if run.ID == runID {
+			return run, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryIngestionRunRepository) GetLastCompletedRunTime(source string) (*time.Time, error) {
+	var last *time.Time
+	for _, run := range r.runs {
+		if run.Source == source && run.Status == model.IngestionStatusCompleted && (last == nil || run.StartedAt.After(*last)) {
+			startedAt := run.StartedAt
+			last = &startedAt
+		}
+	}
+	return last, nil
+}
+
+func (r *memoryIngestionRunRepository) GetDB() *sql.DB {
+	return nil
+}
+
+// memoryStockRejectRepository keeps quarantined events in creation order.
+type memoryStockRejectRepository struct {
+	rejects []*model.StockReject
+}
+
+func (r *memoryStockRejectRepository) CreateRejects(rejects []*model.StockReject) error {
+	r.rejects = append(r.rejects, rejects...)
+	return nil
+}
+
+func (r *memoryStockRejectRepository) GetRejects(filter model.StockRejectFilter, limit, offset int) ([]*model.StockReject, error) {
+	return r.rejects, nil
+}
+
+func (r *memoryStockRejectRepository) GetRejectsCount(filter model.StockRejectFilter) (int, error) {
+	return len(r.rejects), nil
+}
+
+func (r *memoryStockRejectRepository) GetRejectByID(rejectID string) (*model.StockReject, error) {
+	for _, reject := range r.rejects {
+		if reject.ID == rejectID {
+			return reject, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryStockRejectRepository) UpdateReject(reject *model.StockReject) error {
+	return nil
+}
+
+func (r *memoryStockRejectRepository) GetDB() *sql.DB {
+	return nil
+}
+
+// memoryDataQualityRepository keeps data quality findings in creation order.
+type memoryDataQualityRepository struct {
+	findings []*model.DataQualityFinding
+}
+
+func (r *memoryDataQualityRepository) CreateFindings(findings []*model.DataQualityFinding) error {
+	r.findings = append(r.findings, findings...)
+	return nil
+}
+
+func (r *memoryDataQualityRepository) GetFindings(runID string, filter model.DataQualityFindingFilter, limit, offset int) ([]*model.DataQualityFinding, error) {
+	return r.findings, nil
+}
+
+func (r *memoryDataQualityRepository) GetFindingsCount(runID string, filter model.DataQualityFindingFilter) (int, error) {
+	return len(r.findings), nil
+}
+
+func (r *memoryDataQualityRepository) GetDB() *sql.DB {
+	return nil
+}
+
+// pagedUpstream serves pages keyed by next_page cursor; each page lists
+// tickers with their event day in March 2025, newest first.
+type pagedUpstream struct {
+	pages    map[string][]string
+	next     map[string]string
+	failOn   string
+	requests []string
+}
+
+func (u *pagedUpstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cursor := r.URL.Query().Get("next_page")
+	u.requests = append(u.requests, cursor)
+	if cursor == u.failOn && u.failOn != "" {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	var items []string
+	for _, entry := range u.pages[cursor] {
+		parts := strings.Split(entry, "@")
+		items = append(items, fmt.Sprintf(`{"ticker": %q, "company": "Co", "action": "target raised by", "brokerage": "Goldman", "rating_to": "Buy", "time": "2025-03-%sT10:00:00Z"}`, parts[0], parts[1]))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"items": [%s], "next_page": %q}`, strings.Join(items, ","), u.next[cursor])
+}
+
+func newCheckpointTestWorker(serverURL string, checkpoints *memoryCheckpointRepository, stockCommand *recordingStockCommand) *DataWorkerImpl {
+	logger := logrus.New()
+	externalClient := client.NewExternalAPIClient(client.ExternalAPIConfig{BaseURL: serverURL, Timeout: 5 * time.Second}, logger)
+	return NewDataWorker([]client.StockSource{externalClient}, nil, stockCommand, checkpoints, &memoryIngestionRunRepository{}, &memoryStockRejectRepository{}, &memoryDataQualityRepository{}, nil, logger, DataWorkerConfig{}).(*DataWorkerImpl)
+}
+
 func TestDataWorkerConfig(t *testing.T) {
 	config := DataWorkerConfig{
 		ScheduleInterval: 1 * time.Hour,
@@ -65,3 +310,624 @@ func TestDataWorkerImpl_StructInitialization(t *testing.T) {
 	assert.NotNil(t, worker.logger)
 	assert.Equal(t, 1*time.Hour, worker.config.ScheduleInterval)
 }
+
+func TestDataWorkerImpl_FetchAndProcessSource_SkipsLockedSource(t *testing.T) {
+	upstream := &pagedUpstream{pages: map[string][]string{"": {"AAPL@12"}}}
+	server := httptest.NewServer(upstream)
+	defer server.Close()
+
+	checkpoints := newMemoryCheckpointRepository()
+	stockCommand := &recordingStockCommand{}
+	worker := newCheckpointTestWorker(server.URL, checkpoints, stockCommand)
+
+	// Another ingestion of the source holds the lock
+	unlock, ok, err := checkpoints.LockSource(context.Background(), model.DefaultStockSource)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.Error(t, err)
+	assert.True(t, errors.IsConflictError(err))
+	assert.Contains(t, err.Error(), "already running")
+	assert.Empty(t, upstream.requests)
+	assert.Nil(t, checkpoints.get(model.DefaultStockSource))
+	assert.Empty(t, worker.runRepo.(*memoryIngestionRunRepository).runs)
+
+	// Every source is locked, so ingesting all of them conflicts too
+	_, err = worker.FetchAndProcessStocks(context.Background(), nil)
+	assert.True(t, errors.IsConflictError(err))
+
+	unlock()
+	_, err = worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AAPL"}, stockCommand.upserted)
+}
+
+func TestDataWorkerImpl_FetchAndProcessStocks_ResumesFromCheckpoint(t *testing.T) {
+	upstream := &pagedUpstream{
+		pages: map[string][]string{
+			"":     {"AAPL@12", "MSFT@11"},
+			"MSFT": {"NVDA@10"},
+			"NVDA": {"TSLA@09"},
+		},
+		next:   map[string]string{"": "MSFT", "MSFT": "NVDA"},
+		failOn: "NVDA",
+	}
+	server := httptest.NewServer(upstream)
+	defer server.Close()
+
+	checkpoints := newMemoryCheckpointRepository()
+	stockCommand := &recordingStockCommand{}
+	worker := newCheckpointTestWorker(server.URL, checkpoints, stockCommand)
+
+	// First run fails on the third page after committing two
+	result, err := worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.Error(t, err)
+	assert.Equal(t, 2, result.PagesFetched)
+	assert.Equal(t, model.IngestionStatusFailed, checkpoints.get(model.DefaultStockSource).Status)
+	assert.Equal(t, "NVDA", checkpoints.get(model.DefaultStockSource).NextPage)
+	assert.Equal(t, 2, checkpoints.get(model.DefaultStockSource).PagesCommitted)
+	assert.Nil(t, checkpoints.get(model.DefaultStockSource).HighWaterMark)
+
+	// Second run resumes from the failed page only
+	upstream.failOn = ""
+	upstream.requests = nil
+	result, err = worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NVDA"}, upstream.requests)
+	assert.True(t, result.Resumed)
+	assert.Equal(t, 2, result.PagesResumed)
+	assert.Equal(t, 1, result.PagesFetched)
+	assert.Equal(t, []string{"AAPL", "MSFT", "NVDA", "TSLA"}, stockCommand.upserted)
+
+	assert.Equal(t, model.IngestionStatusCompleted, checkpoints.get(model.DefaultStockSource).Status)
+	assert.Empty(t, checkpoints.get(model.DefaultStockSource).NextPage)
+	require.NotNil(t, checkpoints.get(model.DefaultStockSource).HighWaterMark)
+	assert.Equal(t, 12, checkpoints.get(model.DefaultStockSource).HighWaterMark.Day())
+}
+
+func TestDataWorkerImpl_FetchAndProcessSource_QuarantinesRejects(t *testing.T) {
+	upstream := &pagedUpstream{
+		pages: map[string][]string{"": {"AAPL@12", "BAD@11", "MSFT@10"}},
+	}
+	server := httptest.NewServer(upstream)
+	defer server.Close()
+
+	logger := logrus.New()
+	externalClient := client.NewExternalAPIClient(client.ExternalAPIConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, logger)
+	stockCommand := &recordingStockCommand{rejected: map[string]string{"BAD": "invalid target price"}}
+	runs := &memoryIngestionRunRepository{}
+	rejects := &memoryStockRejectRepository{}
+	worker := NewDataWorker([]client.StockSource{externalClient}, nil, stockCommand, newMemoryCheckpointRepository(), runs, rejects, &memoryDataQualityRepository{}, nil, logger, DataWorkerConfig{})
+
+	result, err := worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.StocksInserted)
+	assert.Equal(t, 1, result.StocksRejected)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, stockCommand.upserted)
+
+	// The rejected event is kept, linked to its run, with the upstream payload
+	require.Len(t, rejects.rejects, 1)
+	reject := rejects.rejects[0]
+	require.Len(t, runs.runs, 1)
+	assert.Equal(t, runs.runs[0].ID, reject.RunID)
+	assert.Equal(t, model.DefaultStockSource, reject.Source)
+	assert.Equal(t, "BAD", reject.Ticker)
+	assert.Equal(t, "invalid target price", reject.ErrorMessage)
+	assert.Equal(t, model.StockRejectStatusPending, reject.Status)
+	require.NotNil(t, reject.EventTime)
+	assert.Equal(t, 11, reject.EventTime.Day())
+
+	var event model.Stock
+	require.NoError(t, json.Unmarshal(reject.Payload, &event))
+	assert.Equal(t, "BAD", event.Ticker)
+}
+
+func TestDataWorkerImpl_FetchAndProcessSource_DataQuality(t *testing.T) {
+	future := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"items": [
+			{"ticker": "AAPL", "company": "Apple", "target_from": "$100.00", "target_to": "$120.00", "action": "target raised by", "brokerage": "Goldman", "rating_to": "Buy", "time": "2025-03-12T10:00:00Z"},
+			{"ticker": "MSFT", "company": "Microsoft", "target_from": "$100.00", "target_to": "n/a", "action": "target raised by", "brokerage": "Goldman", "rating_to": "Buy", "time": "2025-03-11T10:00:00Z"},
+			{"ticker": "NVDA", "company": "NVIDIA", "target_from": "$100.00", "target_to": "$110.00", "action": "target raised by", "brokerage": "Goldman", "rating_to": "Buy", "time": %q}
+		]}`, future)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		policy     model.DataQualityPolicy
+		wantStatus model.DataQualityStatus
+		wantStored []string
+		wantErr    bool
+	}{
+		{name: "flag", policy: model.DataQualityPolicyFlag, wantStatus: model.DataQualityStatusFlagged, wantStored: []string{"AAPL", "MSFT", "NVDA"}},
+		{name: "fail", policy: model.DataQualityPolicyFail, wantStatus: model.DataQualityStatusFailed, wantStored: []string{"AAPL"}, wantErr: true},
+		{name: "off", policy: model.DataQualityPolicyOff, wantStored: []string{"AAPL", "MSFT", "NVDA"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			externalClient := client.NewExternalAPIClient(client.ExternalAPIConfig{BaseURL: server.URL, Timeout: 5 * time.Second}, logger)
+			stockCommand := &recordingStockCommand{}
+			runs := &memoryIngestionRunRepository{}
+			findings := &memoryDataQualityRepository{}
+			rejects := &memoryStockRejectRepository{}
+			config := DataWorkerConfig{Quality: quality.Config{Policy: tt.policy}}
+			worker := NewDataWorker([]client.StockSource{externalClient}, nil, stockCommand, newMemoryCheckpointRepository(), runs, rejects, findings, nil, logger, config)
+
+			result, err := worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "data quality checks failed")
+			} else {
+				require.NoError(t, err)
+			}
+
+			// Only the fail policy keeps events with error findings out of storage
+			assert.Equal(t, tt.wantStored, stockCommand.upserted)
+			assert.Equal(t, 3-len(tt.wantStored), result.StocksRejected)
+			assert.Equal(t, tt.wantStatus, result.QualityStatus)
+
+			require.Len(t, runs.runs, 1)
+			run := runs.runs[0]
+			assert.Equal(t, tt.wantStatus, run.QualityStatus)
+			if tt.wantErr {
+				assert.Equal(t, model.IngestionStatusFailed, run.Status)
+			} else {
+				assert.Equal(t, model.IngestionStatusCompleted, run.Status)
+			}
+
+			if tt.policy == model.DataQualityPolicyOff {
+				assert.Empty(t, findings.findings)
+				return
+			}
+
+			assert.Equal(t, 2, run.QualityErrors)
+			rules := make(map[string]string)
+			for _, finding := range findings.findings {
+				assert.Equal(t, run.ID, finding.RunID)
+				assert.NotEmpty(t, finding.ID)
+				rules[finding.Ticker] = finding.Rule
+			}
+			assert.Equal(t, map[string]string{"MSFT": "unparseable_target", "NVDA": "future_timestamp"}, rules)
+
+			if !tt.wantErr {
+				assert.Empty(t, rejects.rejects)
+				return
+			}
+
+			// The blocked events are quarantined with their findings as the reason
+			require.Len(t, rejects.rejects, 2)
+			for _, reject := range rejects.rejects {
+				assert.Equal(t, run.ID, reject.RunID)
+				assert.Contains(t, reject.ErrorMessage, "data quality: ")
+				assert.NotEmpty(t, reject.Payload)
+			}
+			assert.Equal(t, "MSFT", rejects.rejects[0].Ticker)
+			assert.Equal(t, "NVDA", rejects.rejects[1].Ticker)
+		})
+	}
+}
+
+func TestDataWorkerImpl_FetchAndProcessStocks_StopsAtHighWaterMark(t *testing.T) {
+	upstream := &pagedUpstream{
+		pages: map[string][]string{
+			// TSLA is new but shares the timestamp of the mark
+			"":     {"AMD@14", "TSLA@12", "AAPL@11"},
+			"AAPL": {"MSFT@10"},
+		},
+		next: map[string]string{"": "AAPL"},
+	}
+	server := httptest.NewServer(upstream)
+	defer server.Close()
+
+	highWaterMark := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+	checkpoints := newMemoryCheckpointRepository(&model.IngestionCheckpoint{
+		Source:        model.DefaultStockSource,
+		Status:        model.IngestionStatusCompleted,
+		HighWaterMark: &highWaterMark,
+	})
+	stockCommand := &recordingStockCommand{}
+	worker := newCheckpointTestWorker(server.URL, checkpoints, stockCommand)
+
+	result, err := worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{""}, upstream.requests)
+	assert.False(t, result.Resumed)
+	assert.True(t, result.ReachedHighWaterMark)
+	assert.Equal(t, 1, result.PagesFetched)
+	assert.Equal(t, 0, result.PagesSkipped)
+	assert.Equal(t, 3, result.StocksFetched)
+	assert.Equal(t, 2, result.StocksSaved)
+	assert.Equal(t, []string{"AMD", "TSLA"}, stockCommand.upserted)
+	assert.Equal(t, 14, checkpoints.get(model.DefaultStockSource).HighWaterMark.Day())
+
+	// Nothing new upstream: only the event at the mark is upserted again
+	upstream.pages[""] = []string{"AMD@14", "TSLA@12"}
+	upstream.next[""] = ""
+	result, err = worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.True(t, result.ReachedHighWaterMark)
+	assert.Equal(t, []string{"AMD", "TSLA", "AMD"}, stockCommand.upserted)
+
+	// Only events before the mark: the page is skipped
+	upstream.pages[""] = []string{"TSLA@12"}
+	result, err = worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.PagesSkipped)
+	assert.Equal(t, 0, result.StocksSaved)
+	assert.Equal(t, []string{"AMD", "TSLA", "AMD"}, stockCommand.upserted)
+}
+
+func TestDataWorkerImpl_FetchAndProcessStocks_RevisionLookback(t *testing.T) {
+	upstream := &pagedUpstream{
+		pages: map[string][]string{
+			"":     {"AMD@14", "AAPL@12"},
+			"AAPL": {"MSFT@11", "NVDA@09"},
+		},
+		next: map[string]string{"": "AAPL"},
+	}
+	server := httptest.NewServer(upstream)
+	defer server.Close()
+
+	highWaterMark := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+	checkpoints := newMemoryCheckpointRepository(&model.IngestionCheckpoint{
+		Source:        model.DefaultStockSource,
+		Status:        model.IngestionStatusCompleted,
+		HighWaterMark: &highWaterMark,
+	})
+	stockCommand := &recordingStockCommand{}
+	worker := newCheckpointTestWorker(server.URL, checkpoints, stockCommand)
+	worker.config.RevisionLookback = 48 * time.Hour
+
+	// Events within two days of the mark are upserted again for corrections
+	result, err := worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "AAPL"}, upstream.requests)
+	assert.True(t, result.ReachedHighWaterMark)
+	assert.Equal(t, []string{"AMD", "AAPL", "MSFT"}, stockCommand.upserted)
+	assert.Equal(t, 14, checkpoints.get(model.DefaultStockSource).HighWaterMark.Day())
+}
+
+func TestDataWorkerImpl_FetchAndProcessStocks_StopsOnCancellation(t *testing.T) {
+	upstream := &pagedUpstream{
+		pages: map[string][]string{
+			"":     {"AAPL@12"},
+			"MSFT": {"MSFT@11"},
+			"NVDA": {"NVDA@10"},
+		},
+		next: map[string]string{"": "MSFT", "MSFT": "NVDA"},
+	}
+	server := httptest.NewServer(upstream)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkpoints := newMemoryCheckpointRepository()
+	stockCommand := &recordingStockCommand{onUpsert: cancel}
+	worker := newCheckpointTestWorker(server.URL, checkpoints, stockCommand)
+
+	result, err := worker.FetchAndProcessSource(ctx, model.DefaultStockSource, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ingestion cancelled")
+	assert.Equal(t, 1, result.PagesFetched)
+	assert.Equal(t, []string{"AAPL"}, stockCommand.upserted)
+
+	// The committed page is kept and the next run resumes after it
+	assert.Equal(t, model.IngestionStatusFailed, checkpoints.get(model.DefaultStockSource).Status)
+	assert.Equal(t, "MSFT", checkpoints.get(model.DefaultStockSource).NextPage)
+	assert.Equal(t, 1, checkpoints.get(model.DefaultStockSource).PagesCommitted)
+}
+
+func TestDataWorkerImpl_FetchAndProcessStocks_StopsBetweenBatches(t *testing.T) {
+	var page []string
+	for i := 0; i < 501; i++ {
+		page = append(page, fmt.Sprintf("T%d@12", i))
+	}
+	upstream := &pagedUpstream{pages: map[string][]string{"": page}}
+	server := httptest.NewServer(upstream)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkpoints := newMemoryCheckpointRepository()
+	stockCommand := &recordingStockCommand{onUpsert: cancel}
+	worker := newCheckpointTestWorker(server.URL, checkpoints, stockCommand)
+
+	_, err := worker.FetchAndProcessSource(ctx, model.DefaultStockSource, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ingestion cancelled")
+
+	// Only the first batch was written and the page is not committed
+	assert.Len(t, stockCommand.upserted, 500)
+	assert.Equal(t, 0, checkpoints.get(model.DefaultStockSource).PagesCommitted)
+}
+
+func TestDataWorkerImpl_FetchAndProcessStocks_ReportsProgress(t *testing.T) {
+	primary := httptest.NewServer(&pagedUpstream{
+		pages: map[string][]string{"": {"AAPL@12"}, "AAPL": {"MSFT@11"}},
+		next:  map[string]string{"": "AAPL"},
+	})
+	defer primary.Close()
+	backup := httptest.NewServer(&pagedUpstream{pages: map[string][]string{"": {"NVDA@10"}}})
+	defer backup.Close()
+
+	logger := logrus.New()
+	newSource := func(name, url string) client.StockSource {
+		return client.NewExternalAPIClient(client.ExternalAPIConfig{Name: name, BaseURL: url, Timeout: 5 * time.Second}, logger)
+	}
+	worker := NewDataWorker(
+		[]client.StockSource{newSource("primary", primary.URL), newSource("backup", backup.URL)},
+		nil, &recordingStockCommand{}, newMemoryCheckpointRepository(), &memoryIngestionRunRepository{},
+		&memoryStockRejectRepository{}, &memoryDataQualityRepository{}, nil, logger, DataWorkerConfig{},
+	)
+
+	var percents []int
+	var messages []string
+	_, err := worker.FetchAndProcessStocks(context.Background(), func(progress int, message string) {
+		percents = append(percents, progress)
+		messages = append(messages, message)
+	})
+	require.NoError(t, err)
+
+	// Each source covers half of the job and progress never goes back
+	assert.Equal(t, 0, percents[0])
+	assert.Contains(t, messages, "Fetching stocks from backup")
+	assert.Contains(t, percents, 50)
+	assert.Equal(t, 100, percents[len(percents)-1])
+	assert.IsNonDecreasing(t, percents)
+	assert.Contains(t, messages, "Source primary: committed page 2, 2 stocks saved")
+}
+
+func TestEstimateProgress(t *testing.T) {
+	startedAt := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+	highWaterMark := startedAt.Add(-10 * 24 * time.Hour)
+	checkpoint := &model.IngestionCheckpoint{HighWaterMark: &highWaterMark}
+	page := func(daysOld ...int) *client.StockPage {
+		page := &client.StockPage{NextPage: "next"}
+		for _, days := range daysOld {
+			page.Items = append(page.Items, model.Stock{Time: startedAt.Add(-time.Duration(days) * 24 * time.Hour)})
+		}
+		return page
+	}
+
+	scope := &runScope{startedAt: startedAt}
+	assert.Equal(t, 40, estimateProgress(scope, checkpoint, page(1, 4, 2), false))
+
+	// Never goes back, and only reaches 100 on the last page
+	scope.percent = 60
+	assert.Equal(t, 60, estimateProgress(scope, checkpoint, page(3), false))
+	assert.Equal(t, 99, estimateProgress(scope, checkpoint, page(12), false))
+	assert.Equal(t, 100, estimateProgress(scope, checkpoint, page(3), true))
+	assert.Equal(t, 100, estimateProgress(scope, checkpoint, &client.StockPage{}, false))
+
+	// Without a high-water mark there is no estimate
+	assert.Equal(t, 60, estimateProgress(scope, &model.IngestionCheckpoint{}, page(8), false))
+}
+
+func TestDataWorkerImpl_FetchAndProcessStocks_MultipleSources(t *testing.T) {
+	primary := httptest.NewServer(&pagedUpstream{pages: map[string][]string{"": {"AAPL@12"}}})
+	defer primary.Close()
+	backup := httptest.NewServer(&pagedUpstream{pages: map[string][]string{"": {"MSFT@11"}}})
+	defer backup.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer broken.Close()
+
+	logger := logrus.New()
+	newSource := func(name, url string) client.StockSource {
+		return client.NewExternalAPIClient(client.ExternalAPIConfig{Name: name, BaseURL: url, Timeout: 5 * time.Second}, logger)
+	}
+
+	checkpoints := newMemoryCheckpointRepository()
+	stockCommand := &recordingStockCommand{}
+	runs := &memoryIngestionRunRepository{}
+	worker := NewDataWorker(
+		[]client.StockSource{newSource("primary", primary.URL), newSource("broken", broken.URL), newSource("backup", backup.URL)},
+		nil, stockCommand, checkpoints, runs, &memoryStockRejectRepository{}, &memoryDataQualityRepository{}, nil, logger, DataWorkerConfig{},
+	)
+
+	results, err := worker.FetchAndProcessStocks(context.Background(), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken")
+
+	// The failing source does not stop the ones after it
+	require.Len(t, results, 3)
+	assert.Equal(t, "primary", results[0].Source)
+	assert.Equal(t, "backup", results[2].Source)
+	assert.Equal(t, []string{"AAPL", "MSFT"}, stockCommand.upserted)
+	assert.Equal(t, []string{"primary", "backup"}, stockCommand.sources)
+	assert.Equal(t, model.IngestionStatusFailed, checkpoints.get("broken").Status)
+	assert.Equal(t, model.IngestionStatusCompleted, checkpoints.get("backup").Status)
+
+	// Each source run is recorded with its outcome
+	require.Len(t, runs.runs, 3)
+	assert.Equal(t, "primary", runs.runs[0].Source)
+	assert.Equal(t, model.IngestionStatusCompleted, runs.runs[0].Status)
+	assert.Equal(t, 1, runs.runs[0].StocksInserted)
+	assert.Equal(t, model.IngestionStatusFailed, runs.runs[1].Status)
+	assert.Contains(t, runs.runs[1].ErrorMessage, "broken")
+	assert.Equal(t, model.IngestionStatusCompleted, runs.runs[2].Status)
+
+	sources, err := worker.GetSources()
+	require.NoError(t, err)
+	require.Len(t, sources, 3)
+	assert.Equal(t, "broken", sources[1].Name)
+	assert.Equal(t, 2, sources[1].Precedence)
+
+	_, err = worker.FetchAndProcessSource(context.Background(), "unknown", nil)
+	assert.Error(t, err)
+}
+
+func newFakeUpstreamWorker(serverURL string, checkpoints *memoryCheckpointRepository, stockCommand *recordingStockCommand) *DataWorkerImpl {
+	logger := logrus.New()
+	externalClient := client.NewExternalAPIClient(client.ExternalAPIConfig{BaseURL: serverURL, Timeout: 5 * time.Second}, logger)
+	config := DataWorkerConfig{MaxRetries: 2, RetryDelay: time.Millisecond}
+	return NewDataWorker([]client.StockSource{externalClient}, nil, stockCommand, checkpoints, &memoryIngestionRunRepository{}, &memoryStockRejectRepository{}, &memoryDataQualityRepository{}, nil, logger, config).(*DataWorkerImpl)
+}
+
+func TestDataWorkerImpl_FakeUpstream_RetriesTransientFaults(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+	events := fakeupstream.Generate(12, 1, now)
+	upstream, server := fakeupstream.NewTestServer(events, fakeupstream.Options{
+		PageSize: 5,
+		Faults: []fakeupstream.Fault{
+			{Kind: fakeupstream.FaultTruncated, Page: 1, Times: 1},
+			{Kind: fakeupstream.FaultStatus, Status: http.StatusServiceUnavailable, Page: 2, Times: 2},
+			{Kind: fakeupstream.FaultRateLimit, Page: 3, Times: 1},
+		},
+	})
+	defer server.Close()
+
+	checkpoints := newMemoryCheckpointRepository()
+	stockCommand := &recordingStockCommand{}
+	worker := newFakeUpstreamWorker(server.URL, checkpoints, stockCommand)
+
+	result, err := worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.PagesFetched)
+	assert.Equal(t, 12, result.StocksSaved)
+	assert.Len(t, stockCommand.upserted, 12)
+
+	cursors := upstream.Cursors()
+	assert.Equal(t, []string{"", "", cursors[1], cursors[1], cursors[1], cursors[2], cursors[2]}, upstream.Requests())
+	assert.Equal(t, model.IngestionStatusCompleted, checkpoints.get(model.DefaultStockSource).Status)
+	assert.Equal(t, now, checkpoints.get(model.DefaultStockSource).HighWaterMark.UTC())
+}
+
+func TestDataWorkerImpl_FakeUpstream_ResumesAfterFatalFault(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+	upstream, server := fakeupstream.NewTestServer(fakeupstream.Generate(9, 2, now), fakeupstream.Options{PageSize: 3})
+	defer server.Close()
+	upstream.AddFault(fakeupstream.Fault{Kind: fakeupstream.FaultMalformed, Page: 3})
+
+	checkpoints := newMemoryCheckpointRepository()
+	stockCommand := &recordingStockCommand{}
+	worker := newFakeUpstreamWorker(server.URL, checkpoints, stockCommand)
+
+	// Malformed JSON is not retried; the two committed pages are kept
+	result, err := worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode response")
+	assert.Equal(t, 2, result.PagesFetched)
+	assert.Equal(t, upstream.Cursors()[2], checkpoints.get(model.DefaultStockSource).NextPage)
+
+	upstream.ClearFaults()
+	upstream.ResetRequests()
+	result, err = worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.NoError(t, err)
+	assert.True(t, result.Resumed)
+	assert.Equal(t, []string{upstream.Cursors()[2]}, upstream.Requests())
+	assert.Len(t, stockCommand.upserted, 9)
+	assert.Equal(t, model.IngestionStatusCompleted, checkpoints.get(model.DefaultStockSource).Status)
+}
+
+func TestDataWorkerImpl_FakeUpstream_CircuitBreakerFailsFast(t *testing.T) {
+	now := time.Date(2025, time.March, 12, 10, 0, 0, 0, time.UTC)
+	upstream, server := fakeupstream.NewTestServer(fakeupstream.Generate(6, 1, now), fakeupstream.Options{
+		PageSize: 3,
+		Faults:   []fakeupstream.Fault{{Kind: fakeupstream.FaultStatus, Status: http.StatusBadGateway}},
+	})
+	defer server.Close()
+
+	logger := logrus.New()
+	externalClient := client.NewExternalAPIClient(client.ExternalAPIConfig{
+		BaseURL:        server.URL,
+		Timeout:        5 * time.Second,
+		CircuitBreaker: client.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute},
+	}, logger)
+	config := DataWorkerConfig{MaxRetries: 5, RetryDelay: time.Millisecond}
+	worker := NewDataWorker([]client.StockSource{externalClient}, nil, &recordingStockCommand{}, newMemoryCheckpointRepository(),
+		&memoryIngestionRunRepository{}, &memoryStockRejectRepository{}, &memoryDataQualityRepository{}, nil, logger, config).(*DataWorkerImpl)
+
+	// The breaker opens on the second failure instead of using every retry
+	_, err := worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, client.ErrCircuitOpen)
+	assert.Len(t, upstream.Requests(), 2)
+
+	// While open, ingestion fails before calling the upstream
+	upstream.ResetRequests()
+	_, err = worker.FetchAndProcessSource(context.Background(), model.DefaultStockSource, nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, client.ErrCircuitOpen)
+	assert.Contains(t, err.Error(), "EXTERNAL_ERROR: circuit breaker open for source external_api")
+	assert.Empty(t, upstream.Requests())
+
+	sources, err := worker.GetSources()
+	require.NoError(t, err)
+	require.NotNil(t, sources[0].Circuit)
+	assert.Equal(t, model.CircuitOpen, sources[0].Circuit.State)
+	assert.Equal(t, int64(1), sources[0].Circuit.Opens)
+
+	health := worker.GetSourcesHealth(context.Background())
+	require.Len(t, health, 1)
+	assert.False(t, health[0].Healthy)
+	assert.Contains(t, health[0].Error, "circuit breaker open")
+}
+
+func TestDataWorkerImpl_ShouldRun_FollowsRunHistory(t *testing.T) {
+	runs := &memoryIngestionRunRepository{}
+	worker := NewDataWorker(nil, nil, &recordingStockCommand{}, newMemoryCheckpointRepository(), runs,
+		&memoryStockRejectRepository{}, &memoryDataQualityRepository{}, nil, logrus.New(),
+		DataWorkerConfig{ScheduleInterval: time.Hour}).(*DataWorkerImpl)
+
+	shouldRun, err := worker.ShouldRun(context.Background(), "primary")
+	require.NoError(t, err)
+	assert.True(t, shouldRun)
+
+	// Failed runs do not count; a completed one, manual or not, defers the next
+	require.NoError(t, runs.CreateRun(&model.IngestionRun{ID: "failed", Status: model.IngestionStatusFailed, StartedAt: time.Now(), IngestionResult: model.IngestionResult{Source: "primary"}}))
+	require.NoError(t, runs.CreateRun(&model.IngestionRun{ID: "old", Status: model.IngestionStatusCompleted, StartedAt: time.Now().Add(-2 * time.Hour), IngestionResult: model.IngestionResult{Source: "primary"}}))
+	shouldRun, err = worker.ShouldRun(context.Background(), "primary")
+	require.NoError(t, err)
+	assert.True(t, shouldRun)
+
+	require.NoError(t, runs.CreateRun(&model.IngestionRun{ID: "manual", Status: model.IngestionStatusCompleted, StartedAt: time.Now().Add(-10 * time.Minute), IngestionResult: model.IngestionResult{Source: "primary"}}))
+	shouldRun, err = worker.ShouldRun(context.Background(), "primary")
+	require.NoError(t, err)
+	assert.False(t, shouldRun)
+
+	// Runs of another source do not count
+	shouldRun, err = worker.ShouldRun(context.Background(), "secondary")
+	require.NoError(t, err)
+	assert.True(t, shouldRun)
+}
+
+func TestDataWorkerImpl_ShouldRun_WaitsForEverySource(t *testing.T) {
+	runs := &memoryIngestionRunRepository{}
+	sources := []client.StockSource{
+		client.NewExternalAPIClient(client.ExternalAPIConfig{Name: "primary"}, logrus.New()),
+		client.NewExternalAPIClient(client.ExternalAPIConfig{Name: "secondary"}, logrus.New()),
+	}
+	worker := NewDataWorker(sources, nil, &recordingStockCommand{},
+		newMemoryCheckpointRepository(), runs, &memoryStockRejectRepository{}, &memoryDataQualityRepository{}, nil, logrus.New(),
+		DataWorkerConfig{ScheduleInterval: time.Hour}).(*DataWorkerImpl)
+
+	// A recent run of one source does not cover the other
+	require.NoError(t, runs.CreateRun(&model.IngestionRun{ID: "primary", Status: model.IngestionStatusCompleted, StartedAt: time.Now().Add(-10 * time.Minute), IngestionResult: model.IngestionResult{Source: "primary"}}))
+	shouldRun, err := worker.ShouldRun(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, shouldRun)
+
+	// The oldest last run decides
+	require.NoError(t, runs.CreateRun(&model.IngestionRun{ID: "secondary", Status: model.IngestionStatusCompleted, StartedAt: time.Now().Add(-2 * time.Hour), IngestionResult: model.IngestionResult{Source: "secondary"}}))
+	lastRun, err := worker.GetLastRunTime(context.Background(), "")
+	require.NoError(t, err)
+	require.NotNil(t, lastRun)
+	assert.WithinDuration(t, time.Now().Add(-2*time.Hour), *lastRun, time.Minute)
+	shouldRun, err = worker.ShouldRun(context.Background(), "")
+	require.NoError(t, err)
+	assert.True(t, shouldRun)
+
+	require.NoError(t, runs.CreateRun(&model.IngestionRun{ID: "secondary-manual", Status: model.IngestionStatusCompleted, StartedAt: time.Now().Add(-5 * time.Minute), IngestionResult: model.IngestionResult{Source: "secondary"}}))
+	shouldRun, err = worker.ShouldRun(context.Background(), "")
+	require.NoError(t, err)
+	assert.False(t, shouldRun)
+}
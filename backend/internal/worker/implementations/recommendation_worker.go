@@ -19,12 +19,15 @@ type RecommendationWorkerImpl struct {
 	logger                *logrus.Logger
 	isRunning             bool
 	mutex                 sync.RWMutex
-	lastRunTime           *time.Time
+	scheduleInterval      time.Duration
 }
 
+// NewRecommendationWorker creates a worker whose ShouldRun requires
+// scheduleInterval since the last published run.
 func NewRecommendationWorker(
 	recommendationService interfaces.RecommendationServiceInterface,
 	stockRepo repoInterfaces.StockRepository,
+	scheduleInterval time.Duration,
 	logger *logrus.Logger,
 ) workerInterfaces.RecommendationWorker {
 	return &RecommendationWorkerImpl{
@@ -32,6 +35,7 @@ func NewRecommendationWorker(
 		stockRepo:             stockRepo,
 		logger:                logger,
 		isRunning:             false,
+		scheduleInterval:      scheduleInterval,
 	}
 }
 
@@ -51,15 +55,40 @@ func (w *RecommendationWorkerImpl) RunDailyRecommendations(ctx context.Context)
 		return err
 	}
 
-	w.setLastRunTime(time.Now())
 	w.logger.Info("Daily recommendation process completed successfully")
 	return nil
 }
 
+// GetLastRunTime returns when the latest published recommendation run
+// started, whether it was scheduled or triggered through the admin API.
 func (w *RecommendationWorkerImpl) GetLastRunTime() (*time.Time, error) {
-	w.mutex.RLock()
-	defer w.mutex.RUnlock()
-	return w.lastRunTime, nil
+	return w.recommendationService.GetLastRunTime()
+}
+
+// ShouldRun reports whether the schedule interval has passed since the last
+// published run, so a manual run pushes back the next scheduled one.
+func (w *RecommendationWorkerImpl) ShouldRun(ctx context.Context) (bool, error) {
+	lastRun, err := w.GetLastRunTime()
+	if err != nil {
+		return false, err
+	}
+
+	if lastRun == nil {
+		w.logger.Info("No previous recommendation run found, should run")
+		return true, nil
+	}
+
+	timeSinceLastRun := time.Since(*lastRun)
+	shouldRun := timeSinceLastRun >= w.scheduleInterval
+
+	w.logger.WithFields(logrus.Fields{
+		"last_run":            lastRun,
+		"time_since_last_run": timeSinceLastRun,
+		"schedule_interval":   w.scheduleInterval,
+		"should_run":          shouldRun,
+	}).Debug("Checking if recommendations should run")
+
+	return shouldRun, nil
 }
 
 func (w *RecommendationWorkerImpl) IsRunning() bool {
@@ -113,17 +142,17 @@ func (w *RecommendationWorkerImpl) calculateRecommendations(ctx context.Context)
 		MinScore:   80,
 	}
 
-	recommendations, err := w.recommendationService.CalculateRecommendations(params)
+	run, err := w.recommendationService.CalculateRecommendations(ctx, params)
 	if err != nil {
 		return err
 	}
 
-	if err := w.recommendationService.SaveRecommendations(recommendations); err != nil {
+	if err := w.recommendationService.SaveRecommendations(run); err != nil {
 		w.logger.WithError(err).Error("Failed to save recommendations")
 		return err
 	}
 
-	w.logger.Info("Recommendations calculated and saved successfully")
+	w.logger.WithField("run_id", run.ID).Info("Recommendations calculated and saved successfully")
 	return nil
 }
 
@@ -132,9 +161,3 @@ func (w *RecommendationWorkerImpl) setRunning(running bool) {
 	defer w.mutex.Unlock()
 	w.isRunning = running
 }
-
-func (w *RecommendationWorkerImpl) setLastRunTime(t time.Time) {
-	w.mutex.Lock()
-	defer w.mutex.Unlock()
-	w.lastRunTime = &t
-}
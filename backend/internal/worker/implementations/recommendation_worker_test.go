@@ -1,11 +1,14 @@
 package implementations
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valeriapadilla/stock-insights/internal/service/interfaces"
 )
 
 func TestRecommendationWorkerImpl_NewRecommendationWorker(t *testing.T) {
@@ -43,18 +46,37 @@ func TestRecommendationWorkerImpl_IsRunning(t *testing.T) {
 	assert.True(t, worker.IsRunning())
 }
 
-func TestRecommendationWorkerImpl_GetLastRunTime(t *testing.T) {
-	worker := &RecommendationWorkerImpl{
-		logger: logrus.New(),
-	}
+// lastRunService reports lastRun as the last published recommendation run.
+type lastRunService struct {
+	interfaces.RecommendationServiceInterface
+	lastRun *time.Time
+}
+
+func (s *lastRunService) GetLastRunTime() (*time.Time, error) {
+	return s.lastRun, nil
+}
+
+func TestRecommendationWorkerImpl_ShouldRun_FollowsPublishedRuns(t *testing.T) {
+	service := &lastRunService{}
+	worker := NewRecommendationWorker(service, nil, time.Hour, logrus.New())
+
+	shouldRun, err := worker.ShouldRun(context.Background())
+	require.NoError(t, err)
+	assert.True(t, shouldRun)
+
+	old := time.Now().Add(-2 * time.Hour)
+	service.lastRun = &old
+	shouldRun, err = worker.ShouldRun(context.Background())
+	require.NoError(t, err)
+	assert.True(t, shouldRun)
 
+	// A run published since, manual or not, defers the next
+	recent := time.Now().Add(-10 * time.Minute)
+	service.lastRun = &recent
 	lastRun, err := worker.GetLastRunTime()
-	assert.NoError(t, err)
-	assert.Nil(t, lastRun)
-
-	now := time.Now()
-	worker.lastRunTime = &now
-	lastRun, err = worker.GetLastRunTime()
-	assert.NoError(t, err)
-	assert.Equal(t, now, *lastRun)
+	require.NoError(t, err)
+	assert.Equal(t, recent, *lastRun)
+	shouldRun, err = worker.ShouldRun(context.Background())
+	require.NoError(t, err)
+	assert.False(t, shouldRun)
 }
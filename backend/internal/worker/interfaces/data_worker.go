@@ -3,11 +3,18 @@ package interfaces
 import (
 	"context"
 	"time"
+
+	"github.com/valeriapadilla/stock-insights/internal/model"
 )
 
 type DataWorker interface {
-	FetchAndProcessStocks(ctx context.Context) error
+	FetchAndProcessStocks(ctx context.Context, progress model.ProgressFunc) ([]*model.IngestionResult, error)
+	FetchAndProcessSource(ctx context.Context, source string, progress model.ProgressFunc) (*model.IngestionResult, error)
+	GetSources() ([]*model.IngestionSource, error)
+	StoreStocks(ctx context.Context, source string, stocks []model.Stock) (*model.BulkUpsertResult, error)
+	PreviewStocks(ctx context.Context, source string, stocks []model.Stock) (*model.BulkUpsertResult, error)
 	HealthCheck(ctx context.Context) error
-	GetLastRunTime(ctx context.Context) (*time.Time, error)
-	ShouldRun(ctx context.Context) (bool, error)
+	GetSourcesHealth(ctx context.Context) []*model.SourceHealth
+	GetLastRunTime(ctx context.Context, source string) (*time.Time, error)
+	ShouldRun(ctx context.Context, source string) (bool, error)
 }
@@ -8,5 +8,6 @@ import (
 type RecommendationWorker interface {
 	RunDailyRecommendations(ctx context.Context) error
 	GetLastRunTime() (*time.Time, error)
+	ShouldRun(ctx context.Context) (bool, error)
 	IsRunning() bool
 }